@@ -5,8 +5,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"todo-cli/model"
@@ -32,10 +35,19 @@ var (
 	ErrInvalidSessionFocus = errors.New("invalid session focus")
 )
 
-// Service holds domain rules and in-memory state.
+// Service holds domain rules and in-memory state. It is safe for
+// concurrent use: exported methods take mu, unexported helpers expect the
+// caller to hold it. Returned values are copies and never alias the state.
 type Service struct {
-	state model.AppState
-	undo  []model.AppState
+	mu      sync.RWMutex
+	state   model.AppState
+	undo    []model.AppState
+	pending []Event
+	events  events
+	preHook PreHook
+	// rev counts undoable changes, so a change proposed to the pre-hook
+	// can tell whether the state moved on while the hook ran.
+	rev uint64
 }
 
 // NewService creates a service with a copy of the provided state.
@@ -46,21 +58,33 @@ func NewService(state model.AppState) *Service {
 
 // State returns a copy of current state.
 func (s *Service) State() model.AppState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return copyState(s.state)
 }
 
 // Lists returns all lists as a copy.
 func (s *Service) Lists() []model.List {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	lists := make([]model.List, len(s.state.Lists))
-	copy(lists, s.state.Lists)
+	for i, l := range s.state.Lists {
+		lists[i] = cloneList(l)
+	}
 	return lists
 }
 
 // GetList returns a list by id.
 func (s *Service) GetList(id string) (model.List, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.getList(id)
+}
+
+func (s *Service) getList(id string) (model.List, error) {
 	for _, l := range s.state.Lists {
 		if l.ID == id {
-			return l, nil
+			return cloneList(l), nil
 		}
 	}
 	return model.List{}, ErrListNotFound
@@ -69,10 +93,18 @@ func (s *Service) GetList(id string) (model.List, error) {
 // Tasks returns tasks for a list sorted by manual position.
 // If listID is empty, returns all tasks sorted by list then position.
 func (s *Service) Tasks(listID string) []model.Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tasks(listID)
+}
+
+func (s *Service) tasks(listID string) []model.Task {
 	listID = strings.TrimSpace(listID)
 	if listID == "" {
 		out := make([]model.Task, len(s.state.Tasks))
-		copy(out, s.state.Tasks)
+		for i, t := range s.state.Tasks {
+			out[i] = cloneTask(t)
+		}
 		sortTasks(out, "")
 		return out
 	}
@@ -80,7 +112,7 @@ func (s *Service) Tasks(listID string) []model.Task {
 	out := make([]model.Task, 0)
 	for _, t := range s.state.Tasks {
 		if t.ListID == listID {
-			out = append(out, t)
+			out = append(out, cloneTask(t))
 		}
 	}
 	sortTasks(out, listID)
@@ -89,9 +121,11 @@ func (s *Service) Tasks(listID string) []model.Task {
 
 // GetTask returns a task by id.
 func (s *Service) GetTask(id string) (model.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	for _, t := range s.state.Tasks {
 		if t.ID == id {
-			return t, nil
+			return cloneTask(t), nil
 		}
 	}
 	return model.Task{}, ErrTaskNotFound
@@ -110,8 +144,17 @@ func (s *Service) CreateList(name, color string) (model.List, error) {
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
-	s.pushUndo()
-	s.state.Lists = append(s.state.Lists, list)
+	err := s.mutate(func() (Event, error) {
+		return ListCreated{List: list}, nil
+	}, func(Event) error {
+		s.pushUndo()
+		s.state.Lists = append(s.state.Lists, list)
+		s.emit(ListCreated{List: list})
+		return nil
+	})
+	if err != nil {
+		return model.List{}, err
+	}
 	return list, nil
 }
 
@@ -120,37 +163,59 @@ func (s *Service) UpdateList(id, name, color string) (model.List, error) {
 	if name == "" {
 		return model.List{}, ErrInvalidName
 	}
-	for i := range s.state.Lists {
-		if s.state.Lists[i].ID == id {
-			s.pushUndo()
-			s.state.Lists[i].Name = name
-			s.state.Lists[i].Color = strings.TrimSpace(color)
-			s.state.Lists[i].UpdatedAt = time.Now().UTC()
-			return s.state.Lists[i], nil
+	var (
+		idx           int
+		before, after model.List
+	)
+	err := s.mutate(func() (Event, error) {
+		if idx = s.listIndex(id); idx < 0 {
+			return nil, ErrListNotFound
 		}
+		before = cloneList(s.state.Lists[idx])
+		after = cloneList(before)
+		after.Name = name
+		after.Color = strings.TrimSpace(color)
+		after.UpdatedAt = time.Now().UTC()
+		return ListUpdated{Before: before, After: cloneList(after)}, nil
+	}, func(Event) error {
+		s.pushUndo()
+		s.state.Lists[idx] = cloneList(after)
+		s.emit(ListUpdated{Before: before, After: cloneList(after)})
+		return nil
+	})
+	if err != nil {
+		return model.List{}, err
 	}
-	return model.List{}, ErrListNotFound
+	return after, nil
 }
 
 func (s *Service) DeleteList(id string) error {
-	for i := range s.state.Lists {
-		if s.state.Lists[i].ID != id {
-			continue
+	var (
+		idx  int
+		ev   ListDeleted
+		kept []model.Task
+	)
+	return s.mutate(func() (Event, error) {
+		if idx = s.listIndex(id); idx < 0 {
+			return nil, ErrListNotFound
 		}
-
-		s.pushUndo()
-		s.state.Lists = append(s.state.Lists[:i], s.state.Lists[i+1:]...)
-
-		keptTasks := make([]model.Task, 0, len(s.state.Tasks))
+		ev = ListDeleted{List: cloneList(s.state.Lists[idx])}
+		kept = make([]model.Task, 0, len(s.state.Tasks))
 		for _, t := range s.state.Tasks {
 			if t.ListID != id {
-				keptTasks = append(keptTasks, t)
+				kept = append(kept, t)
+			} else {
+				ev.Tasks = append(ev.Tasks, cloneTask(t))
 			}
 		}
-		s.state.Tasks = keptTasks
+		return ev, nil
+	}, func(Event) error {
+		s.pushUndo()
+		s.state.Lists = slices.Delete(s.state.Lists, idx, idx+1)
+		s.state.Tasks = kept
+		s.emit(ev)
 		return nil
-	}
-	return ErrListNotFound
+	})
 }
 
 func (s *Service) MoveListUp(listID string) (model.List, error) {
@@ -162,34 +227,48 @@ func (s *Service) MoveListDown(listID string) (model.List, error) {
 }
 
 func (s *Service) moveList(listID string, direction int) (model.List, error) {
-	idx := -1
-	for i := range s.state.Lists {
-		if s.state.Lists[i].ID == listID {
-			idx = i
-			break
+	var (
+		idx, target int
+		moved       model.List
+	)
+	now := time.Now().UTC()
+	err := s.mutate(func() (Event, error) {
+		if idx = s.listIndex(listID); idx < 0 {
+			return nil, ErrListNotFound
 		}
+		target = idx + direction
+		if target < 0 {
+			return nil, ErrListAlreadyAtTop
+		}
+		if target >= len(s.state.Lists) {
+			return nil, ErrListAlreadyAtBottom
+		}
+		moved = cloneList(s.state.Lists[idx])
+		moved.UpdatedAt = now
+		return ListMoved{List: cloneList(moved), From: idx, To: target}, nil
+	}, func(Event) error {
+		s.pushUndo()
+		s.state.Lists[idx], s.state.Lists[target] = s.state.Lists[target], s.state.Lists[idx]
+		s.state.Lists[idx].UpdatedAt = now
+		s.state.Lists[target].UpdatedAt = now
+		moved = cloneList(s.state.Lists[target])
+		s.emit(ListMoved{List: cloneList(moved), From: idx, To: target})
+		return nil
+	})
+	if err != nil {
+		return model.List{}, err
 	}
-	if idx == -1 {
-		return model.List{}, ErrListNotFound
-	}
-
-	target := idx + direction
-	if target < 0 {
-		return model.List{}, ErrListAlreadyAtTop
-	}
-	if target >= len(s.state.Lists) {
-		return model.List{}, ErrListAlreadyAtBottom
-	}
-
-	s.pushUndo()
-	s.state.Lists[idx], s.state.Lists[target] = s.state.Lists[target], s.state.Lists[idx]
-	now := time.Now().UTC()
-	s.state.Lists[idx].UpdatedAt = now
-	s.state.Lists[target].UpdatedAt = now
-	return s.state.Lists[target], nil
+	return moved, nil
 }
 
 func (s *Service) CreateTask(listID, text string) (model.Task, error) {
+	return s.CreateTaskWith(listID, text, TaskPatch{})
+}
+
+// CreateTaskWith creates a task and applies p to it as a single change, so
+// a task can be created already done, prioritized or in another column.
+// The pre-hook sees the task as it will be created.
+func (s *Service) CreateTaskWith(listID, text string, p TaskPatch) (model.Task, error) {
 	listID = strings.TrimSpace(listID)
 	if listID == "" {
 		return model.Task{}, ErrInvalidListRef
@@ -198,29 +277,57 @@ func (s *Service) CreateTask(listID, text string) (model.Task, error) {
 	if text == "" {
 		return model.Task{}, ErrInvalidTask
 	}
-	if !s.hasList(listID) {
-		return model.Task{}, ErrListNotFound
-	}
-	now := time.Now().UTC()
-	insertPos := s.nextTodoInsertPosition(listID)
-	task := model.Task{
-		ID:        newID(),
-		ListID:    listID,
-		Text:      text,
-		Done:      false,
-		Priority:  model.PriorityNone,
-		Position:  insertPos,
-		CreatedAt: now,
-		UpdatedAt: now,
-	}
-	s.pushUndo()
-	for i := range s.state.Tasks {
-		if s.state.Tasks[i].ListID == listID && s.state.Tasks[i].Position >= insertPos {
-			s.state.Tasks[i].Position++
+	// O texto vem do argumento, já validado acima.
+	p.Text = nil
+	var (
+		task      model.Task
+		insertPos int
+	)
+	err := s.mutate(func() (Event, error) {
+		if !s.hasList(listID) {
+			return nil, ErrListNotFound
+		}
+		now := time.Now().UTC()
+		insertPos = s.nextTodoInsertPosition(listID)
+		task = model.Task{
+			ID:        newID(),
+			ListID:    listID,
+			Text:      text,
+			Done:      false,
+			Status:    s.statusesFor(listID)[0],
+			Priority:  model.PriorityNone,
+			Position:  insertPos,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if _, err := s.applyPatch(&task, p, now); err != nil {
+			return nil, err
+		}
+		if task.Done {
+			insertPos = s.nextPositionInList(listID)
+			task.Position = insertPos
+		}
+		return TaskCreated{Task: cloneTask(task)}, nil
+	}, func(ev Event) error {
+		amended, err := amendTask(task, ev)
+		if err != nil {
+			return err
+		}
+		s.pushUndo()
+		for i := range s.state.Tasks {
+			if s.state.Tasks[i].ListID == listID && s.state.Tasks[i].Position >= insertPos {
+				s.state.Tasks[i].Position++
+			}
 		}
+		s.state.Tasks = append(s.state.Tasks, amended)
+		s.normalizePositionsForList(listID)
+		task = cloneTask(s.state.Tasks[len(s.state.Tasks)-1])
+		s.emit(TaskCreated{Task: cloneTask(task)})
+		return nil
+	})
+	if err != nil {
+		return model.Task{}, err
 	}
-	s.state.Tasks = append(s.state.Tasks, task)
-	s.normalizePositionsForList(listID)
 	return task, nil
 }
 
@@ -229,74 +336,148 @@ func (s *Service) UpdateTask(id, text string) (model.Task, error) {
 	if text == "" {
 		return model.Task{}, ErrInvalidTask
 	}
-	for i := range s.state.Tasks {
-		if s.state.Tasks[i].ID == id {
-			s.pushUndo()
-			s.state.Tasks[i].Text = text
-			s.state.Tasks[i].UpdatedAt = time.Now().UTC()
-			return s.state.Tasks[i], nil
+	return s.changeTask(id, func(t *model.Task, now time.Time) (bool, error) {
+		t.Text = text
+		t.UpdatedAt = now
+		return true, nil
+	})
+}
+
+// changeTask edits one task as a single change. edit works on a copy and
+// reports whether it changed anything. Completing or reopening the task
+// emits TaskToggled and sends a completed task to the end of its list;
+// any other edit emits TaskUpdated.
+func (s *Service) changeTask(taskID string, edit func(t *model.Task, now time.Time) (bool, error)) (model.Task, error) {
+	var (
+		i             int
+		before, after model.Task
+	)
+	err := s.mutate(func() (Event, error) {
+		if i = s.taskIndex(taskID); i < 0 {
+			return nil, ErrTaskNotFound
+		}
+		before = cloneTask(s.state.Tasks[i])
+		after = cloneTask(before)
+		changed, err := edit(&after, time.Now().UTC())
+		if err != nil || !changed {
+			after = before
+			return nil, err
+		}
+		if after.Done != before.Done {
+			return TaskToggled{Before: before, After: cloneTask(after)}, nil
+		}
+		return TaskUpdated{Before: before, After: cloneTask(after)}, nil
+	}, func(ev Event) error {
+		amended, err := amendTask(after, ev)
+		if err != nil {
+			return err
+		}
+		s.pushUndo()
+		s.state.Tasks[i] = amended
+		toggled := amended.Done != before.Done
+		if toggled && amended.Done {
+			// Concluídas vão para o fim da lista.
+			s.state.Tasks[i].Position = s.nextPositionInList(amended.ListID)
+			s.normalizePositionsForList(amended.ListID)
+		}
+		after = cloneTask(s.state.Tasks[i])
+		if toggled {
+			s.emit(TaskToggled{Before: before, After: cloneTask(after)})
+		} else {
+			s.emit(TaskUpdated{Before: before, After: cloneTask(after)})
 		}
+		return nil
+	})
+	if err != nil {
+		return model.Task{}, err
 	}
-	return model.Task{}, ErrTaskNotFound
+	return after, nil
 }
 
 func (s *Service) DeleteTask(id string) error {
-	for i := range s.state.Tasks {
-		if s.state.Tasks[i].ID != id {
-			continue
+	var (
+		i       int
+		deleted model.Task
+	)
+	return s.mutate(func() (Event, error) {
+		if i = s.taskIndex(id); i < 0 {
+			return nil, ErrTaskNotFound
 		}
-		listID := s.state.Tasks[i].ListID
+		deleted = cloneTask(s.state.Tasks[i])
+		return TaskDeleted{Task: deleted}, nil
+	}, func(Event) error {
 		s.pushUndo()
-		s.state.Tasks = append(s.state.Tasks[:i], s.state.Tasks[i+1:]...)
-		s.normalizePositionsForList(listID)
+		s.state.Tasks = slices.Delete(s.state.Tasks, i, i+1)
+		s.normalizePositionsForList(deleted.ListID)
+		s.emit(TaskDeleted{Task: deleted})
 		return nil
-	}
-	return ErrTaskNotFound
+	})
 }
 
+// ToggleDone completes an open task, moving it to the last board column,
+// or reopens a done one in the first column.
 func (s *Service) ToggleDone(taskID string) (model.Task, error) {
-	for i := range s.state.Tasks {
-		if s.state.Tasks[i].ID == taskID {
-			s.pushUndo()
-			s.state.Tasks[i].Done = !s.state.Tasks[i].Done
-			s.state.Tasks[i].UpdatedAt = time.Now().UTC()
-			if s.state.Tasks[i].Done {
-				listID := s.state.Tasks[i].ListID
-				maxPos := 0
-				for j := range s.state.Tasks {
-					if j == i || s.state.Tasks[j].ListID != listID {
-						continue
-					}
-					if s.state.Tasks[j].Position > maxPos {
-						maxPos = s.state.Tasks[j].Position
-					}
-				}
-				s.state.Tasks[i].Position = maxPos + 1
-				s.normalizePositionsForList(listID)
-			}
-			return s.state.Tasks[i], nil
+	return s.changeTask(taskID, func(t *model.Task, now time.Time) (bool, error) {
+		statuses := s.statusesFor(t.ListID)
+		status := statuses[len(statuses)-1]
+		if t.Done {
+			status = statuses[0]
 		}
-	}
-	return model.Task{}, ErrTaskNotFound
+		return s.applyPatch(t, TaskPatch{Status: &status}, now)
+	})
 }
 
 func (s *Service) SetTaskPriority(taskID string, priority model.Priority) (model.Task, error) {
 	if priority < model.PriorityNone || priority > model.PriorityHigh {
 		return model.Task{}, fmt.Errorf("%w: %d", ErrInvalidPriority, priority)
 	}
+	return s.changeTask(taskID, func(t *model.Task, now time.Time) (bool, error) {
+		if t.Priority == priority {
+			return false, nil
+		}
+		t.Priority = priority
+		t.UpdatedAt = now
+		return true, nil
+	})
+}
 
-	for i := range s.state.Tasks {
-		if s.state.Tasks[i].ID == taskID {
-			if s.state.Tasks[i].Priority == priority {
-				return s.state.Tasks[i], nil
-			}
-			s.pushUndo()
-			s.state.Tasks[i].Priority = priority
-			s.state.Tasks[i].UpdatedAt = time.Now().UTC()
-			return s.state.Tasks[i], nil
+// SetTaskPinned adds a task to or removes it from the "today" agenda.
+func (s *Service) SetTaskPinned(taskID string, pinned bool) (model.Task, error) {
+	return s.changeTask(taskID, func(t *model.Task, now time.Time) (bool, error) {
+		if t.Pinned == pinned {
+			return false, nil
 		}
+		t.Pinned = pinned
+		t.UpdatedAt = now
+		return true, nil
+	})
+}
+
+// SetTaskDue sets the due date of a task, stored in Extra[model.ExtraDue]
+// so the import/export formats carry it. A zero due clears it.
+func (s *Service) SetTaskDue(taskID string, due time.Time) (model.Task, error) {
+	value := ""
+	if !due.IsZero() {
+		value = due.Format(model.DateLayout)
 	}
-	return model.Task{}, ErrTaskNotFound
+	return s.changeTask(taskID, func(t *model.Task, now time.Time) (bool, error) {
+		if t.Extra[model.ExtraDue] == value {
+			return false, nil
+		}
+		if value == "" {
+			delete(t.Extra, model.ExtraDue)
+		} else {
+			if t.Extra == nil {
+				t.Extra = map[string]string{}
+			}
+			t.Extra[model.ExtraDue] = value
+		}
+		if len(t.Extra) == 0 {
+			t.Extra = nil
+		}
+		t.UpdatedAt = now
+		return true, nil
+	})
 }
 
 func (s *Service) MoveTaskUp(taskID string) (model.Task, error) {
@@ -308,123 +489,169 @@ func (s *Service) MoveTaskDown(taskID string) (model.Task, error) {
 }
 
 func (s *Service) moveTask(taskID string, direction int) (model.Task, error) {
-	idx := -1
-	for i := range s.state.Tasks {
-		if s.state.Tasks[i].ID == taskID {
-			idx = i
-			break
+	return s.relocate(taskID, func(i int) (string, int, error) {
+		listID := s.state.Tasks[i].ListID
+		position := slices.Index(s.taskIndexesForList(listID), i)
+		target := position + direction
+		if target < 0 {
+			return "", 0, ErrTaskAlreadyAtTop
 		}
-	}
-	if idx == -1 {
-		return model.Task{}, ErrTaskNotFound
-	}
-
-	listID := s.state.Tasks[idx].ListID
-	ordered := s.taskIndexesForList(listID)
-	position := -1
-	for i, taskIdx := range ordered {
-		if s.state.Tasks[taskIdx].ID == taskID {
-			position = i
-			break
+		if target >= len(s.taskIndexesForList(listID)) {
+			return "", 0, ErrTaskAlreadyAtBottom
 		}
-	}
-	if position == -1 {
-		return model.Task{}, ErrTaskNotFound
-	}
-
-	targetPos := position + direction
-	if targetPos < 0 {
-		return model.Task{}, ErrTaskAlreadyAtTop
-	}
-	if targetPos >= len(ordered) {
-		return model.Task{}, ErrTaskAlreadyAtBottom
-	}
-
-	s.pushUndo()
-	a := ordered[position]
-	b := ordered[targetPos]
-	s.state.Tasks[a].Position, s.state.Tasks[b].Position = s.state.Tasks[b].Position, s.state.Tasks[a].Position
-	now := time.Now().UTC()
-	s.state.Tasks[a].UpdatedAt = now
-	s.state.Tasks[b].UpdatedAt = now
-	s.normalizePositionsForList(listID)
+		return listID, target, nil
+	})
+}
 
-	for i := range s.state.Tasks {
-		if s.state.Tasks[i].ID == taskID {
-			return s.state.Tasks[i], nil
-		}
-	}
-	return model.Task{}, ErrTaskNotFound
+// MoveTaskTo moves a task to position (0-based) in its list, shifting the
+// tasks in between. Positions past either end are clamped; moving a task to
+// where it already is changes nothing.
+func (s *Service) MoveTaskTo(taskID string, position int) (model.Task, error) {
+	return s.relocate(taskID, func(i int) (string, int, error) {
+		return s.state.Tasks[i].ListID, position, nil
+	})
 }
 
-func (s *Service) ClearCompletedToArchive(listID string) (int, error) {
+// MoveTaskToList moves a task to another list, after that list's open tasks
+// (or at its end, if the task is done), in the matching board column.
+func (s *Service) MoveTaskToList(taskID, listID string) (model.Task, error) {
 	listID = strings.TrimSpace(listID)
-	if listID == "" {
-		return 0, ErrInvalidListRef
-	}
-
-	list, err := s.GetList(listID)
-	if err != nil {
-		return 0, err
-	}
+	return s.relocate(taskID, func(i int) (string, int, error) {
+		if !s.hasList(listID) {
+			return "", 0, ErrListNotFound
+		}
+		t := s.state.Tasks[i]
+		if t.ListID == listID {
+			return listID, t.Position - 1, nil
+		}
+		if t.Done {
+			return listID, s.nextPositionInList(listID) - 1, nil
+		}
+		return listID, s.nextTodoInsertPosition(listID) - 1, nil
+	})
+}
 
-	toArchive := make([]model.ArchivedCompletedTask, 0)
-	kept := make([]model.Task, 0, len(s.state.Tasks))
+// relocate moves task taskID to the list and 0-based index target picks
+// for it, as a single change emitting TaskMoved. The pre-hook may send the
+// task to another list or position.
+func (s *Service) relocate(taskID string, target func(i int) (listID string, index int, err error)) (model.Task, error) {
+	var (
+		i             int
+		before, after model.Task
+	)
 	now := time.Now().UTC()
-
-	for _, t := range s.state.Tasks {
-		if t.ListID == listID && t.Done {
-			doneAt := t.UpdatedAt
-			if doneAt.IsZero() {
-				doneAt = now
+	err := s.mutate(func() (Event, error) {
+		if i = s.taskIndex(taskID); i < 0 {
+			return nil, ErrTaskNotFound
+		}
+		listID, index, err := target(i)
+		if err != nil {
+			return nil, err
+		}
+		before = cloneTask(s.state.Tasks[i])
+		after = cloneTask(before)
+		others := len(s.taskIndexesForList(listID))
+		if listID == before.ListID {
+			others--
+		}
+		after.ListID = listID
+		after.Position = max(0, min(index, others)) + 1
+		if after.ListID == before.ListID && after.Position == before.Position {
+			return nil, nil
+		}
+		after.UpdatedAt = now
+		syncStatus(&after, s.statusesFor(listID))
+		return TaskMoved{Before: before, After: cloneTask(after)}, nil
+	}, func(ev Event) error {
+		amended, err := amendTask(after, ev)
+		if err != nil {
+			return err
+		}
+		if moved, ok := ev.(TaskMoved); ok {
+			if !s.hasList(moved.After.ListID) {
+				return ErrListNotFound
 			}
-			toArchive = append(toArchive, model.ArchivedCompletedTask{
-				ID:           newID(),
-				TaskText:     t.Text,
-				OriginListID: list.ID,
-				OriginList:   list.Name,
-				Priority:     t.Priority,
-				DoneAt:       doneAt,
-				ArchivedAt:   now,
-			})
-			continue
+			amended.ListID = moved.After.ListID
+			amended.Position = moved.After.Position
 		}
-		kept = append(kept, t)
+		s.pushUndo()
+		s.placeTask(i, amended.ListID, amended.Position-1, now)
+		t := &s.state.Tasks[i]
+		t.Text, t.Priority, t.Pinned, t.Extra = amended.Text, amended.Priority, amended.Pinned, amended.Extra
+		after = cloneTask(*t)
+		s.emit(TaskMoved{Before: before, After: cloneTask(after)})
+		return nil
+	})
+	if err != nil {
+		return model.Task{}, err
 	}
+	return after, nil
+}
 
-	if len(toArchive) == 0 {
-		return 0, ErrNoCompletedToClear
+// placeTask puts task i at index (0-based, clamped) among the tasks of
+// listID and numbers that list from 1, as well as the list it left. Tasks
+// whose position changes get UpdatedAt = now; a task that changes lists
+// takes the matching board column.
+func (s *Service) placeTask(i int, listID string, index int, now time.Time) {
+	from := s.state.Tasks[i].ListID
+	ordered := slices.DeleteFunc(s.taskIndexesForList(listID), func(j int) bool { return j == i })
+	ordered = slices.Insert(ordered, max(0, min(index, len(ordered))), i)
+	if t := &s.state.Tasks[i]; from != listID {
+		t.ListID = listID
+		t.UpdatedAt = now
+		syncStatus(t, s.statusesFor(listID))
+	}
+	for pos, j := range ordered {
+		if s.state.Tasks[j].Position != pos+1 {
+			s.state.Tasks[j].Position = pos + 1
+			s.state.Tasks[j].UpdatedAt = now
+		}
 	}
+	if from != listID {
+		s.normalizePositionsForList(from)
+	}
+}
 
-	s.pushUndo()
-	s.state.Tasks = kept
-	s.normalizePositionsForList(listID)
-	s.state.ArchivedCompleted = append(s.state.ArchivedCompleted, toArchive...)
-	return len(toArchive), nil
+func (s *Service) ClearCompletedToArchive(listID string) (int, error) {
+	return s.archive(listID, false)
 }
 
 func (s *Service) ArchiveAllToArchive(listID string) (int, error) {
+	return s.archive(listID, true)
+}
+
+// archive moves the done tasks of a list, or all of them, to the archive.
+func (s *Service) archive(listID string, all bool) (int, error) {
 	listID = strings.TrimSpace(listID)
 	if listID == "" {
 		return 0, ErrInvalidListRef
 	}
-
-	list, err := s.GetList(listID)
-	if err != nil {
-		return 0, err
-	}
-
-	toArchive := make([]model.ArchivedCompletedTask, 0)
-	kept := make([]model.Task, 0, len(s.state.Tasks))
-	now := time.Now().UTC()
-
-	for _, t := range s.state.Tasks {
-		if t.ListID == listID {
-			doneAt := t.UpdatedAt
+	var (
+		ev   Archived
+		kept []model.Task
+	)
+	err := s.mutate(func() (Event, error) {
+		list, err := s.getList(listID)
+		if err != nil {
+			return nil, err
+		}
+		ev = Archived{ListID: listID, All: all, Entries: make([]model.ArchivedCompletedTask, 0)}
+		kept = make([]model.Task, 0, len(s.state.Tasks))
+		now := time.Now().UTC()
+		for _, t := range s.state.Tasks {
+			if t.ListID != listID || (!all && !t.Done) {
+				kept = append(kept, t)
+				continue
+			}
+			doneAt := t.DoneAt
+			if doneAt.IsZero() {
+				doneAt = t.UpdatedAt
+			}
 			if doneAt.IsZero() || !t.Done {
 				doneAt = now
 			}
-			toArchive = append(toArchive, model.ArchivedCompletedTask{
+			ev.Tasks = append(ev.Tasks, cloneTask(t))
+			ev.Entries = append(ev.Entries, model.ArchivedCompletedTask{
 				ID:           newID(),
 				TaskText:     t.Text,
 				OriginListID: list.ID,
@@ -433,20 +660,26 @@ func (s *Service) ArchiveAllToArchive(listID string) (int, error) {
 				DoneAt:       doneAt,
 				ArchivedAt:   now,
 			})
-			continue
 		}
-		kept = append(kept, t)
-	}
-
-	if len(toArchive) == 0 {
-		return 0, ErrNoTasksInList
+		if len(ev.Entries) == 0 {
+			if all {
+				return nil, ErrNoTasksInList
+			}
+			return nil, ErrNoCompletedToClear
+		}
+		return ev, nil
+	}, func(Event) error {
+		s.pushUndo()
+		s.state.Tasks = kept
+		s.normalizePositionsForList(listID)
+		s.state.ArchivedCompleted = append(s.state.ArchivedCompleted, ev.Entries...)
+		s.emit(ev)
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-
-	s.pushUndo()
-	s.state.Tasks = kept
-	s.normalizePositionsForList(listID)
-	s.state.ArchivedCompleted = append(s.state.ArchivedCompleted, toArchive...)
-	return len(toArchive), nil
+	return len(ev.Entries), nil
 }
 
 func (s *Service) DeleteAllTasks(listID string) (int, error) {
@@ -454,36 +687,51 @@ func (s *Service) DeleteAllTasks(listID string) (int, error) {
 	if listID == "" {
 		return 0, ErrInvalidListRef
 	}
-	if !s.hasList(listID) {
-		return 0, ErrListNotFound
-	}
-
-	kept := make([]model.Task, 0, len(s.state.Tasks))
-	removed := 0
-	for _, t := range s.state.Tasks {
-		if t.ListID == listID {
-			removed++
-			continue
+	var (
+		ev   TasksCleared
+		kept []model.Task
+	)
+	err := s.mutate(func() (Event, error) {
+		if !s.hasList(listID) {
+			return nil, ErrListNotFound
 		}
-		kept = append(kept, t)
-	}
-	if removed == 0 {
-		return 0, ErrNoTasksInList
+		ev = TasksCleared{ListID: listID}
+		kept = make([]model.Task, 0, len(s.state.Tasks))
+		for _, t := range s.state.Tasks {
+			if t.ListID == listID {
+				ev.Tasks = append(ev.Tasks, cloneTask(t))
+				continue
+			}
+			kept = append(kept, t)
+		}
+		if len(ev.Tasks) == 0 {
+			return nil, ErrNoTasksInList
+		}
+		return ev, nil
+	}, func(Event) error {
+		s.pushUndo()
+		s.state.Tasks = kept
+		s.normalizePositionsForList(listID)
+		s.emit(ev)
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-
-	s.pushUndo()
-	s.state.Tasks = kept
-	s.normalizePositionsForList(listID)
-	return removed, nil
+	return len(ev.Tasks), nil
 }
 
 func (s *Service) ArchivedCompleted() []model.ArchivedCompletedTask {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	out := make([]model.ArchivedCompletedTask, len(s.state.ArchivedCompleted))
 	copy(out, s.state.ArchivedCompleted)
 	return out
 }
 
 func (s *Service) SetSessionContext(activeListID, focus string) error {
+	s.mu.Lock()
+	defer s.unlock()
 	focus = strings.TrimSpace(focus)
 	switch focus {
 	case "", model.SessionFocusLists, model.SessionFocusTasks:
@@ -493,6 +741,7 @@ func (s *Service) SetSessionContext(activeListID, focus string) error {
 	if activeListID != "" && !s.hasList(activeListID) {
 		activeListID = ""
 	}
+	defer s.emitSessionChange(s.session())
 	if focus != "" {
 		s.state.Metadata.Session.Focus = focus
 	}
@@ -501,12 +750,18 @@ func (s *Service) SetSessionContext(activeListID, focus string) error {
 }
 
 func (s *Service) MarkOnboardingSeen() {
+	s.mu.Lock()
+	defer s.unlock()
+	defer s.emitSessionChange(s.session())
 	s.state.Metadata.FirstRun = false
 }
 
 func (s *Service) SetFilter(filter model.Filter) error {
+	s.mu.Lock()
+	defer s.unlock()
 	switch filter {
 	case model.FilterAll, model.FilterTodo, model.FilterDone:
+		defer s.emitSessionChange(s.session())
 		s.state.Filter = filter
 		return nil
 	default:
@@ -515,12 +770,17 @@ func (s *Service) SetFilter(filter model.Filter) error {
 }
 
 func (s *Service) SetQuery(query string) {
+	s.mu.Lock()
+	defer s.unlock()
+	defer s.emitSessionChange(s.session())
 	s.state.Query = strings.TrimSpace(query)
 }
 
 func (s *Service) FilteredTasks() []model.Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	q := strings.ToLower(strings.TrimSpace(s.state.Query))
-	all := s.Tasks("")
+	all := s.tasks("")
 	out := make([]model.Task, 0, len(all))
 	for _, t := range all {
 		if !matchesFilter(s.state.Filter, t.Done) {
@@ -536,15 +796,31 @@ func (s *Service) FilteredTasks() []model.Task {
 
 // Undo reverts the latest mutable action from the undo stack.
 func (s *Service) Undo() error {
+	s.mu.Lock()
+	defer s.unlock()
 	if len(s.undo) == 0 {
 		return ErrNothingToUndo
 	}
 	last := s.undo[len(s.undo)-1]
 	s.undo = s.undo[:len(s.undo)-1]
+	s.rev++
+	before := s.state
 	s.state = copyState(last)
+	s.emit(Undone{Before: before, After: copyState(s.state)})
 	return nil
 }
 
+// ReplaceState swaps the whole state, e.g. when restoring a backup.
+// The previous state is pushed to the undo stack.
+func (s *Service) ReplaceState(state model.AppState) {
+	s.mu.Lock()
+	defer s.unlock()
+	s.pushUndo()
+	before := s.state
+	s.state = normalizeState(copyState(state))
+	s.emit(StateReplaced{Before: before, After: copyState(s.state)})
+}
+
 // UndoDelete is kept for compatibility with older callers.
 func (s *Service) UndoDelete() error {
 	return s.Undo()
@@ -582,6 +858,7 @@ func (s *Service) nextTodoInsertPosition(listID string) int {
 }
 
 func (s *Service) pushUndo() {
+	s.rev++
 	s.undo = append(s.undo, copyState(s.state))
 	if len(s.undo) > undoStackLimit {
 		s.undo = s.undo[len(s.undo)-undoStackLimit:]
@@ -670,6 +947,20 @@ func normalizeState(state model.AppState) model.AppState {
 		}
 	}
 
+	statuses := make(map[string][]string, len(state.Lists))
+	for i, l := range state.Lists {
+		// Colunas inválidas voltam ao padrão (cleanStatuses devolve nil).
+		state.Lists[i].Statuses, _ = cleanStatuses(l.Statuses)
+		statuses[l.ID] = state.Lists[i].BoardStatuses()
+	}
+	for i := range state.Tasks {
+		columns, ok := statuses[state.Tasks[i].ListID]
+		if !ok {
+			columns = model.DefaultStatuses()
+		}
+		syncStatus(&state.Tasks[i], columns)
+	}
+
 	if state.Metadata.Session.ActiveListID != "" && !listIDExists(state.Lists, state.Metadata.Session.ActiveListID) {
 		state.Metadata.Session.ActiveListID = ""
 	}
@@ -703,11 +994,23 @@ func sortTasks(tasks []model.Task, singleListID string) {
 	})
 }
 
+// cloneTask copies a task so callers cannot reach the state's Extra map.
+func cloneTask(t model.Task) model.Task {
+	if t.Extra != nil {
+		t.Extra = maps.Clone(t.Extra)
+	}
+	return t
+}
+
 func copyState(state model.AppState) model.AppState {
 	lists := make([]model.List, len(state.Lists))
-	copy(lists, state.Lists)
+	for i, l := range state.Lists {
+		lists[i] = cloneList(l)
+	}
 	tasks := make([]model.Task, len(state.Tasks))
-	copy(tasks, state.Tasks)
+	for i, t := range state.Tasks {
+		tasks[i] = cloneTask(t)
+	}
 	archived := make([]model.ArchivedCompletedTask, len(state.ArchivedCompleted))
 	copy(archived, state.ArchivedCompleted)
 
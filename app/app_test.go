@@ -2,7 +2,9 @@ package app
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"todo-cli/model"
 )
@@ -47,6 +49,37 @@ func TestSetTaskPriorityValidation(t *testing.T) {
 	}
 }
 
+func TestSetTaskPinnedAndDue(t *testing.T) {
+	svc := NewService(model.NewState())
+	list := mustCreateList(t, svc, "Inbox")
+	task := mustCreateTask(t, svc, list.ID, "Pagar conta")
+
+	task, err := svc.SetTaskPinned(task.ID, true)
+	if err != nil || !task.Pinned {
+		t.Fatalf("pin failed: %+v (%v)", task, err)
+	}
+	day := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	if task, err = svc.SetTaskDue(task.ID, day); err != nil {
+		t.Fatalf("set due failed: %v", err)
+	}
+	if due, ok := task.Due(); !ok || !due.Equal(day) || task.Extra[model.ExtraDue] != "2026-10-20" {
+		t.Fatalf("unexpected due date %+v", task.Extra)
+	}
+	if task, err = svc.SetTaskDue(task.ID, time.Time{}); err != nil || task.Extra != nil {
+		t.Fatalf("expected clearing the due date to drop Extra, got %+v (%v)", task.Extra, err)
+	}
+
+	if err := svc.Undo(); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+	if tasks := svc.Tasks(list.ID); tasks[0].Extra[model.ExtraDue] != "2026-10-20" || !tasks[0].Pinned {
+		t.Fatalf("expected undo to restore the due date, got %+v", tasks[0])
+	}
+	if _, err := svc.SetTaskPinned("missing", true); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
 func TestManualOrderingMoveWithLimits(t *testing.T) {
 	svc := NewService(model.NewState())
 	list := mustCreateList(t, svc, "Inbox")
@@ -78,6 +111,83 @@ func TestManualOrderingMoveWithLimits(t *testing.T) {
 	}
 }
 
+func TestMoveTaskToAbsolutePosition(t *testing.T) {
+	svc := NewService(model.NewState())
+	list := mustCreateList(t, svc, "Inbox")
+	a := mustCreateTask(t, svc, list.ID, "A")
+	_ = mustCreateTask(t, svc, list.ID, "B")
+	_ = mustCreateTask(t, svc, list.ID, "C")
+	d := mustCreateTask(t, svc, list.ID, "D")
+
+	if _, err := svc.MoveTaskTo(a.ID, 2); err != nil {
+		t.Fatalf("move to failed: %v", err)
+	}
+	if _, err := svc.MoveTaskTo(d.ID, -5); err != nil {
+		t.Fatalf("move to failed: %v", err)
+	}
+	var got []string
+	for _, task := range svc.Tasks(list.ID) {
+		got = append(got, task.Text)
+	}
+	if strings.Join(got, "") != "DBCA" {
+		t.Fatalf("unexpected order after moves: %v", got)
+	}
+
+	if err := svc.Undo(); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+	if tasks := svc.Tasks(list.ID); tasks[3].Text != "D" {
+		t.Fatalf("expected undo to restore D at the end, got %+v", tasks)
+	}
+	if _, err := svc.MoveTaskTo("missing", 0); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestMoveTaskToList(t *testing.T) {
+	svc := NewService(model.NewState())
+	inbox := mustCreateList(t, svc, "Inbox")
+	work := mustCreateList(t, svc, "Work")
+	a := mustCreateTask(t, svc, inbox.ID, "A")
+	b := mustCreateTask(t, svc, inbox.ID, "B")
+	_ = mustCreateTask(t, svc, work.ID, "X")
+	y := mustCreateTask(t, svc, work.ID, "Y")
+	if _, err := svc.ToggleDone(y.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if _, err := svc.SetListStatuses(work.ID, []string{"next", "shipped"}); err != nil {
+		t.Fatalf("set statuses failed: %v", err)
+	}
+
+	moved, err := svc.MoveTaskToList(a.ID, work.ID)
+	if err != nil {
+		t.Fatalf("move to list failed: %v", err)
+	}
+	if moved.ListID != work.ID || moved.Status != "next" || moved.Position != 2 {
+		t.Fatalf("expected A after the open tasks of Work, got %+v", moved)
+	}
+	var got []string
+	for _, task := range svc.Tasks(work.ID) {
+		got = append(got, task.Text)
+	}
+	if strings.Join(got, "") != "XAY" {
+		t.Fatalf("unexpected order in Work: %v", got)
+	}
+	if tasks := svc.Tasks(inbox.ID); len(tasks) != 1 || tasks[0].ID != b.ID || tasks[0].Position != 1 {
+		t.Fatalf("expected Inbox renumbered, got %+v", tasks)
+	}
+
+	if err := svc.Undo(); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+	if task, _ := svc.GetTask(a.ID); task.ListID != inbox.ID {
+		t.Fatalf("expected undo to bring A back, got %+v", task)
+	}
+	if _, err := svc.MoveTaskToList(a.ID, "missing"); !errors.Is(err, ErrListNotFound) {
+		t.Fatalf("expected ErrListNotFound, got %v", err)
+	}
+}
+
 func TestToggleDoneMovesTaskToEnd(t *testing.T) {
 	svc := NewService(model.NewState())
 	list := mustCreateList(t, svc, "Inbox")
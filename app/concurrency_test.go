@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"todo-cli/model"
+)
+
+// Run with -race: every public method is hammered from many goroutines.
+func TestServiceConcurrentUse(t *testing.T) {
+	svc := NewService(model.NewState())
+	home, _ := svc.CreateList("Casa", "green")
+	work, _ := svc.CreateList("Trabalho", "blue")
+	lists := []string{home.ID, work.ID}
+
+	const workers, rounds = 16, 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			listID := lists[w%len(lists)]
+			for i := 0; i < rounds; i++ {
+				task, err := svc.CreateTask(listID, fmt.Sprintf("w%d-%d", w, i))
+				if err != nil {
+					t.Errorf("create task failed: %v", err)
+					return
+				}
+				// Outras goroutines podem desfazer ou arquivar a tarefa; só
+				// interessam erros que não sejam "não encontrada".
+				_, _ = svc.ToggleDone(task.ID)
+				_, _ = svc.SetTaskPriority(task.ID, model.Priority(i%4))
+				_, _ = svc.MoveTaskUp(task.ID)
+				_, _ = svc.UpdateTask(task.ID, fmt.Sprintf("w%d-%d editada", w, i))
+
+				switch i % 10 {
+				case 3:
+					_ = svc.Undo()
+				case 5:
+					_, _ = svc.ClearCompletedToArchive(listID)
+				case 7:
+					_, _ = svc.Import(ImportBatch{Lists: []ImportList{{
+						List:  model.List{Name: "Importadas"},
+						Tasks: []model.Task{{Text: "importada", Extra: map[string]string{"w": fmt.Sprint(w)}}},
+					}}})
+				case 9:
+					_, _ = svc.UpdateList(listID, "Casa", "red")
+					_ = svc.SetFilter(model.FilterTodo)
+					svc.SetQuery("w")
+				}
+
+				for _, tk := range svc.Tasks("") {
+					if tk.Extra != nil {
+						tk.Extra["lido"] = "sim" // cópias: não pode afetar o estado
+					}
+				}
+				_ = svc.State()
+				_ = svc.Lists()
+				_ = svc.FilteredTasks()
+				_ = svc.ArchivedCompleted()
+				_, _ = svc.GetTask(task.ID)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	state := svc.State()
+	byList := map[string][]int{}
+	for _, task := range state.Tasks {
+		if task.Extra["lido"] != "" {
+			t.Fatalf("returned task copies must not alias the state: %+v", task)
+		}
+		byList[task.ListID] = append(byList[task.ListID], task.Position)
+	}
+	for listID, positions := range byList {
+		sort.Ints(positions)
+		for i, p := range positions {
+			if p != i+1 {
+				t.Fatalf("list %s has broken positions: %v", listID, positions)
+			}
+		}
+	}
+}
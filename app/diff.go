@@ -0,0 +1,93 @@
+package app
+
+import "todo-cli/model"
+
+// TaskChange pairs the two versions of a task present in both states.
+type TaskChange struct {
+	Before model.Task
+	After  model.Task
+}
+
+// StateDiff summarizes what changes when moving from one state to another.
+type StateDiff struct {
+	AddedLists     []model.List
+	RemovedLists   []model.List
+	RenamedLists   []model.List
+	AddedTasks     []model.Task
+	RemovedTasks   []model.Task
+	ChangedTasks   []TaskChange
+	ArchivedBefore int
+	ArchivedAfter  int
+}
+
+// Empty reports whether both states hold the same lists, tasks and archive size.
+func (d StateDiff) Empty() bool {
+	return len(d.AddedLists) == 0 &&
+		len(d.RemovedLists) == 0 &&
+		len(d.RenamedLists) == 0 &&
+		len(d.AddedTasks) == 0 &&
+		len(d.RemovedTasks) == 0 &&
+		len(d.ChangedTasks) == 0 &&
+		d.ArchivedBefore == d.ArchivedAfter
+}
+
+// DiffStates compares lists and tasks by id.
+// Position-only changes are ignored since they are renormalized on load.
+func DiffStates(from, to model.AppState) StateDiff {
+	diff := StateDiff{
+		ArchivedBefore: len(from.ArchivedCompleted),
+		ArchivedAfter:  len(to.ArchivedCompleted),
+	}
+
+	fromLists := make(map[string]model.List, len(from.Lists))
+	for _, l := range from.Lists {
+		fromLists[l.ID] = l
+	}
+	toLists := make(map[string]bool, len(to.Lists))
+	for _, l := range to.Lists {
+		toLists[l.ID] = true
+		old, ok := fromLists[l.ID]
+		switch {
+		case !ok:
+			diff.AddedLists = append(diff.AddedLists, l)
+		case old.Name != l.Name:
+			diff.RenamedLists = append(diff.RenamedLists, l)
+		}
+	}
+	for _, l := range from.Lists {
+		if !toLists[l.ID] {
+			diff.RemovedLists = append(diff.RemovedLists, l)
+		}
+	}
+
+	fromTasks := make(map[string]model.Task, len(from.Tasks))
+	for _, t := range from.Tasks {
+		fromTasks[t.ID] = t
+	}
+	toTasks := make(map[string]bool, len(to.Tasks))
+	for _, t := range to.Tasks {
+		toTasks[t.ID] = true
+		old, ok := fromTasks[t.ID]
+		if !ok {
+			diff.AddedTasks = append(diff.AddedTasks, t)
+			continue
+		}
+		if taskContentChanged(old, t) {
+			diff.ChangedTasks = append(diff.ChangedTasks, TaskChange{Before: old, After: t})
+		}
+	}
+	for _, t := range from.Tasks {
+		if !toTasks[t.ID] {
+			diff.RemovedTasks = append(diff.RemovedTasks, t)
+		}
+	}
+
+	return diff
+}
+
+func taskContentChanged(a, b model.Task) bool {
+	return a.Text != b.Text ||
+		a.Done != b.Done ||
+		a.Priority != b.Priority ||
+		a.ListID != b.ListID
+}
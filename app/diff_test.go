@@ -0,0 +1,68 @@
+package app
+
+import (
+	"testing"
+
+	"todo-cli/model"
+)
+
+func TestDiffStatesReportsListAndTaskChanges(t *testing.T) {
+	svc := NewService(model.NewState())
+	inbox := mustCreateList(t, svc, "Inbox")
+	keep := mustCreateTask(t, svc, inbox.ID, "Keep")
+	gone := mustCreateTask(t, svc, inbox.ID, "Gone")
+	before := svc.State()
+
+	if _, err := svc.UpdateList(inbox.ID, "Inbox 2", ""); err != nil {
+		t.Fatalf("update list failed: %v", err)
+	}
+	if _, err := svc.ToggleDone(keep.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if err := svc.DeleteTask(gone.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	work := mustCreateList(t, svc, "Work")
+	added := mustCreateTask(t, svc, work.ID, "New")
+
+	diff := DiffStates(before, svc.State())
+	if diff.Empty() {
+		t.Fatalf("expected non-empty diff")
+	}
+	if len(diff.AddedLists) != 1 || diff.AddedLists[0].ID != work.ID {
+		t.Fatalf("expected Work as added list, got %+v", diff.AddedLists)
+	}
+	if len(diff.RenamedLists) != 1 || diff.RenamedLists[0].Name != "Inbox 2" {
+		t.Fatalf("expected renamed inbox, got %+v", diff.RenamedLists)
+	}
+	if len(diff.AddedTasks) != 1 || diff.AddedTasks[0].ID != added.ID {
+		t.Fatalf("expected one added task, got %+v", diff.AddedTasks)
+	}
+	if len(diff.RemovedTasks) != 1 || diff.RemovedTasks[0].ID != gone.ID {
+		t.Fatalf("expected one removed task, got %+v", diff.RemovedTasks)
+	}
+	if len(diff.ChangedTasks) != 1 || !diff.ChangedTasks[0].After.Done {
+		t.Fatalf("expected toggled task as changed, got %+v", diff.ChangedTasks)
+	}
+
+	if !DiffStates(before, before).Empty() {
+		t.Fatalf("expected identical states to produce an empty diff")
+	}
+}
+
+func TestReplaceStateIsUndoable(t *testing.T) {
+	svc := NewService(model.NewState())
+	mustCreateList(t, svc, "Inbox")
+	before := svc.State()
+
+	svc.ReplaceState(model.NewState())
+	if got := len(svc.Lists()); got != 0 {
+		t.Fatalf("expected replaced empty state, got %d lists", got)
+	}
+	if err := svc.Undo(); err != nil {
+		t.Fatalf("undo replace failed: %v", err)
+	}
+	if got := svc.State(); len(got.Lists) != len(before.Lists) {
+		t.Fatalf("expected undo to bring back previous lists, got %+v", got.Lists)
+	}
+}
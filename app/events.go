@@ -0,0 +1,272 @@
+package app
+
+import (
+	"slices"
+	"sync"
+
+	"todo-cli/model"
+)
+
+// Event is a change notification from Service. Subscribers type-switch on
+// the concrete types below; Name gives a stable identifier for logs and
+// external consumers such as hook scripts.
+type Event interface {
+	Name() string
+}
+
+// ListCreated is emitted by CreateList.
+type ListCreated struct{ List model.List }
+
+// ListUpdated is emitted when a list is renamed, recolored or gets new
+// board columns.
+type ListUpdated struct{ Before, After model.List }
+
+// ListDeleted carries the list and the tasks removed with it.
+type ListDeleted struct {
+	List  model.List
+	Tasks []model.Task
+}
+
+// ListMoved reports a list changing its index in the sidebar order.
+type ListMoved struct {
+	List     model.List
+	From, To int
+}
+
+// TaskCreated is emitted by CreateTask.
+type TaskCreated struct{ Task model.Task }
+
+// TaskUpdated is emitted when a task's text, priority, status (without
+// completing or reopening it), pin or due date changes.
+type TaskUpdated struct{ Before, After model.Task }
+
+// TaskToggled is emitted when a task is completed or reopened.
+type TaskToggled struct{ Before, After model.Task }
+
+// TaskMoved is emitted when a task changes position within its list or
+// moves to another one.
+type TaskMoved struct{ Before, After model.Task }
+
+// TaskDeleted is emitted by DeleteTask.
+type TaskDeleted struct{ Task model.Task }
+
+// TasksCleared is emitted by DeleteAllTasks.
+type TasksCleared struct {
+	ListID string
+	Tasks  []model.Task
+}
+
+// Archived is emitted when tasks move to the archive. All is set for
+// ArchiveAllToArchive, which also archives open tasks.
+type Archived struct {
+	ListID  string
+	All     bool
+	Tasks   []model.Task
+	Entries []model.ArchivedCompletedTask
+}
+
+// Imported is emitted by Import.
+type Imported struct {
+	Result        ImportResult
+	Before, After model.AppState
+}
+
+// Undone is emitted by Undo.
+type Undone struct{ Before, After model.AppState }
+
+// StateReplaced is emitted by ReplaceState, e.g. when a backup is restored.
+type StateReplaced struct{ Before, After model.AppState }
+
+// SessionChanged reports a change to persisted UI context (filter, query,
+// active list, focus, onboarding). These changes are not undoable.
+type SessionChanged struct{ Before, After Session }
+
+// Session is the non-undoable part of the state.
+type Session struct {
+	Filter   model.Filter
+	Query    string
+	Context  model.SessionContext
+	FirstRun bool
+}
+
+func (ListCreated) Name() string    { return "list.created" }
+func (ListUpdated) Name() string    { return "list.updated" }
+func (ListDeleted) Name() string    { return "list.deleted" }
+func (ListMoved) Name() string      { return "list.moved" }
+func (TaskCreated) Name() string    { return "task.created" }
+func (TaskUpdated) Name() string    { return "task.updated" }
+func (TaskToggled) Name() string    { return "task.toggled" }
+func (TaskMoved) Name() string      { return "task.moved" }
+func (TaskDeleted) Name() string    { return "task.deleted" }
+func (TasksCleared) Name() string   { return "tasks.cleared" }
+func (Archived) Name() string       { return "archived" }
+func (Imported) Name() string       { return "imported" }
+func (Undone) Name() string         { return "undone" }
+func (StateReplaced) Name() string  { return "state.replaced" }
+func (SessionChanged) Name() string { return "session.changed" }
+
+// events fans out notifications. Mutators queue events while holding
+// Service.mu and deliver them after releasing it, so subscribers may call
+// back into the Service. Delivery is in mutation order: whichever goroutine
+// finds the queue idle drains it, including events queued meanwhile by
+// other goroutines or by the subscribers themselves.
+type events struct {
+	mu         sync.Mutex
+	subs       []subscription
+	nextID     int
+	stateSubs  int
+	queue      []queued
+	delivering bool
+}
+
+type subscription struct {
+	id int
+	fn func(Event, *model.AppState)
+}
+
+// queued pairs an event with the state right after the change that emitted
+// it; after is nil when no subscriber asked for it.
+type queued struct {
+	ev    Event
+	after *model.AppState
+}
+
+// Subscribe registers fn for every event and returns a function that
+// removes it. fn runs synchronously on the goroutine that drains the
+// queue (usually the one that made the change) and must not block for long.
+func (s *Service) Subscribe(fn func(Event)) (unsubscribe func()) {
+	return s.subscribe(func(ev Event, _ *model.AppState) { fn(ev) }, false)
+}
+
+// SubscribeWithState is like Subscribe, but fn also gets the state as it was
+// right after the change that emitted the event, for consumers that persist
+// each change on its own. Events emitted by the same call share one copy of
+// the state, which fn must not modify.
+func (s *Service) SubscribeWithState(fn func(ev Event, after model.AppState)) (unsubscribe func()) {
+	return s.subscribe(func(ev Event, after *model.AppState) {
+		// Eventos enfileirados antes da inscrição vêm sem estado.
+		if after != nil {
+			fn(ev, *after)
+		}
+	}, true)
+}
+
+func (s *Service) subscribe(fn func(Event, *model.AppState), withState bool) func() {
+	s.events.mu.Lock()
+	defer s.events.mu.Unlock()
+	s.events.nextID++
+	id := s.events.nextID
+	s.events.subs = append(s.events.subs, subscription{id: id, fn: fn})
+	if withState {
+		s.events.stateSubs++
+	}
+	return func() {
+		s.events.mu.Lock()
+		defer s.events.mu.Unlock()
+		n := len(s.events.subs)
+		s.events.subs = slices.DeleteFunc(s.events.subs, func(sub subscription) bool { return sub.id == id })
+		if withState && len(s.events.subs) < n {
+			s.events.stateSubs--
+		}
+	}
+}
+
+// SubscribeChan delivers events on a buffered channel, for consumers that
+// run their own loop. Events that do not fit in the buffer are dropped
+// rather than blocking the Service. unsubscribe closes the channel.
+func (s *Service) SubscribeChan(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	var (
+		mu     sync.Mutex
+		closed bool
+	)
+	remove := s.Subscribe(func(ev Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- ev:
+		default:
+		}
+	})
+	return ch, func() {
+		remove()
+		mu.Lock()
+		defer mu.Unlock()
+		if !closed {
+			closed = true
+			close(ch)
+		}
+	}
+}
+
+// emit queues an event; the caller holds s.mu.
+func (s *Service) emit(ev Event) {
+	s.pending = append(s.pending, ev)
+}
+
+// unlock releases s.mu and delivers the events queued while it was held.
+func (s *Service) unlock() {
+	pending := s.pending
+	s.pending = nil
+	if len(pending) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	s.events.mu.Lock()
+	if len(s.events.subs) > 0 {
+		var after *model.AppState
+		if s.events.stateSubs > 0 {
+			// Copiado ainda sob o lock: é o estado que estes eventos produziram.
+			state := copyState(s.state)
+			after = &state
+		}
+		for _, ev := range pending {
+			s.events.queue = append(s.events.queue, queued{ev: ev, after: after})
+		}
+	}
+	s.events.mu.Unlock()
+	s.mu.Unlock()
+	s.deliver()
+}
+
+func (s *Service) deliver() {
+	e := &s.events
+	e.mu.Lock()
+	if e.delivering {
+		e.mu.Unlock()
+		return
+	}
+	e.delivering = true
+	for len(e.queue) > 0 {
+		q := e.queue[0]
+		e.queue = e.queue[1:]
+		subs := slices.Clone(e.subs)
+		e.mu.Unlock()
+		for _, sub := range subs {
+			sub.fn(q.ev, q.after)
+		}
+		e.mu.Lock()
+	}
+	e.delivering = false
+	e.mu.Unlock()
+}
+
+// session captures the non-undoable part of the state; the caller holds s.mu.
+func (s *Service) session() Session {
+	return Session{
+		Filter:   s.state.Filter,
+		Query:    s.state.Query,
+		Context:  s.state.Metadata.Session,
+		FirstRun: s.state.Metadata.FirstRun,
+	}
+}
+
+// emitSessionChange queues SessionChanged when before differs from now.
+func (s *Service) emitSessionChange(before Session) {
+	if after := s.session(); after != before {
+		s.emit(SessionChanged{Before: before, After: after})
+	}
+}
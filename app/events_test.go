@@ -0,0 +1,137 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"todo-cli/model"
+)
+
+func TestSubscribeReceivesTypedEvents(t *testing.T) {
+	svc := NewService(model.NewState())
+	var got []Event
+	unsubscribe := svc.Subscribe(func(ev Event) { got = append(got, ev) })
+
+	list, _ := svc.CreateList("Casa", "green")
+	task, _ := svc.CreateTask(list.ID, "Pintar")
+	if _, err := svc.CreateTask("nope", "falha"); err == nil {
+		t.Fatal("expected error for unknown list")
+	}
+	toggled, _ := svc.ToggleDone(task.ID)
+	if _, err := svc.ClearCompletedToArchive(list.ID); err != nil {
+		t.Fatalf("archive failed: %v", err)
+	}
+	if err := svc.Undo(); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+	if err := svc.DeleteList(list.ID); err != nil {
+		t.Fatalf("delete list failed: %v", err)
+	}
+
+	names := make([]string, len(got))
+	for i, ev := range got {
+		names[i] = ev.Name()
+	}
+	want := []string{"list.created", "task.created", "task.toggled", "archived", "undone", "list.deleted"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	if ev := got[1].(TaskCreated); ev.Task.ID != task.ID {
+		t.Fatalf("unexpected TaskCreated: %+v", ev)
+	}
+	if ev := got[2].(TaskToggled); ev.Before.Done || !ev.After.Done || !reflect.DeepEqual(ev.After, toggled) {
+		t.Fatalf("expected before/after values, got %+v", ev)
+	}
+	if ev := got[3].(Archived); len(ev.Tasks) != 1 || len(ev.Entries) != 1 || ev.Entries[0].TaskText != "Pintar" || ev.All {
+		t.Fatalf("unexpected Archived: %+v", ev)
+	}
+	if ev := got[4].(Undone); len(ev.Before.Tasks) != 0 || len(ev.After.Tasks) != 1 {
+		t.Fatalf("unexpected Undone: %+v", ev)
+	}
+	if ev := got[5].(ListDeleted); ev.List.ID != list.ID || len(ev.Tasks) != 1 {
+		t.Fatalf("unexpected ListDeleted: %+v", ev)
+	}
+
+	unsubscribe()
+	if _, err := svc.CreateList("Outra", ""); err != nil {
+		t.Fatalf("create list failed: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected no events after unsubscribe, got %d", len(got))
+	}
+}
+
+func TestSubscriberCanCallBackIntoService(t *testing.T) {
+	svc := NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "")
+
+	var order []string
+	svc.Subscribe(func(ev Event) {
+		order = append(order, ev.Name())
+		// Um assinante pode reagir alterando o estado; o evento resultante
+		// chega depois do atual, sem deadlock.
+		if created, ok := ev.(TaskCreated); ok {
+			if _, err := svc.SetTaskPriority(created.Task.ID, model.PriorityHigh); err != nil {
+				t.Errorf("priority from subscriber failed: %v", err)
+			}
+			_ = svc.State()
+		}
+	})
+	task, _ := svc.CreateTask(list.ID, "Pintar")
+
+	if !reflect.DeepEqual(order, []string{"task.created", "task.updated"}) {
+		t.Fatalf("unexpected order %v", order)
+	}
+	if got, _ := svc.GetTask(task.ID); got.Priority != model.PriorityHigh {
+		t.Fatalf("expected subscriber change to apply, got %+v", got)
+	}
+}
+
+func TestSessionChangedOnlyOnChange(t *testing.T) {
+	svc := NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "")
+	ch, unsubscribe := svc.SubscribeChan(1)
+
+	if err := svc.SetSessionContext(list.ID, model.SessionFocusTasks); err != nil {
+		t.Fatalf("set session failed: %v", err)
+	}
+	if err := svc.SetSessionContext(list.ID, model.SessionFocusTasks); err != nil {
+		t.Fatalf("set session failed: %v", err)
+	}
+	svc.SetQuery("x") // não cabe no buffer: descartado
+
+	ev := (<-ch).(SessionChanged)
+	if ev.Before.Context.ActiveListID != "" || ev.After.Context.ActiveListID != list.ID || ev.After.Context.Focus != model.SessionFocusTasks {
+		t.Fatalf("unexpected SessionChanged: %+v", ev)
+	}
+	unsubscribe()
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed after unsubscribe")
+	}
+}
+
+func TestSubscribeWithStateSeesStateAfterEachChange(t *testing.T) {
+	svc := NewService(model.NewState())
+	var counts []int
+	unsubscribe := svc.SubscribeWithState(func(ev Event, after model.AppState) {
+		counts = append(counts, len(after.Tasks))
+		// O estado entregue é o do evento, mesmo que o Service já tenha mudado.
+		if _, ok := ev.(ListCreated); ok {
+			if _, err := svc.CreateTask(after.Lists[0].ID, "Lavar"); err != nil {
+				t.Errorf("create task failed: %v", err)
+			}
+		}
+	})
+
+	list := mustCreateList(t, svc, "Casa")
+	mustCreateTask(t, svc, list.ID, "Secar")
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(counts, want) {
+		t.Fatalf("expected task counts %v, got %v", want, counts)
+	}
+
+	unsubscribe()
+	mustCreateTask(t, svc, list.ID, "Guardar")
+	if len(counts) != 3 {
+		t.Fatalf("expected no events after unsubscribe, got %v", counts)
+	}
+}
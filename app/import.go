@@ -0,0 +1,383 @@
+package app
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"todo-cli/model"
+)
+
+// ErrNothingToImport is returned when an import batch has no lists, tasks or archive entries.
+var ErrNothingToImport = errors.New("nothing to import")
+
+// ImportBatch is what a format parser hands to Import. Lists are matched to
+// existing ones by ID or name (case-insensitive) and created when missing.
+type ImportBatch struct {
+	Lists    []ImportList
+	Archived []model.ArchivedCompletedTask
+	// MatchExtra names Task.Extra keys that identify a task across imports
+	// (e.g. a calendar UID). A task whose ID or any of these values matches
+	// an existing task updates it instead of creating a duplicate, and an
+	// archive entry whose ID matches one of these values completes that task
+	// instead of being archived next to it.
+	MatchExtra []string
+}
+
+// ImportList groups imported tasks under a list. The list is matched by ID,
+// then by name; Task.ListID is ignored.
+type ImportList struct {
+	model.List
+	Tasks []model.Task
+}
+
+// ImportResult counts what Import changed.
+type ImportResult struct {
+	ListsCreated int
+	TasksCreated int
+	TasksUpdated int
+	Archived     int
+}
+
+// Import applies a batch as a single undoable change. The pre-hook is
+// consulted for every task the batch would create (TaskCreated, which may
+// amend it as in CreateTask) or complete (TaskToggled); a veto rejects the
+// whole batch and leaves the state untouched. The batch is applied to a
+// copy of the state, so the hooks run without the Service lock.
+func (s *Service) Import(batch ImportBatch) (ImportResult, error) {
+	total := len(batch.Archived)
+	for _, l := range batch.Lists {
+		if strings.TrimSpace(l.Name) == "" {
+			return ImportResult{}, ErrInvalidName
+		}
+		for _, t := range l.Tasks {
+			if strings.TrimSpace(t.Text) == "" {
+				return ImportResult{}, ErrInvalidTask
+			}
+		}
+		total += len(l.Tasks) + 1
+	}
+	if total == 0 {
+		return ImportResult{}, ErrNothingToImport
+	}
+
+	for attempt := 1; ; attempt++ {
+		s.mu.RLock()
+		hook, rev := s.preHook, s.rev
+		var scratch *Service
+		if hook != nil {
+			scratch = s.scratch(hook)
+		}
+		s.mu.RUnlock()
+		if hook == nil {
+			s.mu.Lock()
+			defer s.unlock()
+			return s.commitImport(s.scratch(nil).importBatch(batch))
+		}
+
+		scratch, result, err := scratch.importBatch(batch)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		s.mu.Lock()
+		if s.rev == rev {
+			defer s.unlock()
+			return s.commitImport(scratch, result, nil)
+		}
+		s.mu.Unlock()
+		if attempt == maxHookAttempts {
+			return ImportResult{}, ErrStateChanged
+		}
+	}
+}
+
+// scratch copies the state into a Service no other goroutine can reach, for
+// Import to work on; the caller holds s.mu.
+func (s *Service) scratch(hook PreHook) *Service {
+	return &Service{state: copyState(s.state), preHook: hook}
+}
+
+// commitImport replaces lists, tasks and archive with those of the scratch
+// Service; the caller holds s.mu.
+func (s *Service) commitImport(scratch *Service, result ImportResult, err error) (ImportResult, error) {
+	if err != nil {
+		return ImportResult{}, err
+	}
+	before := copyState(s.state)
+	s.pushUndo()
+	s.state.Lists = scratch.state.Lists
+	s.state.Tasks = scratch.state.Tasks
+	s.state.ArchivedCompleted = scratch.state.ArchivedCompleted
+	s.emit(Imported{Result: result, Before: before, After: copyState(s.state)})
+	return result, nil
+}
+
+// importBatch applies batch to s, which must be a scratch Service.
+func (s *Service) importBatch(batch ImportBatch) (*Service, ImportResult, error) {
+	now := time.Now().UTC()
+	var result ImportResult
+	touched := make(map[string]bool)
+
+	for _, in := range batch.Lists {
+		listID, created := s.importList(in, now)
+		if created {
+			result.ListsCreated++
+		}
+		for _, t := range in.Tasks {
+			created, err := s.importTask(listID, t, batch.MatchExtra, now, touched)
+			if err != nil {
+				return nil, ImportResult{}, err
+			}
+			if created {
+				result.TasksCreated++
+			} else {
+				result.TasksUpdated++
+			}
+		}
+	}
+
+	archived := make(map[string]int, len(s.state.ArchivedCompleted))
+	for i, a := range s.state.ArchivedCompleted {
+		archived[a.ID] = i
+	}
+	for _, a := range batch.Archived {
+		a.TaskText = strings.TrimSpace(a.TaskText)
+		if a.ArchivedAt.IsZero() {
+			a.ArchivedAt = now
+		}
+		if a.DoneAt.IsZero() {
+			a.DoneAt = a.ArchivedAt
+		}
+		completed, err := s.completeMatched(a, batch.MatchExtra, touched)
+		if err != nil {
+			return nil, ImportResult{}, err
+		}
+		if completed {
+			result.TasksUpdated++
+			continue
+		}
+		if idx, ok := archived[a.ID]; ok && a.ID != "" {
+			s.state.ArchivedCompleted[idx] = a
+		} else {
+			if a.ID == "" {
+				a.ID = newID()
+			}
+			archived[a.ID] = len(s.state.ArchivedCompleted)
+			s.state.ArchivedCompleted = append(s.state.ArchivedCompleted, a)
+		}
+		result.Archived++
+	}
+
+	for listID := range touched {
+		s.normalizePositionsForList(listID)
+	}
+	return s, result, nil
+}
+
+func (s *Service) importList(in ImportList, now time.Time) (string, bool) {
+	list := in.List
+	list.Name = strings.TrimSpace(list.Name)
+	// Colunas inválidas voltam ao padrão (cleanStatuses devolve nil).
+	statuses, err := cleanStatuses(list.Statuses)
+	idx := -1
+	if list.ID != "" {
+		idx = slices.IndexFunc(s.state.Lists, func(l model.List) bool { return l.ID == list.ID })
+	}
+	if idx < 0 {
+		idx = slices.IndexFunc(s.state.Lists, func(l model.List) bool { return strings.EqualFold(l.Name, list.Name) })
+	}
+	if idx >= 0 {
+		// Uma lista existente só troca de colunas quando o arquivo traz colunas válidas.
+		if len(list.Statuses) > 0 && err == nil {
+			s.importStatuses(idx, statuses, now)
+		}
+		return s.state.Lists[idx].ID, false
+	}
+	if list.ID == "" {
+		list.ID = newID()
+	}
+	list.Color = strings.TrimSpace(list.Color)
+	list.Statuses = statuses
+	if list.CreatedAt.IsZero() {
+		list.CreatedAt = now
+	}
+	if list.UpdatedAt.IsZero() {
+		list.UpdatedAt = list.CreatedAt
+	}
+	s.state.Lists = append(s.state.Lists, list)
+	return list.ID, true
+}
+
+// importStatuses gives an existing list the board columns of an import and
+// moves its tasks into them, as SetListStatuses does.
+func (s *Service) importStatuses(idx int, statuses []string, now time.Time) {
+	list := &s.state.Lists[idx]
+	if slices.Equal(list.Statuses, statuses) {
+		return
+	}
+	list.Statuses = statuses
+	list.UpdatedAt = now
+	columns := list.BoardStatuses()
+	for i := range s.state.Tasks {
+		if s.state.Tasks[i].ListID == list.ID {
+			syncStatus(&s.state.Tasks[i], columns)
+		}
+	}
+}
+
+// importTask upserts one task and reports whether it was created. An
+// existing task keeps its board status when the import has none, and stays
+// pinned since most formats cannot say otherwise.
+func (s *Service) importTask(listID string, in model.Task, matchExtra []string, now time.Time, touched map[string]bool) (bool, error) {
+	in.Text = strings.TrimSpace(in.Text)
+	in.ListID = listID
+	in.Extra = maps.Clone(in.Extra)
+	if in.Priority < model.PriorityNone || in.Priority > model.PriorityHigh {
+		in.Priority = model.PriorityNone
+	}
+	if in.UpdatedAt.IsZero() {
+		in.UpdatedAt = now
+	}
+	if in.Done && in.DoneAt.IsZero() {
+		in.DoneAt = in.UpdatedAt
+	}
+	if !in.Done {
+		in.DoneAt = time.Time{}
+	}
+	in.Status = strings.TrimSpace(in.Status)
+	touched[listID] = true
+
+	if idx := s.matchTask(in, matchExtra); idx >= 0 {
+		existing := s.state.Tasks[idx]
+		touched[existing.ListID] = true
+		in.ID = existing.ID
+		if in.CreatedAt.IsZero() {
+			in.CreatedAt = existing.CreatedAt
+		}
+		if in.Status == "" {
+			in.Status = existing.Status
+		}
+		in.Pinned = in.Pinned || existing.Pinned
+		if existing.ListID == listID && in.Position == 0 {
+			in.Position = existing.Position
+		}
+		if existing.Extra != nil {
+			merged := maps.Clone(existing.Extra)
+			maps.Copy(merged, in.Extra)
+			in.Extra = merged
+		}
+		if in.Position == 0 {
+			in.Position = s.nextPositionInList(listID)
+		}
+		syncStatus(&in, s.statusesFor(listID))
+		if in.Done && !existing.Done {
+			if _, err := s.check(TaskToggled{Before: cloneTask(existing), After: cloneTask(in)}); err != nil {
+				return false, err
+			}
+		}
+		s.state.Tasks[idx] = in
+		return false, nil
+	}
+
+	if in.ID == "" || s.taskIndex(in.ID) >= 0 {
+		in.ID = newID()
+	}
+	if in.CreatedAt.IsZero() {
+		in.CreatedAt = now
+	}
+	// Posições importadas ordenam a lista; sem posição, a tarefa vai para o fim.
+	if in.Position == 0 {
+		in.Position = s.nextPositionInList(listID)
+	}
+	syncStatus(&in, s.statusesFor(listID))
+	in, err := s.checkNewTask(in)
+	if err != nil {
+		return false, err
+	}
+	s.state.Tasks = append(s.state.Tasks, in)
+	return true, nil
+}
+
+// completeMatched marks done the task an archive entry refers to through
+// MatchExtra, e.g. a Taskwarrior task imported while pending and completed
+// since, and reports whether there was one.
+func (s *Service) completeMatched(a model.ArchivedCompletedTask, matchExtra []string, touched map[string]bool) (bool, error) {
+	if a.ID == "" || len(matchExtra) == 0 {
+		return false, nil
+	}
+	probe := model.Task{Extra: make(map[string]string, len(matchExtra))}
+	for _, key := range matchExtra {
+		probe.Extra[key] = a.ID
+	}
+	idx := s.matchTask(probe, matchExtra)
+	if idx < 0 {
+		return false, nil
+	}
+	existing := cloneTask(s.state.Tasks[idx])
+	t := cloneTask(existing)
+	if a.TaskText != "" {
+		t.Text = a.TaskText
+	}
+	t.Priority = a.Priority
+	t.UpdatedAt = a.ArchivedAt
+	if !t.Done {
+		t.Done = true
+		t.DoneAt = a.DoneAt
+		// Concluídas vão para o fim da lista.
+		t.Position = s.nextPositionInList(t.ListID)
+	}
+	syncStatus(&t, s.statusesFor(t.ListID))
+	if !existing.Done {
+		if _, err := s.check(TaskToggled{Before: existing, After: cloneTask(t)}); err != nil {
+			return false, err
+		}
+	}
+	s.state.Tasks[idx] = t
+	touched[t.ListID] = true
+	return true, nil
+}
+
+func (s *Service) matchTask(in model.Task, matchExtra []string) int {
+	if in.ID != "" {
+		if idx := s.taskIndex(in.ID); idx >= 0 {
+			return idx
+		}
+	}
+	for _, key := range matchExtra {
+		value := in.Extra[key]
+		if value == "" {
+			continue
+		}
+		for i, t := range s.state.Tasks {
+			if t.Extra[key] == value {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (s *Service) listIndex(id string) int {
+	return slices.IndexFunc(s.state.Lists, func(l model.List) bool { return l.ID == id })
+}
+
+func (s *Service) taskIndex(id string) int {
+	for i, t := range s.state.Tasks {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Service) nextPositionInList(listID string) int {
+	maxPos := 0
+	for _, t := range s.state.Tasks {
+		if t.ListID == listID && t.Position > maxPos {
+			maxPos = t.Position
+		}
+	}
+	return maxPos + 1
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"todo-cli/model"
+)
+
+func TestImportIsSingleUndoableChange(t *testing.T) {
+	svc := NewService(model.NewState())
+	inbox, err := svc.CreateList("Inbox", "blue")
+	if err != nil {
+		t.Fatalf("create list failed: %v", err)
+	}
+	if _, err := svc.CreateTask(inbox.ID, "existente"); err != nil {
+		t.Fatalf("create task failed: %v", err)
+	}
+	before := svc.State()
+
+	result, err := svc.Import(ImportBatch{
+		Lists: []ImportList{
+			{List: model.List{Name: "inbox"}, Tasks: []model.Task{{Text: "nova"}, {Text: "feita", Done: true}}},
+			{List: model.List{Name: "Trabalho"}, Tasks: []model.Task{{Text: "relatório", Priority: model.PriorityHigh}}},
+		},
+		Archived: []model.ArchivedCompletedTask{{TaskText: "antiga", OriginList: "Trabalho"}},
+	})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	want := ImportResult{ListsCreated: 1, TasksCreated: 3, Archived: 1}
+	if result != want {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if got := svc.Tasks(inbox.ID); len(got) != 3 || got[1].Text != "nova" || got[1].Position != 2 {
+		t.Fatalf("expected imported tasks appended to existing list, got %+v", got)
+	}
+	for _, task := range svc.Tasks(inbox.ID) {
+		if task.Done && task.DoneAt.IsZero() {
+			t.Fatalf("expected done task to get DoneAt")
+		}
+	}
+
+	if err := svc.Undo(); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+	if diff := DiffStates(before, svc.State()); !diff.Empty() {
+		t.Fatalf("expected a single undo to revert the import, got %+v", diff)
+	}
+}
+
+func TestImportMatchesByExtraKey(t *testing.T) {
+	svc := NewService(model.NewState())
+	batch := ImportBatch{
+		Lists:      []ImportList{{List: model.List{Name: "Agenda"}, Tasks: []model.Task{{Text: "reunião", Extra: map[string]string{"uid": "abc"}}}}},
+		MatchExtra: []string{"uid"},
+	}
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("first import failed: %v", err)
+	}
+	batch.Lists[0].Tasks[0].Text = "reunião remarcada"
+	result, err := svc.Import(batch)
+	if err != nil {
+		t.Fatalf("second import failed: %v", err)
+	}
+	if result.TasksCreated != 0 || result.TasksUpdated != 1 {
+		t.Fatalf("expected re-import to update, got %+v", result)
+	}
+	tasks := svc.Tasks("")
+	if len(tasks) != 1 || tasks[0].Text != "reunião remarcada" {
+		t.Fatalf("expected single updated task, got %+v", tasks)
+	}
+
+	if _, err := svc.Import(ImportBatch{}); !errors.Is(err, ErrNothingToImport) {
+		t.Fatalf("expected ErrNothingToImport, got %v", err)
+	}
+}
+
+func TestImportKeepsBoardStatus(t *testing.T) {
+	svc := NewService(model.NewState())
+	list := mustCreateList(t, svc, "Quadro")
+	task := mustCreateTask(t, svc, list.ID, "revisar")
+	if _, err := svc.SetTaskStatus(task.ID, model.StatusReview); err != nil {
+		t.Fatalf("set status failed: %v", err)
+	}
+
+	// Formatos sem coluna (ex.: iCal) não devem tirar a tarefa de "review".
+	if _, err := svc.Import(ImportBatch{Lists: []ImportList{{List: model.List{Name: "Quadro"}, Tasks: []model.Task{{ID: task.ID, Text: "revisar de novo"}}}}}); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if got, _ := svc.GetTask(task.ID); got.Status != model.StatusReview || got.Text != "revisar de novo" {
+		t.Fatalf("expected the status to survive the update, got %+v", got)
+	}
+
+	columns := []string{"Ideias", "Fazendo", "Pronto"}
+	_, err := svc.Import(ImportBatch{Lists: []ImportList{{
+		List:  model.List{Name: "quadro", Statuses: columns},
+		Tasks: []model.Task{{ID: task.ID, Text: "revisar de novo", Status: "Fazendo"}},
+	}}})
+	if err != nil {
+		t.Fatalf("import with columns failed: %v", err)
+	}
+	if got, _ := svc.GetList(list.ID); !slices.Equal(got.Statuses, columns) {
+		t.Fatalf("expected the imported columns on the existing list, got %+v", got.Statuses)
+	}
+	if got, _ := svc.GetTask(task.ID); got.Status != "Fazendo" || got.Done {
+		t.Fatalf("expected the imported status, got %+v", got)
+	}
+}
+
+func TestImportKeepsPin(t *testing.T) {
+	svc := NewService(model.NewState())
+	list := mustCreateList(t, svc, "Casa")
+	task := mustCreateTask(t, svc, list.ID, "pintar")
+	if _, err := svc.SetTaskPinned(task.ID, true); err != nil {
+		t.Fatalf("pin failed: %v", err)
+	}
+	if _, err := svc.Import(ImportBatch{Lists: []ImportList{{List: model.List{Name: "Casa"}, Tasks: []model.Task{{ID: task.ID, Text: "pintar a sala"}}}}}); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if got, _ := svc.GetTask(task.ID); !got.Pinned || got.Text != "pintar a sala" {
+		t.Fatalf("expected the task to stay pinned, got %+v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"todo-cli/model"
+)
+
+// TaskPatch lists the fields of a task to change; nil fields are left as
+// they are. Status is applied before Done, so {Status: "doing", Done: true}
+// ends in the done column.
+type TaskPatch struct {
+	Text     *string
+	Done     *bool
+	Status   *string
+	Priority *model.Priority
+}
+
+// PatchTask applies every field of p as a single change: one undo entry and
+// one event, TaskToggled when Done changes and TaskUpdated otherwise. When
+// the pre-hook vetoes the change, nothing is applied.
+func (s *Service) PatchTask(taskID string, p TaskPatch) (model.Task, error) {
+	return s.changeTask(taskID, func(t *model.Task, now time.Time) (bool, error) {
+		return s.applyPatch(t, p, now)
+	})
+}
+
+// applyPatch changes t as p says, keeping Done and Status in step as
+// SetTaskStatus does, and reports whether anything changed. It does not
+// touch the state; the caller holds s.mu.
+func (s *Service) applyPatch(t *model.Task, p TaskPatch, now time.Time) (bool, error) {
+	orig := cloneTask(*t)
+	if p.Text != nil {
+		text := strings.TrimSpace(*p.Text)
+		if text == "" {
+			return false, ErrInvalidTask
+		}
+		t.Text = text
+	}
+	if p.Priority != nil {
+		if *p.Priority < model.PriorityNone || *p.Priority > model.PriorityHigh {
+			return false, fmt.Errorf("%w: %d", ErrInvalidPriority, *p.Priority)
+		}
+		t.Priority = *p.Priority
+	}
+	statuses := s.statusesFor(t.ListID)
+	last := statuses[len(statuses)-1]
+	if p.Status != nil {
+		status := strings.TrimSpace(*p.Status)
+		if !slices.Contains(statuses, status) {
+			return false, fmt.Errorf("%w: %q", ErrInvalidStatus, *p.Status)
+		}
+		t.Status = status
+		t.Done = status == last
+	}
+	if p.Done != nil && *p.Done != t.Done {
+		t.Done = *p.Done
+		t.Status = statuses[0]
+		if t.Done {
+			t.Status = last
+		}
+	}
+
+	if t.Text == orig.Text && t.Priority == orig.Priority && t.Status == orig.Status && t.Done == orig.Done {
+		return false, nil
+	}
+	t.UpdatedAt = now
+	if t.Done != orig.Done {
+		t.DoneAt = time.Time{}
+		if t.Done {
+			t.DoneAt = now
+		}
+	}
+	return true, nil
+}
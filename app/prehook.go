@@ -0,0 +1,149 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"todo-cli/model"
+)
+
+var (
+	// ErrVetoed wraps the error of a PreHook that rejected a change.
+	ErrVetoed = errors.New("change vetoed by hook")
+	// ErrStateChanged is returned when other changes kept landing while the
+	// pre-hook ran, so the change could not be applied as the hook saw it.
+	ErrStateChanged = errors.New("state changed while the hook ran")
+)
+
+// maxHookAttempts bounds how often a change is proposed again because the
+// state moved on while its pre-hook ran.
+const maxHookAttempts = 3
+
+// PreHook is consulted before every change to lists, tasks or the archive
+// (Undo and ReplaceState, which put back an earlier state, excepted). It
+// receives the event the change would emit; returning an error vetoes the
+// change. For TaskCreated, TaskUpdated, TaskToggled and TaskMoved the text,
+// priority, pin and extra fields of the returned task replace the proposed
+// ones, and for TaskMoved so do its list and position; other events can
+// only be vetoed. Import consults it with a TaskCreated or TaskToggled for
+// each task it would create or complete, although it only emits Imported.
+//
+// The hook runs without the Service lock, so it may read from the Service.
+// If another change lands meanwhile, the change is proposed again and the
+// hook consulted anew.
+type PreHook func(Event) (Event, error)
+
+// SetPreHook installs h, replacing any previous hook. nil removes it.
+func (s *Service) SetPreHook(h PreHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.preHook = h
+}
+
+// mutate runs one change around the pre-hook. propose builds the event the
+// change would emit without touching the state, under the read lock; a nil
+// event means there is nothing to do. commit applies the event the hook
+// returned (or the proposal itself, without a hook) under the write lock.
+func (s *Service) mutate(propose func() (Event, error), commit func(Event) error) error {
+	for attempt := 1; ; attempt++ {
+		s.mu.RLock()
+		hook, rev := s.preHook, s.rev
+		var (
+			ev  Event
+			err error
+		)
+		if hook != nil {
+			ev, err = propose()
+		}
+		s.mu.RUnlock()
+
+		if hook == nil {
+			s.mu.Lock()
+			defer s.unlock()
+			if ev, err = propose(); err != nil || ev == nil {
+				return err
+			}
+			return commit(ev)
+		}
+		if err != nil || ev == nil {
+			return err
+		}
+		if ev, err = consult(hook, ev); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		if s.rev == rev {
+			defer s.unlock()
+			return commit(ev)
+		}
+		s.mu.Unlock()
+		if attempt == maxHookAttempts {
+			return ErrStateChanged
+		}
+	}
+}
+
+// check runs the pre-hook for a proposed change. Only Import calls it, on
+// a scratch Service no other goroutine can reach.
+func (s *Service) check(ev Event) (Event, error) {
+	if s.preHook == nil {
+		return ev, nil
+	}
+	return consult(s.preHook, ev)
+}
+
+func consult(hook PreHook, ev Event) (Event, error) {
+	out, err := hook(ev)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrVetoed, err)
+	}
+	if out == nil {
+		return ev, nil
+	}
+	return out, nil
+}
+
+// checkNewTask lets the pre-hook amend a task about to be imported.
+func (s *Service) checkNewTask(task model.Task) (model.Task, error) {
+	ev, err := s.check(TaskCreated{Task: cloneTask(task)})
+	if err != nil {
+		return model.Task{}, err
+	}
+	return amendTask(task, ev)
+}
+
+// amendTask copies onto proposed the fields a pre-hook may change in the
+// task carried by ev. Events of another type leave proposed as it is.
+func amendTask(proposed model.Task, ev Event) (model.Task, error) {
+	var hooked model.Task
+	switch ev := ev.(type) {
+	case TaskCreated:
+		hooked = ev.Task
+	case TaskUpdated:
+		hooked = ev.After
+	case TaskToggled:
+		hooked = ev.After
+	case TaskMoved:
+		hooked = ev.After
+	default:
+		return proposed, nil
+	}
+	text := strings.TrimSpace(hooked.Text)
+	if text == "" {
+		return model.Task{}, ErrInvalidTask
+	}
+	if p := hooked.Priority; p < model.PriorityNone || p > model.PriorityHigh {
+		return model.Task{}, fmt.Errorf("%w: %d", ErrInvalidPriority, p)
+	}
+	proposed.Text = text
+	proposed.Priority = hooked.Priority
+	proposed.Pinned = hooked.Pinned
+	proposed.Extra = maps.Clone(hooked.Extra)
+	if len(proposed.Extra) == 0 {
+		proposed.Extra = nil
+	}
+	return proposed, nil
+}
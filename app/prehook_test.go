@@ -0,0 +1,139 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"todo-cli/model"
+)
+
+func TestPreHookConsultedByEveryMutator(t *testing.T) {
+	svc := NewService(model.NewState())
+	home := mustCreateList(t, svc, "Casa")
+	work := mustCreateList(t, svc, "Trabalho")
+	a := mustCreateTask(t, svc, home.ID, "A")
+	mustCreateTask(t, svc, home.ID, "B")
+	before := svc.State()
+
+	var seen []string
+	svc.SetPreHook(func(ev Event) (Event, error) {
+		seen = append(seen, ev.Name())
+		return nil, errors.New("não")
+	})
+	changes := map[string]func() error{
+		"CreateList":      func() error { _, err := svc.CreateList("Outra", ""); return err },
+		"UpdateList":      func() error { _, err := svc.UpdateList(home.ID, "Lar", ""); return err },
+		"MoveListDown":    func() error { _, err := svc.MoveListDown(home.ID); return err },
+		"SetListStatuses": func() error { _, err := svc.SetListStatuses(home.ID, []string{"a", "b"}); return err },
+		"DeleteList":      func() error { return svc.DeleteList(work.ID) },
+		"CreateTask":      func() error { _, err := svc.CreateTask(home.ID, "C"); return err },
+		"UpdateTask":      func() error { _, err := svc.UpdateTask(a.ID, "A2"); return err },
+		"SetTaskPriority": func() error { _, err := svc.SetTaskPriority(a.ID, model.PriorityHigh); return err },
+		"SetTaskPinned":   func() error { _, err := svc.SetTaskPinned(a.ID, true); return err },
+		"SetTaskDue":      func() error { _, err := svc.SetTaskDue(a.ID, time.Now()); return err },
+		"SetTaskStatus":   func() error { _, err := svc.SetTaskStatus(a.ID, model.StatusDoing); return err },
+		"ToggleDone":      func() error { _, err := svc.ToggleDone(a.ID); return err },
+		"MoveTaskDown":    func() error { _, err := svc.MoveTaskDown(a.ID); return err },
+		"MoveTaskTo":      func() error { _, err := svc.MoveTaskTo(a.ID, 1); return err },
+		"MoveTaskToList":  func() error { _, err := svc.MoveTaskToList(a.ID, work.ID); return err },
+		"DeleteTask":      func() error { return svc.DeleteTask(a.ID) },
+		"ArchiveAll":      func() error { _, err := svc.ArchiveAllToArchive(home.ID); return err },
+		"DeleteAllTasks":  func() error { _, err := svc.DeleteAllTasks(home.ID); return err },
+	}
+	for name, change := range changes {
+		if err := change(); !errors.Is(err, ErrVetoed) {
+			t.Fatalf("%s: expected ErrVetoed, got %v", name, err)
+		}
+	}
+	if len(seen) != len(changes) {
+		t.Fatalf("expected one hook call per change, got %v", seen)
+	}
+	if after := svc.State(); !reflect.DeepEqual(before, after) {
+		t.Fatalf("vetoed changes must leave the state untouched")
+	}
+}
+
+func TestPreHookAmendsUpdatesTogglesAndMoves(t *testing.T) {
+	svc := NewService(model.NewState())
+	home := mustCreateList(t, svc, "Casa")
+	work := mustCreateList(t, svc, "Trabalho")
+	a := mustCreateTask(t, svc, home.ID, "A")
+	mustCreateTask(t, svc, work.ID, "W1")
+	mustCreateTask(t, svc, work.ID, "W2")
+
+	svc.SetPreHook(func(ev Event) (Event, error) {
+		switch ev := ev.(type) {
+		case TaskUpdated:
+			ev.After.Text += " (revisada)"
+			return ev, nil
+		case TaskToggled:
+			ev.After.Priority = model.PriorityHigh
+			return ev, nil
+		case TaskMoved:
+			// Tudo que muda de lista entra no topo.
+			ev.After.Position = 1
+			ev.After.Pinned = true
+			return ev, nil
+		}
+		return ev, nil
+	})
+
+	got, err := svc.UpdateTask(a.ID, "Regar")
+	if err != nil || got.Text != "Regar (revisada)" {
+		t.Fatalf("expected the hook to amend the edit, got %+v (%v)", got, err)
+	}
+	if got, err = svc.ToggleDone(a.ID); err != nil || !got.Done || got.Priority != model.PriorityHigh {
+		t.Fatalf("expected the hook to amend the completion, got %+v (%v)", got, err)
+	}
+	if got, err = svc.MoveTaskToList(a.ID, work.ID); err != nil {
+		t.Fatalf("move failed: %v", err)
+	}
+	if got.ListID != work.ID || got.Position != 1 || !got.Pinned {
+		t.Fatalf("expected the hook to pick the position, got %+v", got)
+	}
+	if tasks := svc.Tasks(work.ID); tasks[0].ID != a.ID || tasks[1].Position != 2 || tasks[2].Position != 3 {
+		t.Fatalf("expected the others to shift down, got %+v", tasks)
+	}
+}
+
+func TestPreHookRunsWithoutLock(t *testing.T) {
+	svc := NewService(model.NewState())
+	list := mustCreateList(t, svc, "Casa")
+	task := mustCreateTask(t, svc, list.ID, "A")
+
+	calls := 0
+	svc.SetPreHook(func(ev Event) (Event, error) {
+		calls++
+		// Ler o Service no meio do hook travaria se ele rodasse com o lock.
+		_ = svc.Lists()
+		if calls == 1 {
+			// Uma mudança no meio do hook (que também passa por ele) obriga
+			// a propor a edição de novo.
+			if _, err := svc.CreateList("Outra", ""); err != nil {
+				return nil, err
+			}
+		}
+		return ev, nil
+	})
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.UpdateTask(task.ID, "B")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("update failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the pre-hook must not run while the Service is locked")
+	}
+	if got, _ := svc.GetTask(task.ID); got.Text != "B" || len(svc.Lists()) != 2 {
+		t.Fatalf("expected both changes to land, got %+v and %d lists", got, len(svc.Lists()))
+	}
+	if calls != 3 {
+		t.Fatalf("expected the edit to be proposed again after the concurrent change, got %d hook calls", calls)
+	}
+}
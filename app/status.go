@@ -0,0 +1,134 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"todo-cli/model"
+)
+
+var (
+	ErrInvalidStatus   = errors.New("invalid status")
+	ErrDuplicateStatus = errors.New("duplicate status")
+	ErrTooFewStatuses  = errors.New("a board needs at least two statuses")
+)
+
+// SetTaskStatus moves a task to another column of its list's board. Moving
+// into or out of the last column completes or reopens the task, exactly
+// like ToggleDone.
+func (s *Service) SetTaskStatus(taskID, status string) (model.Task, error) {
+	status = strings.TrimSpace(status)
+	return s.changeTask(taskID, func(t *model.Task, now time.Time) (bool, error) {
+		return s.applyPatch(t, TaskPatch{Status: &status}, now)
+	})
+}
+
+// SetListStatuses sets the board columns of a list, in order; the last one
+// is the done column. An empty slice restores model.DefaultStatuses. Open
+// tasks in a column that no longer exists move to the first column, and
+// done tasks to the new last one.
+func (s *Service) SetListStatuses(listID string, statuses []string) (model.List, error) {
+	cleaned, err := cleanStatuses(statuses)
+	if err != nil {
+		return model.List{}, err
+	}
+	var (
+		idx           int
+		before, after model.List
+	)
+	err = s.mutate(func() (Event, error) {
+		if idx = s.listIndex(listID); idx < 0 {
+			return nil, ErrListNotFound
+		}
+		before = cloneList(s.state.Lists[idx])
+		after = cloneList(before)
+		after.Statuses = slices.Clone(cleaned)
+		after.UpdatedAt = time.Now().UTC()
+		return ListUpdated{Before: before, After: cloneList(after)}, nil
+	}, func(Event) error {
+		s.pushUndo()
+		s.state.Lists[idx] = cloneList(after)
+		columns := after.BoardStatuses()
+		for i := range s.state.Tasks {
+			if s.state.Tasks[i].ListID != listID {
+				continue
+			}
+			if old := s.state.Tasks[i].Status; syncStatus(&s.state.Tasks[i], columns) != old {
+				s.state.Tasks[i].UpdatedAt = after.UpdatedAt
+			}
+		}
+		s.emit(ListUpdated{Before: before, After: cloneList(after)})
+		return nil
+	})
+	if err != nil {
+		return model.List{}, err
+	}
+	return after, nil
+}
+
+// CheckStatuses returns the error SetListStatuses would give for statuses,
+// so callers can validate before changing anything else.
+func CheckStatuses(statuses []string) error {
+	_, err := cleanStatuses(statuses)
+	return err
+}
+
+// cleanStatuses trims the names and rejects blanks, repeats (ignoring case)
+// and boards with fewer than two columns. The defaults are stored as nil.
+func cleanStatuses(statuses []string) ([]string, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+	out := make([]string, 0, len(statuses))
+	for _, st := range statuses {
+		st = strings.TrimSpace(st)
+		if st == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidStatus, st)
+		}
+		if slices.ContainsFunc(out, func(o string) bool { return strings.EqualFold(o, st) }) {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateStatus, st)
+		}
+		out = append(out, st)
+	}
+	if len(out) < 2 {
+		return nil, ErrTooFewStatuses
+	}
+	if slices.Equal(out, model.DefaultStatuses()) {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// statusesFor returns the board columns of a list; the caller holds s.mu.
+func (s *Service) statusesFor(listID string) []string {
+	for _, l := range s.state.Lists {
+		if l.ID == listID {
+			return l.BoardStatuses()
+		}
+	}
+	return model.DefaultStatuses()
+}
+
+// syncStatus makes a task's status valid for the given columns and in step
+// with Done, which wins: done tasks go to the last column, open tasks with
+// an unknown status or in the last column go to the first. It returns the
+// resulting status.
+func syncStatus(t *model.Task, statuses []string) string {
+	last := statuses[len(statuses)-1]
+	switch {
+	case t.Done:
+		t.Status = last
+	case t.Status == last || !slices.Contains(statuses, t.Status):
+		t.Status = statuses[0]
+	}
+	return t.Status
+}
+
+// cloneList copies a list so callers cannot reach the state's Statuses.
+func cloneList(l model.List) model.List {
+	l.Statuses = slices.Clone(l.Statuses)
+	return l
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"todo-cli/model"
+)
+
+func TestSetTaskStatusKeepsDoneInStep(t *testing.T) {
+	svc := NewService(model.NewState())
+	list := mustCreateList(t, svc, "Inbox")
+	a := mustCreateTask(t, svc, list.ID, "A")
+	_ = mustCreateTask(t, svc, list.ID, "B")
+	if a.Status != model.StatusTodo {
+		t.Fatalf("expected new tasks in the first column, got %q", a.Status)
+	}
+
+	a, err := svc.SetTaskStatus(a.ID, model.StatusReview)
+	if err != nil {
+		t.Fatalf("set status failed: %v", err)
+	}
+	if a.Done {
+		t.Fatal("review must not count as done")
+	}
+	if a, err = svc.SetTaskStatus(a.ID, model.StatusDone); err != nil {
+		t.Fatalf("set status failed: %v", err)
+	}
+	if !a.Done || a.DoneAt.IsZero() || a.Position != 2 {
+		t.Fatalf("expected the done column to complete the task at the end, got %+v", a)
+	}
+
+	if a, err = svc.ToggleDone(a.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if a.Done || a.Status != model.StatusTodo {
+		t.Fatalf("expected reopening to go back to the first column, got %+v", a)
+	}
+	if _, err := svc.SetTaskStatus(a.ID, "blocked"); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("expected ErrInvalidStatus, got %v", err)
+	}
+
+	svc.SetPreHook(func(ev Event) (Event, error) { return nil, errors.New("no") })
+	if _, err := svc.SetTaskStatus(a.ID, model.StatusDone); !errors.Is(err, ErrVetoed) {
+		t.Fatalf("expected completion through the board to be vetoable, got %v", err)
+	}
+	if _, err := svc.SetTaskStatus(a.ID, model.StatusDoing); !errors.Is(err, ErrVetoed) {
+		t.Fatalf("expected moving between open columns to be vetoable too, got %v", err)
+	}
+	svc.SetPreHook(func(ev Event) (Event, error) {
+		if _, ok := ev.(TaskToggled); ok {
+			return nil, errors.New("no")
+		}
+		return ev, nil
+	})
+	if _, err := svc.SetTaskStatus(a.ID, model.StatusDoing); err != nil {
+		t.Fatalf("moving between open columns is a TaskUpdated: %v", err)
+	}
+}
+
+func TestSetListStatusesRemapsTasks(t *testing.T) {
+	svc := NewService(model.NewState())
+	list := mustCreateList(t, svc, "Inbox")
+	a := mustCreateTask(t, svc, list.ID, "A")
+	b := mustCreateTask(t, svc, list.ID, "B")
+	if _, err := svc.SetTaskStatus(a.ID, model.StatusReview); err != nil {
+		t.Fatalf("set status failed: %v", err)
+	}
+	if _, err := svc.ToggleDone(b.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+
+	list, err := svc.SetListStatuses(list.ID, []string{" backlog ", "doing", "shipped"})
+	if err != nil {
+		t.Fatalf("set statuses failed: %v", err)
+	}
+	if got := list.BoardStatuses(); len(got) != 3 || got[0] != "backlog" {
+		t.Fatalf("unexpected statuses %v", got)
+	}
+	for _, task := range svc.Tasks(list.ID) {
+		want := "backlog"
+		if task.Done {
+			want = "shipped"
+		}
+		if task.Status != want {
+			t.Fatalf("expected %s in %q, got %q", task.Text, want, task.Status)
+		}
+	}
+
+	for _, bad := range [][]string{{"only"}, {"a", "A"}, {"a", " "}} {
+		if _, err := svc.SetListStatuses(list.ID, bad); err == nil {
+			t.Fatalf("expected %v to be rejected", bad)
+		}
+	}
+	if list, err = svc.SetListStatuses(list.ID, nil); err != nil || list.Statuses != nil {
+		t.Fatalf("expected nil to restore the defaults, got %v (%v)", list.Statuses, err)
+	}
+}
+
+func TestMovedListDoesNotAliasState(t *testing.T) {
+	svc := NewService(model.NewState())
+	mustCreateList(t, svc, "Casa")
+	work := mustCreateList(t, svc, "Trabalho")
+	if _, err := svc.SetListStatuses(work.ID, []string{"backlog", "doing", "shipped"}); err != nil {
+		t.Fatalf("set statuses failed: %v", err)
+	}
+	var moved model.List
+	svc.Subscribe(func(ev Event) {
+		if m, ok := ev.(ListMoved); ok {
+			moved = m.List
+		}
+	})
+
+	list, err := svc.MoveListUp(work.ID)
+	if err != nil {
+		t.Fatalf("move failed: %v", err)
+	}
+	list.Statuses[0] = "mexido"
+	moved.Statuses[1] = "mexido"
+	if got, _ := svc.GetList(work.ID); got.Statuses[0] != "backlog" || got.Statuses[1] != "doing" {
+		t.Fatalf("expected the state to keep its columns, got %v", got.Statuses)
+	}
+}
+
+func TestNormalizeStateDerivesStatusFromDone(t *testing.T) {
+	state := model.NewState()
+	state.Lists = []model.List{{ID: "l1", Name: "Inbox", Statuses: []string{"x"}}}
+	state.Tasks = []model.Task{
+		{ID: "t1", ListID: "l1", Text: "open"},
+		{ID: "t2", ListID: "l1", Text: "done", Done: true},
+		{ID: "t3", ListID: "l1", Text: "stale", Status: model.StatusDone},
+	}
+	svc := NewService(state)
+
+	if lists := svc.Lists(); lists[0].Statuses != nil {
+		t.Fatalf("expected an invalid board to fall back to the defaults, got %v", lists[0].Statuses)
+	}
+	want := map[string]string{"open": model.StatusTodo, "done": model.StatusDone, "stale": model.StatusTodo}
+	for _, task := range svc.Tasks("l1") {
+		if task.Status != want[task.Text] {
+			t.Fatalf("expected %s in %q, got %q", task.Text, want[task.Text], task.Status)
+		}
+	}
+}
+
+func TestPatchTaskIsOneChange(t *testing.T) {
+	svc := NewService(model.NewState())
+	list := mustCreateList(t, svc, "Casa")
+	task := mustCreateTask(t, svc, list.ID, "pintar")
+	mustCreateTask(t, svc, list.ID, "lavar")
+
+	text, done, high := "pintar a sala", true, model.PriorityHigh
+	got, err := svc.PatchTask(task.ID, TaskPatch{Text: &text, Done: &done, Priority: &high})
+	if err != nil {
+		t.Fatalf("patch failed: %v", err)
+	}
+	if got.Text != text || !got.Done || got.Status != model.StatusDone || got.DoneAt.IsZero() || got.Position != 2 {
+		t.Fatalf("unexpected patched task: %+v", got)
+	}
+	if err := svc.Undo(); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+	if got, _ := svc.GetTask(task.ID); got.Text != "pintar" || got.Done || got.Priority != model.PriorityNone {
+		t.Fatalf("expected one undo to revert the whole patch, got %+v", got)
+	}
+
+	bad := "blocked"
+	if _, err := svc.PatchTask(task.ID, TaskPatch{Text: &text, Status: &bad}); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("expected ErrInvalidStatus, got %v", err)
+	}
+	if got, _ := svc.GetTask(task.ID); got.Text != "pintar" {
+		t.Fatalf("expected a rejected patch to change nothing, got %+v", got)
+	}
+}
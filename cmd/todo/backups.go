@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"todo-cli/i18n"
+	"todo-cli/store"
+)
+
+func runBackups(st *store.Store, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return usageError("backups list | backups restore <id>")
+	}
+	switch args[0] {
+	case "list", "ls":
+		return listBackups(st, stdout)
+	case "restore":
+		if len(args) != 2 {
+			return usageError("backups restore <id>")
+		}
+		return restoreBackup(st, args[1], stdout)
+	default:
+		return usageError("backups list | backups restore <id>")
+	}
+}
+
+func listBackups(st *store.Store, stdout io.Writer) error {
+	backups, err := st.ListBackups()
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		fmt.Fprintln(stdout, i18n.T("cli.backups_none", st.Path()))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, i18n.T("cli.backups_header"))
+	for _, b := range backups {
+		tasks := fmt.Sprintf("%d", b.TaskCount)
+		if !b.Valid() {
+			tasks = i18n.T("cli.backups_invalid")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", b.ID, b.TakenAt.Local().Format("2006-01-02 15:04:05"), b.Size, tasks)
+	}
+	return tw.Flush()
+}
+
+func restoreBackup(st *store.Store, id string, stdout io.Writer) error {
+	state, err := st.RestoreBackup(id)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, i18n.T("cli.backups_restored", id, len(state.Lists), len(state.Tasks)))
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"todo-cli/i18n"
+	"todo-cli/store"
+)
+
+var errIssuesFound = errors.New("issues found; run `todo doctor --fix` to repair them")
+
+func runDoctor(st *store.Store, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fix := fs.Bool("fix", false, i18n.T("cli.doctor_flag_fix"))
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return usageError("doctor [--fix]")
+	}
+
+	fmt.Fprintln(stdout, i18n.T("cli.doctor_checking", st.Path()))
+	state, err := st.Load()
+	if err != nil {
+		if errors.Is(err, store.ErrWrongPassphrase) || errors.Is(err, store.ErrPassphraseRequired) {
+			return err
+		}
+		fmt.Fprintln(stdout, i18n.T("cli.doctor_load_failed", store.SeverityError, i18n.Error(err)))
+		if !*fix {
+			return errIssuesFound
+		}
+		recovered, msg, err := st.LoadWithRecovery()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, i18n.T("cli.doctor_recovered", msg))
+		state = recovered
+	}
+
+	issues := store.Validate(state)
+	for _, issue := range issues {
+		fmt.Fprintln(stdout, issue)
+	}
+	errs, warnings := 0, 0
+	for _, issue := range issues {
+		if issue.Severity == store.SeverityError {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+
+	backups, err := st.ListBackups()
+	if err != nil {
+		return err
+	}
+	for _, b := range backups {
+		if !b.Valid() {
+			warnings++
+			fmt.Fprintln(stdout, i18n.T("cli.doctor_bad_backup", store.SeverityWarning, b.ID, i18n.Error(b.Err)))
+		}
+	}
+
+	fmt.Fprintln(stdout, i18n.T("cli.doctor_summary", errs, warnings))
+	if len(issues) == 0 {
+		return nil
+	}
+	if !*fix {
+		if store.HasErrors(issues) {
+			return errIssuesFound
+		}
+		return nil
+	}
+
+	repaired, fixed := store.Repair(state)
+	if err := st.Autosave(repaired); err != nil {
+		return err
+	}
+	after, err := st.ListBackups()
+	if err != nil {
+		return err
+	}
+	// Só cita um backup se ele foi mesmo escrito.
+	if id, ok := newBackup(backups, after); ok {
+		fmt.Fprintln(stdout, i18n.T("cli.doctor_fixed_backup", len(fixed), id))
+	} else {
+		fmt.Fprintln(stdout, i18n.T("cli.doctor_fixed", len(fixed)))
+	}
+	return nil
+}
+
+// newBackup returns the ID of a backup in after that was not in before,
+// preferring a rotating backup over the `.bak` file it is copied from.
+func newBackup(before, after []store.BackupInfo) (string, bool) {
+	seen := make(map[string]bool, len(before))
+	for _, b := range before {
+		seen[b.ID+"@"+b.TakenAt.String()] = true
+	}
+	found := ""
+	for _, b := range after {
+		if seen[b.ID+"@"+b.TakenAt.String()] {
+			continue
+		}
+		if b.ID != store.LatestBackupID {
+			return b.ID, true
+		}
+		found = b.ID
+	}
+	return found, found != ""
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/exchange"
+	"todo-cli/i18n"
+	"todo-cli/store"
+)
+
+func runExport(st *store.Store, args []string, stdout io.Writer) error {
+	usage := usageError(i18n.T("cli.export_usage"))
+	if len(args) == 0 {
+		return usage
+	}
+	format, err := exchange.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := fs.String("o", "", i18n.T("cli.export_flag_out"))
+	listName := fs.String("list", "", i18n.T("cli.export_flag_list"))
+	archive := fs.Bool("archive", false, i18n.T("cli.export_flag_archive"))
+	columns := fs.String("columns", "", i18n.T("cli.export_flag_columns"))
+	since := fs.String("since", "", i18n.T("cli.export_flag_since"))
+	until := fs.String("until", "", i18n.T("cli.export_flag_until"))
+	if err := fs.Parse(args[1:]); err != nil || fs.NArg() > 0 {
+		return usage
+	}
+	opts := exchange.ExportOptions{IncludeArchive: *archive}
+	if *columns != "" {
+		opts.Columns = strings.Split(*columns, ",")
+	}
+	if opts.Since, err = parseDateFlag("since", *since); err != nil {
+		return err
+	}
+	if opts.Until, err = parseDateFlag("until", *until); err != nil {
+		return err
+	}
+
+	state, err := st.Load()
+	if err != nil {
+		return err
+	}
+	if *listName != "" {
+		for _, l := range state.Lists {
+			if strings.EqualFold(l.Name, *listName) {
+				opts.ListID = l.ID
+			}
+		}
+		if opts.ListID == "" {
+			return fmt.Errorf("%w: %q", app.ErrListNotFound, *listName)
+		}
+	}
+
+	if *out == "" {
+		return format.Export(stdout, state, opts)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := format.Export(f, state, opts); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func runImport(st *store.Store, args []string, stdout io.Writer) error {
+	usage := usageError(i18n.T("cli.import_usage"))
+	if len(args) == 0 {
+		return usage
+	}
+	format, err := exchange.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	mapping := fs.String("map", "", i18n.T("cli.import_flag_map"))
+	if err := fs.Parse(args[1:]); err != nil || fs.NArg() > 1 {
+		return usage
+	}
+	opts := exchange.ImportOptions{}
+	if *mapping != "" {
+		opts.Columns = map[string]string{}
+		for _, pair := range strings.Split(*mapping, ",") {
+			from, to, ok := strings.Cut(pair, "=")
+			if !ok {
+				return usage
+			}
+			opts.Columns[from] = to
+		}
+	}
+
+	var in io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	batch, skipped, err := format.Import(in, opts)
+	if err != nil {
+		return err
+	}
+	for _, s := range skipped {
+		fmt.Fprintln(stdout, i18n.T("cli.import_skipped", s))
+	}
+
+	state, err := st.Load()
+	if err != nil {
+		return err
+	}
+	svc := app.NewService(state)
+	defer st.Attach(svc)()
+	result, err := svc.Import(batch)
+	if err != nil {
+		return err
+	}
+	if err := st.Autosave(svc.State()); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, i18n.T("cli.imported", result.ListsCreated, result.TasksCreated, result.TasksUpdated, result.Archived))
+	return nil
+}
+
+func parseDateFlag(name, raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, usageError(i18n.T("cli.export_bad_date", name, raw))
+	}
+	return t, nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+
+	"todo-cli/app"
+	"todo-cli/config"
+	"todo-cli/exchange"
+	"todo-cli/hooks"
+	"todo-cli/i18n"
+	"todo-cli/store"
+	"todo-cli/tui"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("todo", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	// O idioma do ambiente vale já para o uso e os erros de flags; o da
+	// configuração só é conhecido depois do parse.
+	i18n.SetLocale(i18n.Detect(""))
+	statePath := fs.String("state", defaultStatePath(), i18n.T("cli.flag_state"))
+	configPath := fs.String("config", "", i18n.T("cli.flag_config"))
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, i18n.T("cli.usage", strings.Join(exchange.Names(), ", ")))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if *configPath == "" {
+		*configPath = config.PathFor(*statePath)
+	}
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(stderr, i18n.T("cli.config_error", i18n.Error(err)))
+		return 1
+	}
+	i18n.SetLocale(i18n.Detect(cfg.Locale))
+	opts := cfg.StoreOptions()
+	if err := resolvePassphrase(&opts, *statePath, stderr); err != nil {
+		fmt.Fprintln(stderr, i18n.T("cli.error", i18n.Error(err)))
+		return 1
+	}
+	st := store.New(*statePath, opts)
+
+	rest := fs.Args()
+	if len(rest) == 0 {
+		return runTUI(st, cfg, stderr)
+	}
+
+	switch rest[0] {
+	case "backups":
+		err = runBackups(st, rest[1:], stdout)
+	case "doctor":
+		err = runDoctor(st, rest[1:], stdout)
+	case "export":
+		err = runExport(st, rest[1:], stdout)
+	case "import":
+		err = runImport(st, rest[1:], stdout)
+	case "serve":
+		err = runServe(st, cfg.HookOptions(), rest[1:], stdout)
+	default:
+		fmt.Fprintln(stderr, i18n.T("cli.unknown_command", rest[0]))
+		fmt.Fprintln(stderr)
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		var usage usageError
+		if errors.As(err, &usage) {
+			fmt.Fprintln(stderr, usage.Error())
+			return 2
+		}
+		fmt.Fprintln(stderr, i18n.T("cli.error", i18n.Error(err)))
+		return 1
+	}
+	return 0
+}
+
+func runTUI(st *store.Store, cfg config.Config, stderr io.Writer) int {
+	// O keymap é validado antes de abrir a TUI: conflitos não podem deixar
+	// uma ação destrutiva numa tecla inesperada.
+	keys, err := tui.LoadKeymap(cfg.KeymapPath())
+	if err != nil {
+		fmt.Fprintln(stderr, i18n.T("cli.config_error", i18n.Error(err)))
+		return 1
+	}
+	theme, err := tui.LoadTheme(cfg.ThemeSource())
+	if err != nil {
+		fmt.Fprintln(stderr, i18n.T("cli.config_error", i18n.Error(err)))
+		return 1
+	}
+	state, status, err := st.LoadWithRecovery()
+	if err != nil {
+		fmt.Fprintln(stderr, i18n.T("cli.load_error", i18n.Error(err)))
+		return 1
+	}
+	svc := app.NewService(state)
+	// Falhas de hooks posteriores vão para a barra de status; se a TUI estiver
+	// atrasada, as mais antigas bastam.
+	failures := make(chan error, 8)
+	detach := hooks.New(cfg.HookOptions()).Attach(svc, func(err error) {
+		select {
+		case failures <- err:
+		default:
+		}
+	})
+	defer detach()
+	defer st.Attach(svc)()
+	m := tui.NewModel(svc, st.Path(), status)
+	m.SetStore(st)
+	m.SetKeymap(keys)
+	m.SetTheme(theme)
+	m.WatchHooks(failures)
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !cfg.DisableMouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	if _, err := tea.NewProgram(m, opts...).Run(); err != nil {
+		fmt.Fprintln(stderr, i18n.T("cli.error", i18n.Error(err)))
+		return 1
+	}
+	return 0
+}
+
+// resolvePassphrase reads the passphrase from the environment or, when the
+// state is (or must become) encrypted, prompts for it on the terminal.
+func resolvePassphrase(opts *store.Options, statePath string, stderr io.Writer) error {
+	opts.Passphrase = os.Getenv(config.PassphraseEnv)
+	if opts.Passphrase != "" {
+		return nil
+	}
+	encrypted, err := store.IsEncrypted(statePath)
+	if err != nil {
+		return err
+	}
+	if !opts.Encrypt && !encrypted {
+		return nil
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return i18n.Errorf("cli.passphrase_env", config.PassphraseEnv)
+	}
+	fmt.Fprint(stderr, i18n.T("cli.passphrase_prompt"))
+	pass, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(stderr)
+	if err != nil {
+		return err
+	}
+	opts.Passphrase = string(pass)
+	if opts.Passphrase == "" {
+		return store.ErrPassphraseRequired
+	}
+	return nil
+}
+
+// usageError is returned by subcommands when their arguments are malformed.
+type usageError string
+
+func (u usageError) Error() string {
+	return i18n.T("cli.usage_line", string(u))
+}
+
+func defaultStatePath() string {
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "todo-cli", "state.json")
+	}
+	return "state.json"
+}
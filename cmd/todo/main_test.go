@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+	"todo-cli/server"
+	"todo-cli/store"
+)
+
+func TestBackupsListAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	svc := app.NewService(model.NewState())
+	if _, err := svc.CreateList("Inbox", "blue"); err != nil {
+		t.Fatalf("create list failed: %v", err)
+	}
+	if err := store.Save(path, svc.State()); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if err := store.Autosave(path, model.NewState()); err != nil {
+		t.Fatalf("autosave failed: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"-state", path, "backups", "list"}, &out, &errOut); code != 0 {
+		t.Fatalf("backups list exited %d: %s", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), store.LatestBackupID) {
+		t.Fatalf("expected latest backup in listing, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if code := run([]string{"-state", path, "backups", "restore", store.LatestBackupID}, &out, &errOut); code != 0 {
+		t.Fatalf("backups restore exited %d: %s", code, errOut.String())
+	}
+	restored, err := store.Load(path)
+	if err != nil {
+		t.Fatalf("load after restore failed: %v", err)
+	}
+	if len(restored.Lists) != 1 || restored.Lists[0].Name != "Inbox" {
+		t.Fatalf("expected Inbox to be restored, got %+v", restored.Lists)
+	}
+
+	errOut.Reset()
+	if code := run([]string{"-state", path, "backups", "restore"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected usage exit code 2, got %d", code)
+	}
+}
+
+func TestDoctorReportsAndFixes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	svc := app.NewService(model.NewState())
+	list, err := svc.CreateList("Inbox", "blue")
+	if err != nil {
+		t.Fatalf("create list failed: %v", err)
+	}
+	if _, err := svc.CreateTask(list.ID, "duplicada"); err != nil {
+		t.Fatalf("create task failed: %v", err)
+	}
+	state := svc.State()
+	twin := state.Tasks[0]
+	twin.Text = "gêmea"
+	state.Tasks = append(state.Tasks, twin)
+	if err := store.Save(path, state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"-state", path, "doctor"}, &out, &errOut); code != 1 {
+		t.Fatalf("expected doctor to exit 1 on errors, got %d: %s", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), store.IssueDuplicateTaskID) {
+		t.Fatalf("expected duplicate id to be reported, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if code := run([]string{"-state", path, "doctor", "--fix"}, &out, &errOut); code != 0 {
+		t.Fatalf("doctor --fix exited %d: %s", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), "backup") {
+		t.Fatalf("expected the fix to name its backup, got:\n%s", out.String())
+	}
+	fixed, err := store.Load(path)
+	if err != nil {
+		t.Fatalf("load after fix failed: %v", err)
+	}
+	if issues := store.Validate(fixed); len(issues) != 0 {
+		t.Fatalf("expected clean state after fix, got %v", issues)
+	}
+
+	out.Reset()
+	if code := run([]string{"-state", path, "doctor"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected clean doctor run, got %d:\n%s", code, out.String())
+	}
+}
+
+func TestDoctorFixInJournalModeDoesNotClaimBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	svc := app.NewService(model.NewState())
+	list, err := svc.CreateList("Inbox", "blue")
+	if err != nil {
+		t.Fatalf("create list failed: %v", err)
+	}
+	if _, err := svc.CreateTask(list.ID, "duplicada"); err != nil {
+		t.Fatalf("create task failed: %v", err)
+	}
+	state := svc.State()
+	state.Tasks = append(state.Tasks, state.Tasks[0])
+	if err := store.Save(path, state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	opts := store.DefaultOptions()
+	opts.Journal = true
+	var out bytes.Buffer
+	if err := runDoctor(store.New(path, opts), []string{"--fix"}, &out); err != nil {
+		t.Fatalf("doctor --fix failed: %v", err)
+	}
+	if strings.Contains(out.String(), "backup") {
+		t.Fatalf("journal autosave writes no backup, got:\n%s", out.String())
+	}
+}
+
+func TestCommandLineFollowsLocale(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"-state", path, "frobnicate"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected usage exit code 2, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "unknown command: frobnicate") || !strings.Contains(errOut.String(), "usage: todo") {
+		t.Fatalf("expected English usage, got:\n%s", errOut.String())
+	}
+
+	errOut.Reset()
+	if code := run([]string{"-state", path, "export", "nope"}, &out, &errOut); code != 1 {
+		t.Fatalf("expected export of an unknown format to fail, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "available:") {
+		t.Fatalf("expected English format list, got:\n%s", errOut.String())
+	}
+}
+
+func TestExportImportTodoTxt(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.json")
+	dst := filepath.Join(dir, "dst.json")
+	file := filepath.Join(dir, "todo.txt")
+
+	svc := app.NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "green")
+	if _, err := svc.CreateTask(list.ID, "Regar plantas"); err != nil {
+		t.Fatalf("create task failed: %v", err)
+	}
+	if err := store.Save(src, svc.State()); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"-state", src, "export", "todotxt", "-o", file}, &out, &errOut); code != 0 {
+		t.Fatalf("export exited %d: %s", code, errOut.String())
+	}
+	if code := run([]string{"-state", dst, "import", "todotxt", file}, &out, &errOut); code != 0 {
+		t.Fatalf("import exited %d: %s", code, errOut.String())
+	}
+	got, err := store.Load(dst)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(got.Lists) != 1 || got.Lists[0].Name != "Casa" || len(got.Tasks) != 1 || got.Tasks[0].Text != "Regar plantas" {
+		t.Fatalf("unexpected imported state: %+v", got)
+	}
+
+	if code := run([]string{"-state", dst, "export", "nope"}, &out, &errOut); code != 1 {
+		t.Fatalf("expected unknown format to fail, got %d", code)
+	}
+}
+
+func TestServeAnswersAndStops(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	svc := app.NewService(model.NewState())
+	if _, err := svc.CreateList("Inbox", ""); err != nil {
+		t.Fatalf("create list failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serve(ctx, ln, server.New(svc, nil)) }()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/lists")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"Inbox"`) {
+		t.Fatalf("unexpected response %d: %s", resp.StatusCode, body)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("serve returned %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/hooks"
+	"todo-cli/i18n"
+	"todo-cli/server"
+	"todo-cli/store"
+)
+
+const defaultServeAddr = "127.0.0.1:7878"
+
+func runServe(st *store.Store, hookOpts hooks.Options, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	addr := fs.String("addr", defaultServeAddr, i18n.T("cli.serve_flag_addr"))
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return usageError(i18n.T("cli.serve_usage"))
+	}
+
+	state, status, err := st.LoadWithRecovery()
+	if err != nil {
+		return err
+	}
+	if status != "" {
+		fmt.Fprintln(stdout, status)
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	if host, _, _ := net.SplitHostPort(*addr); !isLoopback(host) {
+		fmt.Fprintln(stdout, i18n.T("cli.serve_public"))
+	}
+	fmt.Fprintln(stdout, i18n.T("cli.serving", st.Path(), ln.Addr()))
+
+	svc := app.NewService(state)
+	detach := hooks.New(hookOpts).Attach(svc, func(err error) {
+		fmt.Fprintln(stdout, i18n.T("cli.hook_failed", i18n.Error(err)))
+	})
+	defer detach()
+	defer st.Attach(svc)()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return serve(ctx, ln, server.New(svc, st))
+}
+
+// serve runs until ctx is cancelled, then drains in-flight requests.
+func serve(ctx context.Context, ln net.Listener, h http.Handler) error {
+	srv := &http.Server{Handler: h, ReadHeaderTimeout: 5 * time.Second}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
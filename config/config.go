@@ -0,0 +1,251 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-cli/hooks"
+	"todo-cli/i18n"
+	"todo-cli/store"
+)
+
+// FileName is the config file looked up next to the state file.
+const FileName = "config.json"
+
+// KeymapFileName is the default keymap file, next to the config.
+const KeymapFileName = "keymap.json"
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Config holds user preferences. Zero values mean "use the default".
+type Config struct {
+	Backup      BackupConfig      `json:"backup,omitempty"`
+	Encryption  EncryptionConfig  `json:"encryption,omitempty"`
+	Persistence PersistenceConfig `json:"persistence,omitempty"`
+	Hooks       HooksConfig       `json:"hooks,omitempty"`
+
+	// Locale picks the UI language ("pt-BR" or "en"); empty follows LANG.
+	Locale string `json:"locale,omitempty"`
+
+	// Keymap is the key bindings file (default: KeymapFileName).
+	Keymap string `json:"keymap,omitempty"`
+
+	// Theme is a built-in theme name ("dark", "light", "high-contrast") or
+	// the path of a theme file ending in ".json".
+	Theme string `json:"theme,omitempty"`
+
+	// DisableMouse leaves the mouse to the terminal, e.g. to select text.
+	DisableMouse bool `json:"disableMouse,omitempty"`
+
+	// dir is where the config was read from; relative paths resolve against it.
+	dir string
+}
+
+// BackupConfig mirrors store.BackupPolicy with JSON-friendly durations.
+type BackupConfig struct {
+	Dir         string       `json:"dir,omitempty"`
+	Keep        int          `json:"keep,omitempty"`
+	MinInterval *Duration    `json:"minInterval,omitempty"`
+	Tiers       []TierConfig `json:"tiers,omitempty"`
+}
+
+// EncryptionConfig turns on encryption at rest. The passphrase itself is never
+// stored in the config; it comes from PassphraseEnv or an interactive prompt.
+type EncryptionConfig struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// Persistence modes.
+const (
+	PersistenceSnapshot = "snapshot"
+	PersistenceJournal  = "journal"
+)
+
+// PersistenceConfig picks between rewriting the whole state file on every
+// save ("snapshot", the default) and an append-only journal ("journal").
+type PersistenceConfig struct {
+	Mode         string `json:"mode,omitempty"`
+	CompactEvery int    `json:"compactEvery,omitempty"`
+}
+
+// HooksConfig points at the hook scripts directory (default: "hooks" next
+// to the config) and bounds how long each script may run.
+type HooksConfig struct {
+	Dir     string    `json:"dir,omitempty"`
+	Timeout *Duration `json:"timeout,omitempty"`
+}
+
+// PassphraseEnv names the environment variable holding the state passphrase.
+const PassphraseEnv = "TODO_CLI_PASSPHRASE"
+
+// TierConfig is one age-based retention tier, e.g. {"every": "1h", "for": "1d"}.
+type TierConfig struct {
+	Every Duration `json:"every"`
+	For   Duration `json:"for"`
+}
+
+// Duration accepts Go duration strings plus a "d" suffix for days.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return i18n.Errorf("config.duration_string", ErrInvalidConfig)
+	}
+	parsed, err := ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// ParseDuration parses time.ParseDuration syntax, plus whole days ("7d").
+func ParseDuration(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, i18n.Errorf("config.invalid_duration", ErrInvalidConfig, raw)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return 0, i18n.Errorf("config.invalid_duration", ErrInvalidConfig, raw)
+	}
+	return d, nil
+}
+
+// PathFor returns the default config location for a state file.
+func PathFor(statePath string) string {
+	return filepath.Join(filepath.Dir(statePath), FileName)
+}
+
+// Load reads the config file. A missing file yields an empty Config.
+func Load(path string) (Config, error) {
+	cfg := Config{dir: filepath.Dir(path)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return Config{}, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	if err := cfg.validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return cfg, nil
+}
+
+func (c Config) validate() error {
+	if c.Backup.Keep < 0 {
+		return i18n.Errorf("config.negative", ErrInvalidConfig, "backup.keep")
+	}
+	switch c.Persistence.Mode {
+	case "", PersistenceSnapshot, PersistenceJournal:
+	default:
+		return i18n.Errorf("config.persistence_mode", ErrInvalidConfig, PersistenceSnapshot, PersistenceJournal)
+	}
+	if c.Persistence.CompactEvery < 0 {
+		return i18n.Errorf("config.negative", ErrInvalidConfig, "persistence.compactEvery")
+	}
+	if c.Locale != "" {
+		if _, err := i18n.Parse(c.Locale); err != nil {
+			return i18n.Errorf("config.locale", ErrInvalidConfig, i18n.Locales())
+		}
+	}
+	if c.Hooks.Timeout != nil && *c.Hooks.Timeout <= 0 {
+		return i18n.Errorf("config.positive", ErrInvalidConfig, "hooks.timeout")
+	}
+	for i, tier := range c.Backup.Tiers {
+		if tier.Every <= 0 {
+			return i18n.Errorf("config.positive", ErrInvalidConfig, fmt.Sprintf("backup.tiers[%d].every", i))
+		}
+		if tier.For < tier.Every {
+			return i18n.Errorf("config.tier_for", ErrInvalidConfig, i)
+		}
+	}
+	return nil
+}
+
+// StoreOptions layers the config on top of store.DefaultOptions.
+// The passphrase is filled in by the caller.
+func (c Config) StoreOptions() store.Options {
+	opts := store.DefaultOptions()
+	opts.Encrypt = c.Encryption.Enabled
+	opts.Journal = c.Persistence.Mode == PersistenceJournal
+	opts.CompactEvery = c.Persistence.CompactEvery
+	if c.Backup.Dir != "" {
+		opts.Backup.Dir = c.resolvePath(c.Backup.Dir)
+	}
+	if c.Backup.Keep > 0 {
+		opts.Backup.Keep = c.Backup.Keep
+	}
+	if c.Backup.MinInterval != nil {
+		opts.Backup.MinInterval = time.Duration(*c.Backup.MinInterval)
+	}
+	if c.Backup.Tiers != nil {
+		opts.Backup.Tiers = make([]store.RetentionTier, 0, len(c.Backup.Tiers))
+		for _, tier := range c.Backup.Tiers {
+			opts.Backup.Tiers = append(opts.Backup.Tiers, store.RetentionTier{
+				Every: time.Duration(tier.Every),
+				For:   time.Duration(tier.For),
+			})
+		}
+	}
+	return opts
+}
+
+// HookOptions resolves the hooks directory and timeout.
+func (c Config) HookOptions() hooks.Options {
+	opts := hooks.Options{Dir: c.resolvePath(hooks.DirName), Timeout: hooks.DefaultTimeout}
+	if c.Hooks.Dir != "" {
+		opts.Dir = c.resolvePath(c.Hooks.Dir)
+	}
+	if c.Hooks.Timeout != nil {
+		opts.Timeout = time.Duration(*c.Hooks.Timeout)
+	}
+	return opts
+}
+
+// KeymapPath resolves the key bindings file; it need not exist.
+func (c Config) KeymapPath() string {
+	if c.Keymap != "" {
+		return c.resolvePath(c.Keymap)
+	}
+	return c.resolvePath(KeymapFileName)
+}
+
+// ThemeSource resolves a theme file path; built-in names are returned as is.
+func (c Config) ThemeSource() string {
+	if strings.HasSuffix(c.Theme, ".json") {
+		return c.resolvePath(c.Theme)
+	}
+	return c.Theme
+}
+
+func (c Config) resolvePath(p string) string {
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	if filepath.IsAbs(p) || c.dir == "" {
+		return p
+	}
+	return filepath.Join(c.dir, p)
+}
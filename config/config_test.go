@@ -0,0 +1,162 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"todo-cli/hooks"
+	"todo-cli/store"
+)
+
+func TestLoadMissingConfigUsesStoreDefaults(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("load missing config failed: %v", err)
+	}
+	if !reflect.DeepEqual(store.DefaultOptions(), cfg.StoreOptions()) {
+		t.Fatalf("expected default store options, got %+v", cfg.StoreOptions())
+	}
+}
+
+func TestLoadBackupRetention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	raw := `{
+  "backup": {
+    "dir": "snapshots",
+    "keep": 3,
+    "minInterval": "0s",
+    "tiers": [{"every": "1h", "for": "1d"}, {"every": "1d", "for": "30d"}]
+  }
+}`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("load config failed: %v", err)
+	}
+	opts := cfg.StoreOptions()
+	want := store.BackupPolicy{
+		Dir:         filepath.Join(dir, "snapshots"),
+		Keep:        3,
+		MinInterval: 0,
+		Tiers: []store.RetentionTier{
+			{Every: time.Hour, For: 24 * time.Hour},
+			{Every: 24 * time.Hour, For: 30 * 24 * time.Hour},
+		},
+	}
+	if !reflect.DeepEqual(want, opts.Backup) {
+		t.Fatalf("unexpected backup policy\nwant=%+v\ngot=%+v", want, opts.Backup)
+	}
+}
+
+func TestLoadRejectsInvalidTier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	raw := `{"backup": {"tiers": [{"every": "1d", "for": "1h"}]}}`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	if _, err := Load(path); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestLoadPersistenceMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	raw := `{"persistence": {"mode": "journal", "compactEvery": 50}}`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("load config failed: %v", err)
+	}
+	opts := cfg.StoreOptions()
+	if !opts.Journal || opts.CompactEvery != 50 {
+		t.Fatalf("expected journal mode compacting every 50, got %+v", opts)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"persistence": {"mode": "wal"}}`), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	if _, err := Load(path); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestLoadHooks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("load missing config failed: %v", err)
+	}
+	want := hooks.Options{Dir: filepath.Join(dir, hooks.DirName), Timeout: hooks.DefaultTimeout}
+	if got := cfg.HookOptions(); got != want {
+		t.Fatalf("expected default hook options %+v, got %+v", want, got)
+	}
+	if got := cfg.KeymapPath(); got != filepath.Join(dir, KeymapFileName) {
+		t.Fatalf("expected default keymap next to the config, got %q", got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"hooks": {"dir": "scripts", "timeout": "2s"}}`), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	if cfg, err = Load(path); err != nil {
+		t.Fatalf("load config failed: %v", err)
+	}
+	want = hooks.Options{Dir: filepath.Join(dir, "scripts"), Timeout: 2 * time.Second}
+	if got := cfg.HookOptions(); got != want {
+		t.Fatalf("expected hook options %+v, got %+v", want, got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"hooks": {"timeout": "0s"}}`), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	if _, err := Load(path); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestLoadLocale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte(`{"locale": "en"}`), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("load config failed: %v", err)
+	}
+	if cfg.Locale != "en" {
+		t.Fatalf("expected locale en, got %q", cfg.Locale)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"locale": "fr"}`), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	if _, err := Load(path); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestThemeSource(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"":                  "",
+		"light":             "light",
+		"themes/solar.json": filepath.Join(dir, "themes", "solar.json"),
+		"/etc/todo/hc.json": "/etc/todo/hc.json",
+	}
+	for theme, want := range cases {
+		cfg := Config{Theme: theme, dir: dir}
+		if got := cfg.ThemeSource(); got != want {
+			t.Fatalf("ThemeSource(%q) = %q, want %q", theme, got, want)
+		}
+	}
+}
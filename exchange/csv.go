@@ -0,0 +1,439 @@
+package exchange
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// ErrUnknownColumn is returned for column names a CSV exporter does not know.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// CSV column names.
+const (
+	ColID         = "id"
+	ColList       = "list"
+	ColText       = "text"
+	ColPriority   = "priority"
+	ColDone       = "done"
+	ColStatus     = "status"
+	ColStatuses   = "statuses"
+	ColPinned     = "pinned"
+	ColPosition   = "position"
+	ColCreated    = "created"
+	ColUpdated    = "updated"
+	ColDoneAt     = "doneAt"
+	ColArchivedAt = "archivedAt"
+)
+
+// csvTime is spreadsheet-friendly; imports also accept RFC 3339 and plain dates.
+const csvTime = "2006-01-02 15:04:05"
+
+var (
+	csvTaskColumns    = []string{ColList, ColText, ColPriority, ColDone, ColCreated, ColUpdated, ColPosition, ColStatus, ColStatuses, ColPinned}
+	csvArchiveColumns = []string{ColList, ColText, ColPriority, ColDoneAt, ColArchivedAt}
+)
+
+// csvAliases maps lowercased header names people commonly use to columns.
+var csvAliases = map[string]string{
+	"id": ColID, "uuid": ColID,
+	"list": ColList, "lista": ColList, "project": ColList, "projeto": ColList, "category": ColList, "categoria": ColList,
+	"text": ColText, "texto": ColText, "task": ColText, "tarefa": ColText, "title": ColText, "título": ColText, "titulo": ColText, "description": ColText, "descrição": ColText,
+	"priority": ColPriority, "prioridade": ColPriority,
+	"done": ColDone, "concluída": ColDone, "concluida": ColDone, "completed": ColDone,
+	"status": ColStatus, "estado": ColStatus, "column": ColStatus, "coluna": ColStatus,
+	"statuses": ColStatuses, "columns": ColStatuses, "colunas": ColStatuses,
+	"pinned": ColPinned, "fixada": ColPinned, "starred": ColPinned,
+	"position": ColPosition, "posição": ColPosition, "posicao": ColPosition, "order": ColPosition, "ordem": ColPosition,
+	"created": ColCreated, "createdat": ColCreated, "created_at": ColCreated, "criada": ColCreated, "criado em": ColCreated,
+	"updated": ColUpdated, "updatedat": ColUpdated, "updated_at": ColUpdated, "atualizada": ColUpdated,
+	"doneat": ColDoneAt, "done_at": ColDoneAt, "concluída em": ColDoneAt,
+	"archivedat": ColArchivedAt, "archived_at": ColArchivedAt, "arquivada em": ColArchivedAt,
+}
+
+func init() {
+	register(Format{Name: "csv", Ext: ".csv", Export: ExportCSV, Import: ImportCSV})
+	register(Format{Name: "csv-archive", Ext: ".csv", Export: ExportArchiveCSV, Import: ImportArchiveCSV})
+}
+
+// ExportCSV writes active tasks, one row each, with a header.
+func ExportCSV(w io.Writer, state model.AppState, opts ExportOptions) error {
+	columns, err := csvColumns(opts.Columns, csvTaskColumns, false)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, l := range exportLists(state, opts) {
+		for _, t := range listTasks(state, l.ID) {
+			if !opts.inRange(t.UpdatedAt) {
+				continue
+			}
+			row := make([]string, len(columns))
+			for i, col := range columns {
+				row[i] = csvTaskField(t, l, col)
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportArchiveCSV writes ArchivedCompleted entries, one row each.
+func ExportArchiveCSV(w io.Writer, state model.AppState, opts ExportOptions) error {
+	columns, err := csvColumns(opts.Columns, csvArchiveColumns, true)
+	if err != nil {
+		return err
+	}
+	opts.IncludeArchive = true
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, a := range exportArchive(state, opts) {
+		if !opts.inRange(a.DoneAt) {
+			continue
+		}
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = csvArchiveField(a, col)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvColumns(requested, defaults []string, archive bool) ([]string, error) {
+	if len(requested) == 0 {
+		return defaults, nil
+	}
+	allowed := map[string]bool{ColID: true, ColList: true, ColText: true, ColPriority: true, ColDoneAt: true}
+	if archive {
+		allowed[ColArchivedAt] = true
+	} else {
+		for _, col := range []string{ColDone, ColStatus, ColStatuses, ColPinned, ColPosition, ColCreated, ColUpdated} {
+			allowed[col] = true
+		}
+	}
+	out := make([]string, 0, len(requested))
+	for _, raw := range requested {
+		col := csvCanonical(raw)
+		if !allowed[col] {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, raw)
+		}
+		out = append(out, col)
+	}
+	return out, nil
+}
+
+// csvCanonical resolves a header or column name through the alias table.
+func csvCanonical(name string) string {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if col, ok := csvAliases[key]; ok {
+		return col
+	}
+	return strings.TrimSpace(name)
+}
+
+func csvTaskField(t model.Task, l model.List, col string) string {
+	switch col {
+	case ColID:
+		return t.ID
+	case ColList:
+		return l.Name
+	case ColText:
+		return t.Text
+	case ColPriority:
+		return csvPriorityName(t.Priority)
+	case ColDone:
+		return strconv.FormatBool(t.Done)
+	case ColStatus:
+		return t.Status
+	case ColStatuses:
+		return joinStatuses(l.Statuses)
+	case ColPinned:
+		return strconv.FormatBool(t.Pinned)
+	case ColPosition:
+		return strconv.Itoa(t.Position)
+	case ColCreated:
+		return csvFormatTime(t.CreatedAt)
+	case ColUpdated:
+		return csvFormatTime(t.UpdatedAt)
+	case ColDoneAt:
+		return csvFormatTime(t.DoneAt)
+	}
+	return ""
+}
+
+func csvArchiveField(a model.ArchivedCompletedTask, col string) string {
+	switch col {
+	case ColID:
+		return a.ID
+	case ColList:
+		return a.OriginList
+	case ColText:
+		return a.TaskText
+	case ColPriority:
+		return csvPriorityName(a.Priority)
+	case ColDoneAt:
+		return csvFormatTime(a.DoneAt)
+	case ColArchivedAt:
+		return csvFormatTime(a.ArchivedAt)
+	}
+	return ""
+}
+
+func csvFormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(csvTime)
+}
+
+func csvParseTime(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, csvTime, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, i18n.Errorf("exchange.bad_date", raw)
+}
+
+func csvPriorityName(p model.Priority) string {
+	switch p {
+	case model.PriorityHigh:
+		return "high"
+	case model.PriorityMedium:
+		return "medium"
+	case model.PriorityLow:
+		return "low"
+	}
+	return ""
+}
+
+func csvParsePriority(raw string) (model.Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "0", "none", "nenhuma":
+		return model.PriorityNone, nil
+	case "1", "low", "baixa", "l", "c":
+		return model.PriorityLow, nil
+	case "2", "medium", "média", "media", "m", "b":
+		return model.PriorityMedium, nil
+	case "3", "high", "alta", "h", "a":
+		return model.PriorityHigh, nil
+	}
+	return model.PriorityNone, i18n.Errorf("exchange.bad_priority", raw)
+}
+
+func csvParseDone(raw string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "0", "false", "no", "não", "nao", "n", "todo", "pending", "pendente", "open":
+		return false, nil
+	case "1", "true", "yes", "sim", "s", "y", "x", "done", "completed", "concluída", "concluida":
+		return true, nil
+	}
+	return false, i18n.Errorf("exchange.bad_done", raw)
+}
+
+// csvParsePosition reads the order of a task in its list; empty puts it at
+// the end.
+func csvParsePosition(raw string) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+	pos, err := strconv.Atoi(raw)
+	if err != nil || pos < 0 {
+		return 0, i18n.Errorf("exchange.bad_position", raw)
+	}
+	return pos, nil
+}
+
+func csvParsePinned(raw string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "0", "false", "no", "não", "nao", "n":
+		return false, nil
+	case "1", "true", "yes", "sim", "s", "y", "x", "★":
+		return true, nil
+	}
+	return false, i18n.Errorf("exchange.bad_pinned", raw)
+}
+
+// csvRows reads the header, resolves it through opts.Columns and the alias
+// table, and yields each record as column → value. Headers that map to no
+// known column are returned under their original name.
+func csvRows(r io.Reader, opts ImportOptions, each func(line int, row map[string]string, raw []string)) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+	mapping := make(map[string]string, len(opts.Columns))
+	for from, to := range opts.Columns {
+		mapping[strings.ToLower(strings.TrimSpace(from))] = csvCanonical(to)
+	}
+	columns := make([]string, len(header))
+	for i, h := range header {
+		h = strings.TrimPrefix(h, "\ufeff")
+		if col, ok := mapping[strings.ToLower(strings.TrimSpace(h))]; ok {
+			columns[i] = col
+		} else {
+			columns[i] = csvCanonical(h)
+		}
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := cr.FieldPos(0)
+		row := make(map[string]string, len(record))
+		for i, value := range record {
+			if i < len(columns) && columns[i] != "" {
+				row[columns[i]] = value
+			}
+		}
+		each(line, row, record)
+	}
+}
+
+var csvKnownTaskColumns = map[string]bool{
+	ColID: true, ColList: true, ColText: true, ColPriority: true, ColDone: true,
+	ColStatus: true, ColStatuses: true, ColPinned: true, ColPosition: true, ColCreated: true, ColUpdated: true, ColDoneAt: true,
+}
+
+// ImportCSV reads tasks from a CSV with a header row. Columns that match no
+// task field are kept in Task.Extra under their header name.
+func ImportCSV(r io.Reader, opts ImportOptions) (app.ImportBatch, []Skipped, error) {
+	b := newBatchBuilder()
+	var skipped []Skipped
+	err := csvRows(r, opts, func(line int, row map[string]string, raw []string) {
+		skip := func(reason string) {
+			skipped = append(skipped, Skipped{Line: line, Text: strings.Join(raw, ","), Reason: reason})
+		}
+		text := strings.TrimSpace(row[ColText])
+		if text == "" {
+			skip(i18n.T("exchange.no_text"))
+			return
+		}
+		task := model.Task{ID: strings.TrimSpace(row[ColID]), Text: text, Status: strings.TrimSpace(row[ColStatus])}
+		var err error
+		if task.Priority, err = csvParsePriority(row[ColPriority]); err != nil {
+			skip(i18n.Error(err))
+			return
+		}
+		if task.Done, err = csvParseDone(row[ColDone]); err != nil {
+			skip(i18n.Error(err))
+			return
+		}
+		if task.Pinned, err = csvParsePinned(row[ColPinned]); err != nil {
+			skip(i18n.Error(err))
+			return
+		}
+		if task.Position, err = csvParsePosition(row[ColPosition]); err != nil {
+			skip(i18n.Error(err))
+			return
+		}
+		for col, dst := range map[string]*time.Time{ColCreated: &task.CreatedAt, ColUpdated: &task.UpdatedAt, ColDoneAt: &task.DoneAt} {
+			if *dst, err = csvParseTime(row[col]); err != nil {
+				skip(i18n.Error(err))
+				return
+			}
+		}
+		if !task.DoneAt.IsZero() {
+			task.Done = true
+		}
+		columns := splitStatuses(row[ColStatuses])
+		// Planilhas de quadro costumam trazer só a coluna: a última conclui.
+		board := columns
+		if len(board) == 0 {
+			board = model.DefaultStatuses()
+		}
+		if strings.TrimSpace(row[ColDone]) == "" && task.Status == board[len(board)-1] {
+			task.Done = true
+		}
+		for col, value := range row {
+			if !csvKnownTaskColumns[col] && strings.TrimSpace(value) != "" {
+				if task.Extra == nil {
+					task.Extra = map[string]string{}
+				}
+				task.Extra[col] = value
+			}
+		}
+		b.addTask(row[ColList], task)
+		if len(columns) > 0 {
+			b.columns(row[ColList], columns)
+		}
+	})
+	if err != nil {
+		return app.ImportBatch{}, nil, err
+	}
+	return b.batch, skipped, nil
+}
+
+// ImportArchiveCSV reads archive entries written by ExportArchiveCSV.
+func ImportArchiveCSV(r io.Reader, opts ImportOptions) (app.ImportBatch, []Skipped, error) {
+	var batch app.ImportBatch
+	var skipped []Skipped
+	err := csvRows(r, opts, func(line int, row map[string]string, raw []string) {
+		skip := func(reason string) {
+			skipped = append(skipped, Skipped{Line: line, Text: strings.Join(raw, ","), Reason: reason})
+		}
+		a := model.ArchivedCompletedTask{
+			ID:         strings.TrimSpace(row[ColID]),
+			TaskText:   strings.TrimSpace(row[ColText]),
+			OriginList: strings.TrimSpace(row[ColList]),
+		}
+		if a.TaskText == "" {
+			skip(i18n.T("exchange.no_text"))
+			return
+		}
+		var err error
+		if a.Priority, err = csvParsePriority(row[ColPriority]); err != nil {
+			skip(i18n.Error(err))
+			return
+		}
+		if a.DoneAt, err = csvParseTime(row[ColDoneAt]); err != nil {
+			skip(i18n.Error(err))
+			return
+		}
+		if a.ArchivedAt, err = csvParseTime(row[ColArchivedAt]); err != nil {
+			skip(i18n.Error(err))
+			return
+		}
+		batch.Archived = append(batch.Archived, a)
+	})
+	if err != nil {
+		return app.ImportBatch{}, nil, err
+	}
+	return batch, skipped, nil
+}
@@ -0,0 +1,179 @@
+package exchange
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func TestExportCSVColumnsAndRange(t *testing.T) {
+	state := richState(t)
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, state, ExportOptions{}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "list,text,priority,done,created,updated,position,status,statuses,pinned" {
+		t.Fatalf("unexpected header %q", lines[0])
+	}
+	if len(lines) != 1+len(state.Tasks) {
+		t.Fatalf("expected one row per task, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[1], "Casa Nova,Comprar tinta,high,false,") {
+		t.Fatalf("unexpected first row %q", lines[1])
+	}
+
+	buf.Reset()
+	if err := ExportCSV(&buf, state, ExportOptions{Columns: []string{"texto", "done"}}); err != nil {
+		t.Fatalf("export with columns failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "text,done\n") {
+		t.Fatalf("expected selected columns, got %q", buf.String())
+	}
+	if err := ExportCSV(&buf, state, ExportOptions{Columns: []string{"archivedAt"}}); !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("expected ErrUnknownColumn, got %v", err)
+	}
+
+	buf.Reset()
+	future := time.Now().Add(time.Hour)
+	if err := ExportArchiveCSV(&buf, state, ExportOptions{Since: future}); err != nil {
+		t.Fatalf("archive export failed: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "list,text,priority,doneAt,archivedAt" {
+		t.Fatalf("expected date range to filter every archive row, got %q", got)
+	}
+	buf.Reset()
+	if err := ExportArchiveCSV(&buf, state, ExportOptions{Until: future}); err != nil {
+		t.Fatalf("archive export failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Trabalho,Antiga,low,") {
+		t.Fatalf("expected archived row, got %q", buf.String())
+	}
+}
+
+func TestImportCSVHeaderMapping(t *testing.T) {
+	input := "\ufeffTítulo,Projeto,Prioridade,Concluída,Estimativa,Criada\n" +
+		"Escrever specs,Produto,alta,não,3h,2026-03-01\n" +
+		"Revisar PR,Produto,,sim,,2026-03-02 10:00:00\n" +
+		",Produto,,,,\n" +
+		"Migrar banco,,urgente,,,\n"
+	batch, skipped, err := ImportCSV(strings.NewReader(input), ImportOptions{})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if len(skipped) != 2 || skipped[0].Line != 4 || skipped[1].Line != 5 {
+		t.Fatalf("expected empty and invalid rows to be skipped, got %v", skipped)
+	}
+	if len(batch.Lists) != 1 || batch.Lists[0].Name != "Produto" {
+		t.Fatalf("unexpected lists: %+v", batch.Lists)
+	}
+	tasks := batch.Lists[0].Tasks
+	if tasks[0].Priority != model.PriorityHigh || tasks[0].Done || tasks[0].Extra["Estimativa"] != "3h" {
+		t.Fatalf("unexpected first task: %+v", tasks[0])
+	}
+	if !tasks[1].Done || !tasks[1].CreatedAt.Equal(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected second task: %+v", tasks[1])
+	}
+
+	custom := "Resumo,Grupo\nLigar,Vendas\n"
+	batch, _, err = ImportCSV(strings.NewReader(custom), ImportOptions{Columns: map[string]string{"Resumo": "text", "grupo": "list"}})
+	if err != nil {
+		t.Fatalf("import with mapping failed: %v", err)
+	}
+	if len(batch.Lists) != 1 || batch.Lists[0].Name != "Vendas" || batch.Lists[0].Tasks[0].Text != "Ligar" {
+		t.Fatalf("expected explicit mapping to apply, got %+v", batch.Lists)
+	}
+}
+
+func TestImportCSVPositionAndStatus(t *testing.T) {
+	input := "text,ordem,status\n" +
+		"Terceira,3,doing\n" +
+		"Primeira,1,\n" +
+		"Segunda,2,review\n" +
+		"Quebrada,dois,\n"
+	batch, skipped, err := ImportCSV(strings.NewReader(input), ImportOptions{})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0].Line != 5 || !strings.Contains(skipped[0].Reason, "dois") {
+		t.Fatalf("expected the invalid position to be skipped, got %v", skipped)
+	}
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	var got []string
+	for _, task := range svc.Tasks("") {
+		got = append(got, task.Text+":"+task.Status)
+	}
+	if want := "Primeira:todo Segunda:review Terceira:doing"; strings.Join(got, " ") != want {
+		t.Fatalf("expected %q, got %q", want, strings.Join(got, " "))
+	}
+}
+
+func TestCSVArchiveRoundTrip(t *testing.T) {
+	state := richState(t)
+	var buf bytes.Buffer
+	if err := ExportArchiveCSV(&buf, state, ExportOptions{Columns: []string{"id", "list", "text", "priority", "doneAt", "archivedAt"}}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	batch, skipped, err := ImportArchiveCSV(&buf, ImportOptions{})
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("import failed: %v %v", err, skipped)
+	}
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	got := svc.ArchivedCompleted()
+	want := state.ArchivedCompleted
+	if len(got) != len(want) || got[0].ID != want[0].ID || got[0].TaskText != want[0].TaskText ||
+		!got[0].DoneAt.Equal(want[0].DoneAt.Truncate(time.Second)) {
+		t.Fatalf("archive mismatch\nwant=%+v\ngot=%+v", want, got)
+	}
+}
+
+func TestCSVRoundTripKeepsBoard(t *testing.T) {
+	state := richState(t)
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, state, ExportOptions{}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	batch, skipped, err := ImportCSV(&buf, ImportOptions{})
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("import failed: %v %v", err, skipped)
+	}
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	for _, l := range svc.Lists() {
+		if l.Name == "Trabalho" && !reflect.DeepEqual(l.Statuses, state.Lists[1].Statuses) {
+			t.Fatalf("expected custom columns to survive, got %q", l.Statuses)
+		}
+	}
+	status := map[string]string{}
+	pinned := map[string]bool{}
+	for _, task := range svc.Tasks("") {
+		status[task.Text] = task.Status
+		pinned[task.Text] = task.Pinned
+	}
+	if status["Relatório semanal"] != "Em revisão, com o cliente" || status["Comprar tinta"] != model.StatusDoing {
+		t.Fatalf("expected statuses to survive, got %v", status)
+	}
+	if !pinned["Texto com  espaços duplos"] || pinned["Comprar tinta"] {
+		t.Fatalf("expected pins to survive, got %v", pinned)
+	}
+
+	// Uma planilha de quadro sem coluna de concluída conclui pela última coluna.
+	batch, _, err = ImportCSV(strings.NewReader("text,status\nEntregar,done\n"), ImportOptions{})
+	if err != nil || len(batch.Lists) != 1 || !batch.Lists[0].Tasks[0].Done {
+		t.Fatalf("expected the done column to complete the task, got %+v %v", batch.Lists, err)
+	}
+}
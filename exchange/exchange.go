@@ -0,0 +1,221 @@
+// Package exchange converts todo-cli state to and from other task formats.
+// Exporters read a model.AppState; importers produce an app.ImportBatch so
+// the result goes through app.Service as a single undoable change.
+package exchange
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// ErrUnknownFormat is returned by Lookup for unregistered format names.
+var ErrUnknownFormat = errors.New("unknown format")
+
+// DefaultListName receives imported tasks that do not name a list.
+const DefaultListName = "Inbox"
+
+// ExportOptions narrows what an exporter writes. Formats ignore options
+// that do not apply to them.
+type ExportOptions struct {
+	// ListID limits the export to one list; empty means all lists.
+	ListID string
+	// IncludeArchive also writes ArchivedCompleted entries.
+	IncludeArchive bool
+	// Columns picks and orders the fields of tabular formats.
+	Columns []string
+	// Since and Until bound the exported entries by date (tasks by UpdatedAt,
+	// archive entries by DoneAt) where the format supports it. Zero means open.
+	Since, Until time.Time
+}
+
+// ImportOptions tunes importers. Formats ignore options that do not apply.
+type ImportOptions struct {
+	// Columns maps input header names to field names, e.g. "Título" → "text".
+	Columns map[string]string
+}
+
+func (o ExportOptions) inRange(t time.Time) bool {
+	if !o.Since.IsZero() && t.Before(o.Since) {
+		return false
+	}
+	if !o.Until.IsZero() && !t.Before(o.Until) {
+		return false
+	}
+	return true
+}
+
+// Skipped is an input line an importer could not interpret.
+type Skipped struct {
+	Line   int
+	Text   string
+	Reason string // in the active locale
+}
+
+func (s Skipped) String() string {
+	return i18n.T("exchange.skipped", s.Line, s.Reason, s.Text)
+}
+
+// Format is a registered converter.
+type Format struct {
+	Name   string
+	Ext    string
+	Export func(w io.Writer, state model.AppState, opts ExportOptions) error
+	Import func(r io.Reader, opts ImportOptions) (app.ImportBatch, []Skipped, error)
+}
+
+// withoutOptions adapts an importer that takes no options.
+func withoutOptions(fn func(io.Reader) (app.ImportBatch, []Skipped, error)) func(io.Reader, ImportOptions) (app.ImportBatch, []Skipped, error) {
+	return func(r io.Reader, _ ImportOptions) (app.ImportBatch, []Skipped, error) {
+		return fn(r)
+	}
+}
+
+var formats = map[string]Format{}
+
+func register(f Format) {
+	formats[f.Name] = f
+}
+
+// Lookup returns the format registered under name.
+func Lookup(name string) (Format, error) {
+	f, ok := formats[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Format{}, i18n.Errorf("exchange.unknown_format", ErrUnknownFormat, name, strings.Join(Names(), ", "))
+	}
+	return f, nil
+}
+
+// Names lists the registered formats alphabetically.
+func Names() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// exportLists returns the lists selected by opts, in display order.
+func exportLists(state model.AppState, opts ExportOptions) []model.List {
+	if opts.ListID == "" {
+		return state.Lists
+	}
+	for _, l := range state.Lists {
+		if l.ID == opts.ListID {
+			return []model.List{l}
+		}
+	}
+	return nil
+}
+
+// listTasks returns a list's tasks by manual position.
+func listTasks(state model.AppState, listID string) []model.Task {
+	var out []model.Task
+	for _, t := range state.Tasks {
+		if t.ListID == listID {
+			out = append(out, t)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Position < out[j].Position
+	})
+	return out
+}
+
+// exportArchive returns the archive entries selected by opts.
+func exportArchive(state model.AppState, opts ExportOptions) []model.ArchivedCompletedTask {
+	if !opts.IncludeArchive {
+		return nil
+	}
+	if opts.ListID == "" {
+		return state.ArchivedCompleted
+	}
+	var out []model.ArchivedCompletedTask
+	for _, a := range state.ArchivedCompleted {
+		if a.OriginListID == opts.ListID {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// batchBuilder collects imported tasks per list, keeping first-seen order.
+type batchBuilder struct {
+	batch app.ImportBatch
+	index map[string]int
+}
+
+func newBatchBuilder() *batchBuilder {
+	return &batchBuilder{index: make(map[string]int)}
+}
+
+// list returns the index of the named list, adding it on first use.
+func (b *batchBuilder) list(name string) int {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultListName
+	}
+	key := strings.ToLower(name)
+	idx, ok := b.index[key]
+	if !ok {
+		idx = len(b.batch.Lists)
+		b.index[key] = idx
+		b.batch.Lists = append(b.batch.Lists, app.ImportList{List: model.List{Name: name}})
+	}
+	return idx
+}
+
+func (b *batchBuilder) addTask(listName string, t model.Task) {
+	idx := b.list(listName)
+	b.batch.Lists[idx].Tasks = append(b.batch.Lists[idx].Tasks, t)
+}
+
+// columns sets the board columns of the named list the first time an entry
+// carries them.
+func (b *batchBuilder) columns(listName string, statuses []string) {
+	if l := &b.batch.Lists[b.list(listName)]; len(l.Statuses) == 0 {
+		l.Statuses = statuses
+	}
+}
+
+// joinStatuses and splitStatuses carry board columns in formats that keep a
+// list's columns in a single field, separated by commas. A comma or
+// backslash inside a column name is escaped with a backslash.
+func joinStatuses(statuses []string) string {
+	escaped := make([]string, len(statuses))
+	for i, st := range statuses {
+		escaped[i] = strings.NewReplacer(`\`, `\\`, ",", `\,`).Replace(st)
+	}
+	return strings.Join(escaped, ",")
+}
+
+func splitStatuses(raw string) []string {
+	var out []string
+	var cur strings.Builder
+	flush := func() {
+		if st := strings.TrimSpace(cur.String()); st != "" {
+			out = append(out, st)
+		}
+		cur.Reset()
+	}
+	for i := 0; i < len(raw); i++ {
+		switch {
+		case raw[i] == '\\' && i+1 < len(raw):
+			i++
+			cur.WriteByte(raw[i])
+		case raw[i] == ',':
+			flush()
+		default:
+			cur.WriteByte(raw[i])
+		}
+	}
+	flush()
+	return out
+}
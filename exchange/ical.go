@@ -0,0 +1,375 @@
+package exchange
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+const (
+	icalProdID     = "-//todo-cli//todo-cli//PT"
+	icalUIDDomain  = "@todo-cli"
+	icalDateTime   = "20060102T150405Z"
+	icalLocalTime  = "20060102T150405"
+	icalDate       = "20060102"
+	icalLineLimit  = 75
+	icalArchivedAt = "X-TODO-CLI-ARCHIVED"
+
+	// ICalUIDKey holds the UID of tasks that came from another calendar app.
+	ICalUIDKey = "ical-uid"
+)
+
+func init() {
+	register(Format{Name: "ical", Ext: ".ics", Export: ExportICal, Import: withoutOptions(ImportICal)})
+}
+
+// ExportICal writes an RFC 5545 calendar with one VTODO per task. The list
+// name goes into CATEGORIES and the task ID into a stable UID.
+func ExportICal(w io.Writer, state model.AppState, opts ExportOptions) error {
+	bw := bufio.NewWriter(w)
+	write := func(name, value string) {
+		icalWriteLine(bw, name+":"+value)
+	}
+
+	write("BEGIN", "VCALENDAR")
+	write("VERSION", "2.0")
+	write("PRODID", icalProdID)
+	for _, l := range exportLists(state, opts) {
+		for _, t := range listTasks(state, l.ID) {
+			write("BEGIN", "VTODO")
+			write("UID", icalTaskUID(t))
+			write("DTSTAMP", icalFormatTime(t.UpdatedAt))
+			if !t.CreatedAt.IsZero() {
+				write("CREATED", icalFormatTime(t.CreatedAt))
+			}
+			if !t.UpdatedAt.IsZero() {
+				write("LAST-MODIFIED", icalFormatTime(t.UpdatedAt))
+			}
+			write("SUMMARY", icalEscape(t.Text))
+			if p := icalPriority(t.Priority); p != "" {
+				write("PRIORITY", p)
+			}
+			if t.Done {
+				write("STATUS", "COMPLETED")
+				doneAt := t.DoneAt
+				if doneAt.IsZero() {
+					doneAt = t.UpdatedAt
+				}
+				write("COMPLETED", icalFormatTime(doneAt))
+			} else {
+				write("STATUS", "NEEDS-ACTION")
+			}
+			if due, ok := t.Due(); ok {
+				icalWriteLine(bw, "DUE;VALUE=DATE:"+due.Format(icalDate))
+			}
+			write("CATEGORIES", icalEscape(l.Name))
+			write("END", "VTODO")
+		}
+	}
+	for _, a := range exportArchive(state, opts) {
+		write("BEGIN", "VTODO")
+		write("UID", a.ID+icalUIDDomain)
+		write("DTSTAMP", icalFormatTime(a.ArchivedAt))
+		write("SUMMARY", icalEscape(a.TaskText))
+		if p := icalPriority(a.Priority); p != "" {
+			write("PRIORITY", p)
+		}
+		write("STATUS", "COMPLETED")
+		write("COMPLETED", icalFormatTime(a.DoneAt))
+		if a.OriginList != "" {
+			write("CATEGORIES", icalEscape(a.OriginList))
+		}
+		write(icalArchivedAt, icalFormatTime(a.ArchivedAt))
+		write("END", "VTODO")
+	}
+	write("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+func icalTaskUID(t model.Task) string {
+	if uid := t.Extra[ICalUIDKey]; uid != "" {
+		return uid
+	}
+	return t.ID + icalUIDDomain
+}
+
+// icalWriteLine folds content lines at 75 octets without splitting UTF-8 sequences.
+func icalWriteLine(w *bufio.Writer, line string) {
+	limit := icalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = icalLineLimit - 1
+	}
+	w.WriteString(line + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func icalEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+func icalUnescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// icalSplitList splits a comma-separated value, honouring escaped commas.
+func icalSplitList(s string) []string {
+	var out []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			out = append(out, icalUnescape(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(out, icalUnescape(s[start:]))
+}
+
+func icalFormatTime(t time.Time) string {
+	return t.UTC().Format(icalDateTime)
+}
+
+// icalPriority follows RFC 5545: 1-4 high, 5 medium, 6-9 low, 0 undefined.
+func icalPriority(p model.Priority) string {
+	switch p {
+	case model.PriorityHigh:
+		return "1"
+	case model.PriorityMedium:
+		return "5"
+	case model.PriorityLow:
+		return "9"
+	}
+	return ""
+}
+
+func icalParsePriority(raw string) model.Priority {
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	switch {
+	case err != nil || n <= 0:
+		return model.PriorityNone
+	case n <= 4:
+		return model.PriorityHigh
+	case n == 5:
+		return model.PriorityMedium
+	default:
+		return model.PriorityLow
+	}
+}
+
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func parseICalLine(line string) (icalProperty, bool) {
+	// O valor começa no primeiro ':' fora de aspas; parâmetros vêm antes dele.
+	inQuotes := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ':':
+			if !inQuotes {
+				colon = i
+			}
+		}
+	}
+	if colon <= 0 {
+		return icalProperty{}, false
+	}
+	head := strings.Split(line[:colon], ";")
+	p := icalProperty{name: strings.ToUpper(head[0]), value: line[colon+1:], params: map[string]string{}}
+	for _, param := range head[1:] {
+		if k, v, ok := strings.Cut(param, "="); ok {
+			p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return p, true
+}
+
+func (p icalProperty) time() (time.Time, error) {
+	value := strings.TrimSpace(p.value)
+	if p.params["VALUE"] == "DATE" || len(value) == len(icalDate) {
+		return time.Parse(icalDate, value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(icalDateTime, value)
+	}
+	loc := time.UTC
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(icalLocalTime, value, loc)
+	return t.UTC(), err
+}
+
+// ImportICal reads VTODO components. UIDs written by ExportICal map back to
+// task IDs; foreign UIDs are kept in Extra[ICalUIDKey] so re-importing the
+// same calendar updates tasks instead of duplicating them. Other components
+// are ignored.
+func ImportICal(r io.Reader) (app.ImportBatch, []Skipped, error) {
+	b := newBatchBuilder()
+	b.batch.MatchExtra = []string{ICalUIDKey}
+	var skipped []Skipped
+
+	lines, err := icalUnfold(r)
+	if err != nil {
+		return app.ImportBatch{}, nil, err
+	}
+
+	var (
+		todo    []icalProperty
+		inTodo  bool
+		startAt int
+	)
+	for _, l := range lines {
+		if l.text == "" {
+			continue
+		}
+		prop, ok := parseICalLine(l.text)
+		if !ok {
+			if inTodo {
+				skipped = append(skipped, Skipped{Line: l.n, Text: l.text, Reason: i18n.T("exchange.bad_ical_line")})
+			}
+			continue
+		}
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VTODO"):
+			inTodo, todo, startAt = true, nil, l.n
+		case prop.name == "END" && strings.EqualFold(prop.value, "VTODO"):
+			inTodo = false
+			if reason := icalAddTodo(b, todo); reason != "" {
+				skipped = append(skipped, Skipped{Line: startAt, Text: "BEGIN:VTODO", Reason: reason})
+			}
+		case inTodo:
+			todo = append(todo, prop)
+		}
+	}
+	return b.batch, skipped, nil
+}
+
+type icalLine struct {
+	n    int
+	text string
+}
+
+// icalUnfold joins continuation lines (starting with space or tab).
+func icalUnfold(r io.Reader) ([]icalLine, error) {
+	var out []icalLine
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		text := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(out) > 0 {
+			out[len(out)-1].text += text[1:]
+			continue
+		}
+		out = append(out, icalLine{n: n, text: text})
+	}
+	return out, sc.Err()
+}
+
+func icalAddTodo(b *batchBuilder, props []icalProperty) string {
+	var (
+		task       model.Task
+		list       string
+		uid        string
+		archivedAt time.Time
+		status     string
+	)
+	for _, p := range props {
+		var err error
+		switch p.name {
+		case "UID":
+			uid = strings.TrimSpace(p.value)
+		case "SUMMARY":
+			task.Text = icalUnescape(p.value)
+		case "PRIORITY":
+			task.Priority = icalParsePriority(p.value)
+		case "STATUS":
+			status = strings.ToUpper(strings.TrimSpace(p.value))
+		case "CATEGORIES":
+			if cats := icalSplitList(p.value); len(cats) > 0 {
+				list = cats[0]
+			}
+		case "CREATED":
+			task.CreatedAt, err = p.time()
+		case "LAST-MODIFIED":
+			task.UpdatedAt, err = p.time()
+		case "COMPLETED":
+			task.DoneAt, err = p.time()
+		case "DUE":
+			var due time.Time
+			if due, err = p.time(); err == nil {
+				task.Extra = map[string]string{model.ExtraDue: due.Format(model.DateLayout)}
+			}
+		case icalArchivedAt:
+			archivedAt, err = p.time()
+		}
+		if err != nil {
+			return i18n.T("exchange.bad_ical_property", p.name, i18n.Error(err))
+		}
+	}
+	task.Text = strings.TrimSpace(task.Text)
+	if task.Text == "" {
+		return i18n.T("exchange.no_summary")
+	}
+	task.Done = status == "COMPLETED" || status == "CANCELLED" || !task.DoneAt.IsZero()
+
+	if id, ok := strings.CutSuffix(uid, icalUIDDomain); ok {
+		if !archivedAt.IsZero() {
+			b.batch.Archived = append(b.batch.Archived, model.ArchivedCompletedTask{
+				ID:         id,
+				TaskText:   task.Text,
+				OriginList: list,
+				Priority:   task.Priority,
+				DoneAt:     task.DoneAt,
+				ArchivedAt: archivedAt,
+			})
+			return ""
+		}
+		task.ID = id
+	} else if uid != "" {
+		if task.Extra == nil {
+			task.Extra = map[string]string{}
+		}
+		task.Extra[ICalUIDKey] = uid
+	}
+	b.addTask(list, task)
+	return ""
+}
@@ -0,0 +1,128 @@
+package exchange
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func TestICalRoundTrip(t *testing.T) {
+	original := richState(t)
+
+	var buf bytes.Buffer
+	if err := ExportICal(&buf, original, ExportOptions{IncludeArchive: true}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	exported := buf.String()
+	for _, line := range strings.Split(strings.TrimSuffix(exported, "\r\n"), "\r\n") {
+		if len(line) > icalLineLimit {
+			t.Fatalf("line not folded: %q", line)
+		}
+	}
+	if !strings.Contains(exported, "CATEGORIES:Casa Nova\r\n") || !strings.Contains(exported, "DUE;VALUE=DATE:20260310\r\n") {
+		t.Fatalf("unexpected calendar:\n%s", exported)
+	}
+
+	batch, skipped, err := ImportICal(strings.NewReader(exported))
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("import failed: %v %v", err, skipped)
+	}
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	got := svc.State()
+	want := sortedTasks(original.Tasks)
+	have := sortedTasks(got.Tasks)
+	if len(want) != len(have) {
+		t.Fatalf("expected %d tasks, got %d", len(want), len(have))
+	}
+	for i := range want {
+		w, h := want[i], have[i]
+		if w.ID != h.ID || w.Text != h.Text || w.Priority != h.Priority || w.Done != h.Done || !w.CreatedAt.Truncate(time.Second).Equal(h.CreatedAt) {
+			t.Fatalf("task mismatch\nwant=%+v\ngot=%+v", w, h)
+		}
+		if w.Extra["due"] != h.Extra["due"] {
+			t.Fatalf("expected due to survive, got %+v", h.Extra)
+		}
+	}
+	if len(got.ArchivedCompleted) != 1 || got.ArchivedCompleted[0].ID != original.ArchivedCompleted[0].ID {
+		t.Fatalf("archive mismatch: %+v", got.ArchivedCompleted)
+	}
+
+	result, err := svc.Import(batch)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if result.TasksCreated != 0 || result.ListsCreated != 0 {
+		t.Fatalf("expected idempotent re-import, got %+v", result)
+	}
+}
+
+func TestImportForeignICal(t *testing.T) {
+	input := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:evento@exemplo\r\n" +
+		"SUMMARY:Reunião\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:abc-123@exemplo\r\n" +
+		"SUMMARY:Ligar para o banco\\, urgente e depois revisar uma descrição bem l\r\n" +
+		" onga\r\n" +
+		"PRIORITY:2\r\n" +
+		"CATEGORIES:Pessoal,Banco\r\n" +
+		"STATUS:COMPLETED\r\n" +
+		"COMPLETED;TZID=America/Sao_Paulo:20260301T090000\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:sem-titulo@exemplo\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	batch, skipped, err := ImportICal(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0].Line != 16 {
+		t.Fatalf("expected VTODO without SUMMARY to be skipped, got %v", skipped)
+	}
+	if len(batch.Lists) != 1 || batch.Lists[0].Name != "Pessoal" || len(batch.Lists[0].Tasks) != 1 {
+		t.Fatalf("unexpected lists: %+v", batch.Lists)
+	}
+	task := batch.Lists[0].Tasks[0]
+	if task.Text != "Ligar para o banco, urgente e depois revisar uma descrição bem longa" ||
+		task.Priority != model.PriorityHigh || !task.Done || task.Extra[ICalUIDKey] != "abc-123@exemplo" {
+		t.Fatalf("unexpected task: %+v", task)
+	}
+	if got := task.DoneAt.Format("2006-01-02 15:04"); got != "2026-03-01 12:00" {
+		t.Fatalf("expected TZID to be honoured, got %s", got)
+	}
+
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	updated := strings.Replace(input, "PRIORITY:2", "PRIORITY:9", 1)
+	batch, _, _ = ImportICal(strings.NewReader(updated))
+	result, err := svc.Import(batch)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	tasks := svc.State().Tasks
+	if result.TasksUpdated != 1 || len(tasks) != 1 || tasks[0].Priority != model.PriorityLow {
+		t.Fatalf("expected UID match to update the task, got %+v %+v", result, tasks)
+	}
+
+	var buf bytes.Buffer
+	if err := ExportICal(&buf, svc.State(), ExportOptions{}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "UID:abc-123@exemplo\r\n") {
+		t.Fatalf("expected foreign UID to be kept, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,314 @@
+package exchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// Markdown metadata lives in an HTML comment at the end of headings and
+// items, so the file renders as a plain checklist but round-trips fully.
+const (
+	mdMetaOpen     = "<!-- todo-cli "
+	mdMetaClose    = " -->"
+	mdArchiveTitle = "Arquivadas"
+	mdExtraPrefix  = "x."
+)
+
+var (
+	mdHeading = regexp.MustCompile(`^#{1,6}\s+(.*)$`)
+	mdItem    = regexp.MustCompile(`^[-*+]\s+(?:\[([ xX])\]\s+)?(.*)$`)
+)
+
+func init() {
+	register(Format{Name: "markdown", Ext: ".md", Export: ExportMarkdown, Import: withoutOptions(ImportMarkdown)})
+}
+
+// ExportMarkdown writes one heading per list and a `- [ ]`/`- [x]` item per
+// task, with `!`, `!!` or `!!!` for low, medium and high priority.
+func ExportMarkdown(w io.Writer, state model.AppState, opts ExportOptions) error {
+	bw := bufio.NewWriter(w)
+	first := true
+	heading := func(title string, meta url.Values) {
+		if !first {
+			fmt.Fprintln(bw)
+		}
+		first = false
+		fmt.Fprintf(bw, "# %s%s\n\n", mdOneLine(title), mdMeta(meta))
+	}
+
+	for _, l := range exportLists(state, opts) {
+		heading(l.Name, url.Values{
+			"id":      {l.ID},
+			"color":   {l.Color},
+			"created": {mdTime(l.CreatedAt)},
+			"updated": {mdTime(l.UpdatedAt)},
+			// Uma coluna por valor, então nomes com vírgula também voltam.
+			"statuses": l.Statuses,
+		})
+		for _, t := range listTasks(state, l.ID) {
+			fmt.Fprintln(bw, markdownTaskLine(t))
+		}
+	}
+
+	if archived := exportArchive(state, opts); len(archived) > 0 {
+		heading(mdArchiveTitle, url.Values{"kind": {"archive"}})
+		for _, a := range archived {
+			meta := url.Values{
+				"id":       {a.ID},
+				"list":     {a.OriginList},
+				"listId":   {a.OriginListID},
+				"done":     {mdTime(a.DoneAt)},
+				"archived": {mdTime(a.ArchivedAt)},
+			}
+			fmt.Fprintln(bw, markdownItem(true, a.Priority, a.TaskText, meta))
+		}
+	}
+	return bw.Flush()
+}
+
+func markdownTaskLine(t model.Task) string {
+	meta := url.Values{
+		"id":      {t.ID},
+		"created": {mdTime(t.CreatedAt)},
+		"updated": {mdTime(t.UpdatedAt)},
+		"status":  {t.Status},
+	}
+	if t.Pinned {
+		meta.Set("pinned", "true")
+	}
+	if !t.DoneAt.IsZero() {
+		meta.Set("done", mdTime(t.DoneAt))
+	}
+	for _, key := range slices.Sorted(maps.Keys(t.Extra)) {
+		meta.Set(mdExtraPrefix+key, t.Extra[key])
+	}
+	return markdownItem(t.Done, t.Priority, t.Text, meta)
+}
+
+// markdownItem renders an item and falls back to storing the text in the
+// metadata when the visible form would not parse back to the same text.
+func markdownItem(done bool, p model.Priority, text string, meta url.Values) string {
+	render := func(meta url.Values) string {
+		box := "[ ]"
+		if done {
+			box = "[x]"
+		}
+		parts := []string{"-", box}
+		if marker := mdPriorityMarker(p); marker != "" {
+			parts = append(parts, marker)
+		}
+		parts = append(parts, mdOneLine(text))
+		return strings.Join(parts, " ") + mdMeta(meta)
+	}
+	line := render(meta)
+	if item, ok := parseMarkdownItem(line); !ok || item.text != text || item.priority != p {
+		meta.Set("text", text)
+		meta.Set("priority", strconv.Itoa(int(p)))
+		line = render(meta)
+	}
+	return line
+}
+
+func mdMeta(meta url.Values) string {
+	for key, values := range meta {
+		if len(values) == 0 || values[0] == "" {
+			delete(meta, key)
+		}
+	}
+	if len(meta) == 0 {
+		return ""
+	}
+	return " " + mdMetaOpen + meta.Encode() + mdMetaClose
+}
+
+func mdOneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func mdTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func mdParseTime(raw string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func mdPriorityMarker(p model.Priority) string {
+	if p < model.PriorityLow || p > model.PriorityHigh {
+		return ""
+	}
+	return strings.Repeat("!", int(p))
+}
+
+type markdownEntry struct {
+	done     bool
+	priority model.Priority
+	text     string
+	meta     url.Values
+}
+
+// splitMarkdownMeta separates the trailing metadata comment, if any.
+func splitMarkdownMeta(line string) (string, url.Values) {
+	if !strings.HasSuffix(line, strings.TrimSpace(mdMetaClose)) {
+		return line, nil
+	}
+	start := strings.LastIndex(line, mdMetaOpen)
+	if start < 0 {
+		return line, nil
+	}
+	raw := strings.TrimSuffix(line[start+len(mdMetaOpen):], strings.TrimSpace(mdMetaClose))
+	meta, err := url.ParseQuery(strings.TrimSpace(raw))
+	if err != nil {
+		return line, nil
+	}
+	return strings.TrimSpace(line[:start]), meta
+}
+
+func parseMarkdownItem(line string) (markdownEntry, bool) {
+	body, meta := splitMarkdownMeta(line)
+	m := mdItem.FindStringSubmatch(body)
+	if m == nil {
+		return markdownEntry{}, false
+	}
+	e := markdownEntry{done: strings.EqualFold(m[1], "x"), text: strings.TrimSpace(m[2]), meta: meta}
+	if marker, rest, ok := strings.Cut(e.text, " "); ok && len(marker) <= 3 && strings.Trim(marker, "!") == "" {
+		e.priority = model.Priority(len(marker))
+		e.text = strings.TrimSpace(rest)
+	}
+	if text := meta.Get("text"); text != "" {
+		e.text = text
+	}
+	if raw := meta.Get("priority"); raw != "" {
+		if p, err := strconv.Atoi(raw); err == nil {
+			e.priority = model.Priority(p)
+		}
+	}
+	return e, true
+}
+
+// ImportMarkdown reads headings as lists and checklist items as tasks. Plain
+// `- item` lines count as open tasks; other non-empty lines are skipped.
+func ImportMarkdown(r io.Reader) (app.ImportBatch, []Skipped, error) {
+	b := newBatchBuilder()
+	var skipped []Skipped
+	current := -1
+	inArchive := false
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			title, meta := splitMarkdownMeta(strings.TrimSpace(m[1]))
+			inArchive = meta.Get("kind") == "archive"
+			if inArchive {
+				continue
+			}
+			current = b.list(title)
+			if l := &b.batch.Lists[current]; l.ID == "" {
+				l.ID = meta.Get("id")
+				l.Color = meta.Get("color")
+				l.CreatedAt = mdParseTime(meta.Get("created"))
+				l.UpdatedAt = mdParseTime(meta.Get("updated"))
+				l.Statuses = meta["statuses"]
+			}
+			continue
+		}
+
+		item, ok := parseMarkdownItem(line)
+		if !ok {
+			skipped = append(skipped, Skipped{Line: n, Text: line, Reason: i18n.T("exchange.not_markdown_item")})
+			continue
+		}
+		if item.text == "" {
+			skipped = append(skipped, Skipped{Line: n, Text: line, Reason: i18n.T("exchange.no_text")})
+			continue
+		}
+
+		if inArchive {
+			doneAt := mdParseTime(item.meta.Get("done"))
+			b.batch.Archived = append(b.batch.Archived, model.ArchivedCompletedTask{
+				ID:           item.meta.Get("id"),
+				TaskText:     item.text,
+				OriginListID: item.meta.Get("listId"),
+				OriginList:   item.meta.Get("list"),
+				Priority:     item.priority,
+				DoneAt:       doneAt,
+				ArchivedAt:   mdParseTime(item.meta.Get("archived")),
+			})
+			continue
+		}
+
+		task := model.Task{
+			ID:        item.meta.Get("id"),
+			Text:      item.text,
+			Done:      item.done,
+			Status:    item.meta.Get("status"),
+			Pinned:    item.meta.Get("pinned") == "true",
+			Priority:  item.priority,
+			CreatedAt: mdParseTime(item.meta.Get("created")),
+			UpdatedAt: mdParseTime(item.meta.Get("updated")),
+			DoneAt:    mdParseTime(item.meta.Get("done")),
+		}
+		for key, values := range item.meta {
+			if name, ok := strings.CutPrefix(key, mdExtraPrefix); ok && len(values) > 0 {
+				if task.Extra == nil {
+					task.Extra = map[string]string{}
+				}
+				task.Extra[name] = values[0]
+			}
+		}
+		if current < 0 {
+			current = b.list(DefaultListName)
+		}
+		b.batch.Lists[current].Tasks = append(b.batch.Lists[current].Tasks, task)
+	}
+	if err := sc.Err(); err != nil {
+		return app.ImportBatch{}, nil, err
+	}
+	return b.batch, skipped, nil
+}
+
+// MarkdownChecklist renders tasks as a bare checklist for the clipboard,
+// without headings or metadata.
+func MarkdownChecklist(tasks []model.Task) string {
+	lines := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		if strings.TrimSpace(t.Text) == "" {
+			continue
+		}
+		box := "[ ]"
+		if t.Done {
+			box = "[x]"
+		}
+		parts := []string{"-", box}
+		if marker := mdPriorityMarker(t.Priority); marker != "" {
+			parts = append(parts, marker)
+		}
+		lines = append(lines, strings.Join(append(parts, mdOneLine(t.Text)), " "))
+	}
+	return strings.Join(lines, "\n")
+}
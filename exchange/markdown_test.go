@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func richState(t *testing.T) model.AppState {
+	t.Helper()
+	svc := app.NewService(model.NewState())
+	home, _ := svc.CreateList("Casa Nova", "green")
+	work, _ := svc.CreateList("Trabalho", "blue")
+	if _, err := svc.CreateList("Vazia", "red"); err != nil {
+		t.Fatalf("create list failed: %v", err)
+	}
+	paint, _ := svc.CreateTask(home.ID, "Comprar tinta")
+	bang, _ := svc.CreateTask(home.ID, "! não é prioridade")
+	spaced, _ := svc.CreateTask(home.ID, "Texto com  espaços duplos")
+	report, _ := svc.CreateTask(work.ID, "Relatório semanal")
+	old, _ := svc.CreateTask(work.ID, "Antiga")
+	for id, p := range map[string]model.Priority{paint.ID: model.PriorityHigh, report.ID: model.PriorityMedium, old.ID: model.PriorityLow} {
+		if _, err := svc.SetTaskPriority(id, p); err != nil {
+			t.Fatalf("priority failed: %v", err)
+		}
+	}
+	for _, id := range []string{bang.ID, old.ID} {
+		if _, err := svc.ToggleDone(id); err != nil {
+			t.Fatalf("toggle failed: %v", err)
+		}
+	}
+	if _, err := svc.ClearCompletedToArchive(work.ID); err != nil {
+		t.Fatalf("archive failed: %v", err)
+	}
+	if _, err := svc.SetListStatuses(work.ID, []string{"Backlog", "Em revisão, com o cliente", "Entregue"}); err != nil {
+		t.Fatalf("set statuses failed: %v", err)
+	}
+	for id, status := range map[string]string{report.ID: "Em revisão, com o cliente", paint.ID: model.StatusDoing} {
+		if _, err := svc.SetTaskStatus(id, status); err != nil {
+			t.Fatalf("set status failed: %v", err)
+		}
+	}
+	if _, err := svc.SetTaskPinned(spaced.ID, true); err != nil {
+		t.Fatalf("pin failed: %v", err)
+	}
+	state := svc.State()
+	for i := range state.Tasks {
+		if state.Tasks[i].ID == spaced.ID {
+			state.Tasks[i].Extra = map[string]string{"due": "2026-03-10", "url": "https://example.com/a?b=c&d"}
+		}
+	}
+	return state
+}
+
+func sortedTasks(tasks []model.Task) []model.Task {
+	out := append([]model.Task(nil), tasks...)
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func TestMarkdownRoundTripKeepsEverything(t *testing.T) {
+	original := richState(t)
+
+	var buf bytes.Buffer
+	if err := ExportMarkdown(&buf, original, ExportOptions{IncludeArchive: true}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	exported := buf.String()
+	if !strings.Contains(exported, "# Casa Nova") || !strings.Contains(exported, "- [ ] !!! Comprar tinta") {
+		t.Fatalf("expected readable checklist, got:\n%s", exported)
+	}
+
+	batch, skipped, err := ImportMarkdown(strings.NewReader(exported))
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("import failed: %v %v", err, skipped)
+	}
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	got := svc.State()
+
+	if !reflect.DeepEqual(original.Lists, got.Lists) {
+		t.Fatalf("lists mismatch\nwant=%+v\ngot=%+v", original.Lists, got.Lists)
+	}
+	if want, have := sortedTasks(original.Tasks), sortedTasks(got.Tasks); !reflect.DeepEqual(want, have) {
+		t.Fatalf("tasks mismatch\nwant=%+v\ngot=%+v", want, have)
+	}
+	if !reflect.DeepEqual(original.ArchivedCompleted, got.ArchivedCompleted) {
+		t.Fatalf("archive mismatch\nwant=%+v\ngot=%+v", original.ArchivedCompleted, got.ArchivedCompleted)
+	}
+
+	// Reimportar o mesmo arquivo atualiza em vez de duplicar.
+	result, err := svc.Import(batch)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if result.TasksCreated != 0 || result.ListsCreated != 0 || len(svc.State().Tasks) != len(original.Tasks) {
+		t.Fatalf("expected idempotent re-import, got %+v", result)
+	}
+}
+
+func TestImportPlainMarkdown(t *testing.T) {
+	input := `Uma introdução qualquer.
+- solta no início
+
+## Mercado
+- [ ] leite
+* [X] !! pão
+- ovos
+`
+	batch, skipped, err := ImportMarkdown(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0].Line != 1 {
+		t.Fatalf("expected paragraph to be skipped, got %v", skipped)
+	}
+	if len(batch.Lists) != 2 || batch.Lists[0].Name != DefaultListName || batch.Lists[1].Name != "Mercado" {
+		t.Fatalf("unexpected lists: %+v", batch.Lists)
+	}
+	tasks := batch.Lists[1].Tasks
+	if len(tasks) != 3 || tasks[0].Done || !tasks[1].Done || tasks[1].Priority != model.PriorityMedium || tasks[1].Text != "pão" {
+		t.Fatalf("unexpected tasks: %+v", tasks)
+	}
+}
@@ -0,0 +1,473 @@
+package exchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// Org keeps todo-cli fields in each headline's properties drawer; Extra
+// values use the X_ prefix. Task tags come from and go to Extra["tags"],
+// and Extra["due"] maps to DEADLINE.
+const (
+	orgTimestamp    = "2006-01-02 Mon 15:04"
+	orgDate         = "2006-01-02 Mon"
+	orgExtraPrefix  = "X_"
+	orgArchiveTitle = "Arquivadas"
+	orgTagsKey      = "tags"
+)
+
+var (
+	orgHeadline = regexp.MustCompile(`^(\*+)\s+(.*?)\s*$`)
+	orgPriority = regexp.MustCompile(`^\[#([A-Z])\]\s*`)
+	orgTags     = regexp.MustCompile(`\s+:([\w@#%]+(?::[\w@#%]+)*):$`)
+	orgProperty = regexp.MustCompile(`^:([^:\s]+):(?:\s+(.*))?$`)
+	orgDrawer   = regexp.MustCompile(`^:([A-Za-z_-]+):$`)
+	orgPlanning = regexp.MustCompile(`(CLOSED|DEADLINE|SCHEDULED):\s*([\[<][^\]>]*[\]>])`)
+)
+
+func init() {
+	register(Format{Name: "org", Ext: ".org", Export: ExportOrg, Import: withoutOptions(ImportOrg)})
+}
+
+// ExportOrg writes one top-level heading per list and a TODO/DONE headline
+// per task, with [#A]/[#B]/[#C] for high/medium/low priority.
+func ExportOrg(w io.Writer, state model.AppState, opts ExportOptions) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#+TODO: TODO | DONE")
+
+	for _, l := range exportLists(state, opts) {
+		props := [][2]string{
+			{"ID", l.ID},
+			{"COLOR", l.Color},
+			{"CREATED", mdTime(l.CreatedAt)},
+			{"UPDATED", mdTime(l.UpdatedAt)},
+			{"STATUSES", joinStatuses(l.Statuses)},
+		}
+		line := "* " + mdOneLine(l.Name)
+		// Um nome como "TODO casa" seria lido como tarefa; NAME desfaz a ambiguidade.
+		if h, _ := parseOrgHeadline(line, orgDefaultKeywords()); h.keyword != "" || h.text != l.Name {
+			props = append(props, [2]string{"NAME", l.Name})
+		}
+		fmt.Fprintf(bw, "\n%s\n", line)
+		orgWriteDrawer(bw, props)
+		for _, t := range listTasks(state, l.ID) {
+			orgWriteTask(bw, t)
+		}
+	}
+
+	if archived := exportArchive(state, opts); len(archived) > 0 {
+		fmt.Fprintf(bw, "\n* %s\n", orgArchiveTitle)
+		orgWriteDrawer(bw, [][2]string{{"KIND", "archive"}})
+		for _, a := range archived {
+			props := [][2]string{
+				{"ID", a.ID},
+				{"LIST", a.OriginList},
+				{"LIST_ID", a.OriginListID},
+				{"DONE", mdTime(a.DoneAt)},
+				{"ARCHIVED", mdTime(a.ArchivedAt)},
+			}
+			props = orgHeadlineLine(bw, true, a.Priority, a.TaskText, "", props)
+			if !a.DoneAt.IsZero() {
+				fmt.Fprintf(bw, "CLOSED: [%s]\n", a.DoneAt.Local().Format(orgTimestamp))
+			}
+			orgWriteDrawer(bw, props)
+		}
+	}
+	return bw.Flush()
+}
+
+func orgWriteTask(bw *bufio.Writer, t model.Task) {
+	props := [][2]string{
+		{"ID", t.ID},
+		{"CREATED", mdTime(t.CreatedAt)},
+		{"UPDATED", mdTime(t.UpdatedAt)},
+		{"DONE", mdTime(t.DoneAt)},
+		{"STATUS", t.Status},
+	}
+	if t.Pinned {
+		props = append(props, [2]string{"PINNED", "true"})
+	}
+	due, hasDue := t.Due()
+	for _, key := range slices.Sorted(maps.Keys(t.Extra)) {
+		// Tags e prazo já aparecem no título e no DEADLINE.
+		if key == orgTagsKey || (key == model.ExtraDue && hasDue) {
+			continue
+		}
+		props = append(props, [2]string{orgExtraPrefix + key, t.Extra[key]})
+	}
+	props = orgHeadlineLine(bw, t.Done, t.Priority, t.Text, t.Extra[orgTagsKey], props)
+
+	var planning []string
+	if t.Done && !t.DoneAt.IsZero() {
+		planning = append(planning, "CLOSED: ["+t.DoneAt.Local().Format(orgTimestamp)+"]")
+	}
+	if hasDue {
+		planning = append(planning, "DEADLINE: <"+due.Format(orgDate)+">")
+	}
+	if len(planning) > 0 {
+		fmt.Fprintln(bw, strings.Join(planning, " "))
+	}
+	orgWriteDrawer(bw, props)
+}
+
+// orgHeadlineLine writes a level-2 headline. When the visible headline would
+// not parse back to the same text and priority, they are also stored as
+// TEXT/PRIORITY properties; the (possibly extended) properties are returned.
+func orgHeadlineLine(bw *bufio.Writer, done bool, p model.Priority, text, tags string, props [][2]string) [][2]string {
+	parts := []string{"**", "TODO"}
+	if done {
+		parts[1] = "DONE"
+	}
+	if cookie := orgPriorityCookie(p); cookie != "" {
+		parts = append(parts, cookie)
+	}
+	if visible := mdOneLine(text); visible != "" {
+		parts = append(parts, visible)
+	}
+	line := strings.Join(parts, " ")
+	if tags != "" {
+		line += " :" + strings.ReplaceAll(tags, ",", ":") + ":"
+	}
+	h, ok := parseOrgHeadline(line, orgDefaultKeywords())
+	if !ok || h.text != text || h.priority != p {
+		props = append(props, [2]string{"TEXT", text}, [2]string{"PRIORITY", strconv.Itoa(int(p))})
+	}
+	fmt.Fprintln(bw, line)
+	return props
+}
+
+func orgWriteDrawer(bw *bufio.Writer, props [][2]string) {
+	fmt.Fprintln(bw, ":PROPERTIES:")
+	for _, p := range props {
+		// Valores ficam numa linha só, mas mantêm espaços internos (TEXT precisa deles).
+		if value := strings.TrimSpace(strings.ReplaceAll(p[1], "\n", " ")); value != "" {
+			fmt.Fprintf(bw, ":%s: %s\n", p[0], value)
+		}
+	}
+	fmt.Fprintln(bw, ":END:")
+}
+
+func orgPriorityCookie(p model.Priority) string {
+	switch p {
+	case model.PriorityHigh:
+		return "[#A]"
+	case model.PriorityMedium:
+		return "[#B]"
+	case model.PriorityLow:
+		return "[#C]"
+	}
+	return ""
+}
+
+// orgKeywords records which TODO keywords mean open and which mean done.
+type orgKeywords map[string]bool
+
+func orgDefaultKeywords() orgKeywords {
+	return orgKeywords{"TODO": false, "NEXT": false, "WAITING": false, "DONE": true, "CANCELED": true, "CANCELLED": true}
+}
+
+// parse reads a `#+TODO: A B | C D` line; keywords after the bar are done.
+func (k orgKeywords) parse(spec string) {
+	done := false
+	words := strings.Fields(spec)
+	if !slices.Contains(words, "|") && len(words) > 0 {
+		// Sem barra, a última palavra é o estado concluído.
+		k[orgKeywordName(words[len(words)-1])] = true
+		words = words[:len(words)-1]
+	}
+	for _, word := range words {
+		if word == "|" {
+			done = true
+			continue
+		}
+		k[orgKeywordName(word)] = done
+	}
+}
+
+// orgKeywordName strips fast-access keys such as "TODO(t)".
+func orgKeywordName(word string) string {
+	name, _, _ := strings.Cut(word, "(")
+	return name
+}
+
+type orgHeadlineEntry struct {
+	level    int
+	keyword  string
+	done     bool
+	priority model.Priority
+	text     string
+	tags     []string
+}
+
+func parseOrgHeadline(line string, keywords orgKeywords) (orgHeadlineEntry, bool) {
+	m := orgHeadline.FindStringSubmatch(line)
+	if m == nil {
+		return orgHeadlineEntry{}, false
+	}
+	h := orgHeadlineEntry{level: len(m[1])}
+	rest := m[2]
+	if word, after, _ := strings.Cut(rest, " "); word != "" {
+		if done, ok := keywords[word]; ok {
+			h.keyword, h.done, rest = word, done, strings.TrimSpace(after)
+		}
+	}
+	if pm := orgPriority.FindStringSubmatch(rest); pm != nil {
+		switch pm[1] {
+		case "A":
+			h.priority = model.PriorityHigh
+		case "B":
+			h.priority = model.PriorityMedium
+		case "C":
+			h.priority = model.PriorityLow
+		}
+		rest = rest[len(pm[0]):]
+	}
+	if tm := orgTags.FindStringSubmatchIndex(rest); tm != nil {
+		h.tags = strings.Split(rest[tm[2]:tm[3]], ":")
+		rest = rest[:tm[0]]
+	}
+	h.text = strings.TrimSpace(rest)
+	return h, true
+}
+
+// orgParseTime accepts RFC 3339 (what ExportOrg writes) and Org timestamps.
+func orgParseTime(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, true
+	}
+	if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return t, true
+	}
+	inner := strings.Trim(raw, "[]<>")
+	fields := strings.Fields(inner)
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+	layout, value := "2006-01-02", fields[0]
+	for _, f := range fields[1:] {
+		if strings.Contains(f, ":") {
+			layout, value = "2006-01-02 15:04", fields[0]+" "+f
+			break
+		}
+	}
+	t, err := time.ParseInLocation(layout, value, time.Local)
+	return t, err == nil
+}
+
+type orgNode struct {
+	line     int
+	raw      string
+	headline orgHeadlineEntry
+	props    map[string]string
+	extra    map[string]string
+	planning map[string]string
+}
+
+// setProperty stores known properties upper-cased and X_ extras with their
+// original key case.
+func (n *orgNode) setProperty(name, value string) {
+	if len(name) > len(orgExtraPrefix) && strings.EqualFold(name[:len(orgExtraPrefix)], orgExtraPrefix) {
+		n.extra[name[len(orgExtraPrefix):]] = value
+		return
+	}
+	n.props[strings.ToUpper(name)] = value
+}
+
+// ImportOrg reads top-level headings as lists and TODO/DONE headlines below
+// them (at any depth) as tasks. Headlines without a keyword, body text and
+// malformed properties are reported as skipped.
+func ImportOrg(r io.Reader) (app.ImportBatch, []Skipped, error) {
+	keywords := orgDefaultKeywords()
+	var (
+		nodes    []*orgNode
+		skipped  []Skipped
+		drawer   string
+		propsOK  bool
+		lastNode *orgNode
+	)
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		raw := sc.Text()
+		line := strings.TrimSpace(raw)
+
+		if drawer != "" {
+			if strings.EqualFold(line, ":END:") {
+				drawer = ""
+				continue
+			}
+			if drawer != "PROPERTIES" {
+				continue
+			}
+			if m := orgProperty.FindStringSubmatch(line); m != nil && propsOK {
+				lastNode.setProperty(m[1], strings.TrimSpace(m[2]))
+			} else if line != "" {
+				skipped = append(skipped, Skipped{Line: n, Text: line, Reason: i18n.T("exchange.bad_org_property")})
+			}
+			continue
+		}
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#+"):
+			if len(line) > len("#+TODO:") && strings.EqualFold(line[:len("#+TODO:")], "#+TODO:") {
+				keywords.parse(line[len("#+TODO:"):])
+			}
+			continue
+		case strings.HasPrefix(line, "# ") || line == "#":
+			continue
+		}
+
+		if strings.HasPrefix(raw, "*") {
+			if h, ok := parseOrgHeadline(raw, keywords); ok {
+				lastNode = &orgNode{line: n, raw: line, headline: h, props: map[string]string{}, extra: map[string]string{}, planning: map[string]string{}}
+				nodes = append(nodes, lastNode)
+				propsOK = true
+				continue
+			}
+		}
+		if m := orgDrawer.FindStringSubmatch(line); m != nil {
+			drawer = strings.ToUpper(m[1])
+			if lastNode == nil {
+				// Propriedades do arquivo não pertencem a nenhuma lista.
+				drawer = "FILE"
+			}
+			continue
+		}
+		if lastNode != nil && orgPlanning.MatchString(line) {
+			for _, pm := range orgPlanning.FindAllStringSubmatch(line, -1) {
+				lastNode.planning[pm[1]] = pm[2]
+			}
+			continue
+		}
+		propsOK = false
+		skipped = append(skipped, Skipped{Line: n, Text: line, Reason: i18n.T("exchange.stray_org_text")})
+	}
+	if err := sc.Err(); err != nil {
+		return app.ImportBatch{}, nil, err
+	}
+
+	b := newBatchBuilder()
+	current := -1
+	inArchive := false
+	for _, node := range nodes {
+		h := node.headline
+		name, named := node.props["NAME"]
+		if h.level == 1 && (h.keyword == "" || named) {
+			inArchive = strings.EqualFold(node.props["KIND"], "archive")
+			if inArchive {
+				continue
+			}
+			if !named {
+				name = h.text
+			}
+			current = b.list(name)
+			if l := &b.batch.Lists[current]; l.ID == "" {
+				l.ID = node.props["ID"]
+				l.Color = node.props["COLOR"]
+				l.CreatedAt, _ = orgParseTime(node.props["CREATED"])
+				l.UpdatedAt, _ = orgParseTime(node.props["UPDATED"])
+				l.Statuses = splitStatuses(node.props["STATUSES"])
+			}
+			continue
+		}
+		if h.keyword == "" {
+			skipped = append(skipped, Skipped{Line: node.line, Text: node.raw, Reason: i18n.T("exchange.no_org_keyword")})
+			continue
+		}
+		if reason := orgAddTask(b, node, inArchive, &current); reason != "" {
+			skipped = append(skipped, Skipped{Line: node.line, Text: node.raw, Reason: reason})
+		}
+	}
+	slices.SortStableFunc(skipped, func(a, b Skipped) int { return a.Line - b.Line })
+	return b.batch, skipped, nil
+}
+
+func orgAddTask(b *batchBuilder, node *orgNode, inArchive bool, current *int) string {
+	h, props := node.headline, node.props
+	text := h.text
+	if v, ok := props["TEXT"]; ok {
+		text = v
+	}
+	priority := h.priority
+	if v := props["PRIORITY"]; v != "" {
+		if p, err := strconv.Atoi(v); err == nil {
+			priority = model.Priority(p)
+		}
+	}
+	if strings.TrimSpace(text) == "" {
+		return i18n.T("exchange.no_text")
+	}
+
+	times := map[string]time.Time{}
+	for _, key := range []string{"CREATED", "UPDATED", "DONE", "ARCHIVED"} {
+		t, ok := orgParseTime(props[key])
+		if !ok {
+			return i18n.T("exchange.bad_date_in", key)
+		}
+		times[key] = t
+	}
+	if times["DONE"].IsZero() && node.planning["CLOSED"] != "" {
+		closed, ok := orgParseTime(node.planning["CLOSED"])
+		if !ok {
+			return i18n.T("exchange.bad_date_in", "CLOSED")
+		}
+		times["DONE"] = closed
+	}
+
+	if inArchive {
+		b.batch.Archived = append(b.batch.Archived, model.ArchivedCompletedTask{
+			ID:           props["ID"],
+			TaskText:     text,
+			OriginListID: props["LIST_ID"],
+			OriginList:   props["LIST"],
+			Priority:     priority,
+			DoneAt:       times["DONE"],
+			ArchivedAt:   times["ARCHIVED"],
+		})
+		return ""
+	}
+
+	task := model.Task{
+		ID:        props["ID"],
+		Text:      text,
+		Done:      h.done,
+		Status:    props["STATUS"],
+		Pinned:    props["PINNED"] == "true",
+		Priority:  priority,
+		CreatedAt: times["CREATED"],
+		UpdatedAt: times["UPDATED"],
+		DoneAt:    times["DONE"],
+		Extra:     maps.Clone(node.extra),
+	}
+	if len(h.tags) > 0 {
+		task.Extra[orgTagsKey] = strings.Join(h.tags, ",")
+	}
+	if raw := node.planning["DEADLINE"]; raw != "" {
+		if due, ok := orgParseTime(raw); ok && !due.IsZero() {
+			task.Extra[model.ExtraDue] = due.Format(model.DateLayout)
+		}
+	}
+	if len(task.Extra) == 0 {
+		task.Extra = nil
+	}
+	if *current < 0 {
+		*current = b.list(DefaultListName)
+	}
+	b.batch.Lists[*current].Tasks = append(b.batch.Lists[*current].Tasks, task)
+	return ""
+}
@@ -0,0 +1,95 @@
+package exchange
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func TestOrgRoundTripKeepsEverything(t *testing.T) {
+	original := richState(t)
+	original.Lists = append(original.Lists, model.List{ID: "l-todo", Name: "TODO casa", Color: "red"})
+
+	var buf bytes.Buffer
+	if err := ExportOrg(&buf, original, ExportOptions{IncludeArchive: true}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	exported := buf.String()
+	for _, want := range []string{"* Casa Nova\n", "** TODO [#A] Comprar tinta\n", "DEADLINE: <2026-03-10 Tue>", ":X_url: https://example.com/a?b=c&d\n"} {
+		if !strings.Contains(exported, want) {
+			t.Fatalf("expected %q in export, got:\n%s", want, exported)
+		}
+	}
+
+	batch, skipped, err := ImportOrg(strings.NewReader(exported))
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("import failed: %v %v", err, skipped)
+	}
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	got := svc.State()
+	if len(got.Lists) != len(original.Lists) || got.Lists[3].Name != "TODO casa" || !reflect.DeepEqual(original.Lists[:3], got.Lists[:3]) {
+		t.Fatalf("lists mismatch\nwant=%+v\ngot=%+v", original.Lists, got.Lists)
+	}
+	if want, have := sortedTasks(original.Tasks), sortedTasks(got.Tasks); !reflect.DeepEqual(want, have) {
+		t.Fatalf("tasks mismatch\nwant=%+v\ngot=%+v", want, have)
+	}
+	if !reflect.DeepEqual(original.ArchivedCompleted, got.ArchivedCompleted) {
+		t.Fatalf("archive mismatch\nwant=%+v\ngot=%+v", original.ArchivedCompleted, got.ArchivedCompleted)
+	}
+
+	result, err := svc.Import(batch)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if result.TasksCreated != 0 || result.ListsCreated != 0 {
+		t.Fatalf("expected idempotent re-import, got %+v", result)
+	}
+}
+
+func TestImportHandwrittenOrg(t *testing.T) {
+	input := `#+TITLE: Minhas coisas
+#+TODO: TODO NEXT(n) | DONE(d) ABANDONED
+* Projetos
+** NEXT [#B] Escrever proposta    :trabalho:urgente:
+   SCHEDULED: <2026-03-02 Mon> DEADLINE: <2026-03-06 Fri>
+   :LOGBOOK:
+   - State "NEXT" from "TODO" [2026-03-01 Sun 10:00]
+   :END:
+** Notas soltas
+   Um parágrafo qualquer.
+*** DONE Revisar orçamento
+    CLOSED: [2026-03-01 Sun 18:30]
+** ABANDONED Ideia antiga
+`
+	batch, skipped, err := ImportOrg(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if len(skipped) != 2 || skipped[0].Line != 9 || skipped[1].Line != 10 {
+		t.Fatalf("expected body text and keyword-less headline to be skipped, got %v", skipped)
+	}
+	if len(batch.Lists) != 1 || batch.Lists[0].Name != "Projetos" {
+		t.Fatalf("unexpected lists: %+v", batch.Lists)
+	}
+	tasks := batch.Lists[0].Tasks
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %+v", tasks)
+	}
+	wantExtra := map[string]string{"tags": "trabalho,urgente", "due": "2026-03-06"}
+	if tasks[0].Text != "Escrever proposta" || tasks[0].Done || tasks[0].Priority != model.PriorityMedium || !reflect.DeepEqual(tasks[0].Extra, wantExtra) {
+		t.Fatalf("unexpected first task: %+v", tasks[0])
+	}
+	if !tasks[1].Done || tasks[1].DoneAt.Format("2006-01-02 15:04") != "2026-03-01 18:30" {
+		t.Fatalf("expected CLOSED to set DoneAt, got %+v", tasks[1])
+	}
+	if !tasks[2].Done || tasks[2].Text != "Ideia antiga" {
+		t.Fatalf("expected custom done keyword, got %+v", tasks[2])
+	}
+}
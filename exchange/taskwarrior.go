@@ -0,0 +1,290 @@
+package exchange
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+const (
+	twTime = "20060102T150405Z"
+
+	// TaskwarriorUUIDKey holds the Taskwarrior UUID of imported tasks.
+	TaskwarriorUUIDKey = "tw-uuid"
+	// twTagsKey keeps Taskwarrior tags as a comma-separated Task.Extra value.
+	twTagsKey = "tags"
+)
+
+func init() {
+	register(Format{Name: "taskwarrior", Ext: ".json", Export: ExportTaskwarrior, Import: withoutOptions(ImportTaskwarrior)})
+}
+
+// twTask mirrors the fields of `task export` we read and write. TodoCLIID
+// and TodoCLIArchived are user-defined attributes: Taskwarrior keeps them
+// on import, which lets a round trip restore IDs and archive entries.
+type twTask struct {
+	UUID            string   `json:"uuid"`
+	Description     string   `json:"description"`
+	Status          string   `json:"status"`
+	Entry           string   `json:"entry,omitempty"`
+	Modified        string   `json:"modified,omitempty"`
+	End             string   `json:"end,omitempty"`
+	Due             string   `json:"due,omitempty"`
+	Project         string   `json:"project,omitempty"`
+	Priority        string   `json:"priority,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	TodoCLIID       string   `json:"todocliid,omitempty"`
+	TodoCLIArchived string   `json:"todocliarchived,omitempty"`
+}
+
+// ExportTaskwarrior writes a JSON array accepted by `task import`. Tasks
+// without a Taskwarrior UUID get one derived from their ID, so repeated
+// exports of the same task keep the same UUID.
+func ExportTaskwarrior(w io.Writer, state model.AppState, opts ExportOptions) error {
+	out := []twTask{}
+	for _, l := range exportLists(state, opts) {
+		for _, t := range listTasks(state, l.ID) {
+			tw := twTask{
+				UUID:        t.Extra[TaskwarriorUUIDKey],
+				Description: t.Text,
+				Status:      "pending",
+				Entry:       twFormatTime(t.CreatedAt),
+				Modified:    twFormatTime(t.UpdatedAt),
+				Project:     l.Name,
+				Priority:    twPriority(t.Priority),
+				TodoCLIID:   t.ID,
+			}
+			if tw.UUID == "" {
+				tw.UUID = twUUID(t.ID)
+			}
+			if t.Done {
+				tw.Status = "completed"
+				doneAt := t.DoneAt
+				if doneAt.IsZero() {
+					doneAt = t.UpdatedAt
+				}
+				tw.End = twFormatTime(doneAt)
+			}
+			if due, ok := t.Due(); ok {
+				tw.Due = twFormatTime(due)
+			}
+			if tags := t.Extra[twTagsKey]; tags != "" {
+				tw.Tags = strings.Split(tags, ",")
+			}
+			out = append(out, tw)
+		}
+	}
+	for _, a := range exportArchive(state, opts) {
+		out = append(out, twTask{
+			UUID:            twArchiveUUID(a.ID),
+			Description:     a.TaskText,
+			Status:          "completed",
+			Entry:           twFormatTime(a.DoneAt),
+			Modified:        twFormatTime(a.ArchivedAt),
+			End:             twFormatTime(a.DoneAt),
+			Project:         a.OriginList,
+			Priority:        twPriority(a.Priority),
+			TodoCLIID:       a.ID,
+			TodoCLIArchived: twFormatTime(a.ArchivedAt),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// ImportTaskwarrior reads `task export` output: a JSON array, or one object
+// per line as older versions wrote. Pending and waiting tasks become open
+// tasks; completed ones become done tasks when they came from todo-cli and
+// archive entries otherwise, since Taskwarrior keeps its whole history. An
+// archive entry whose UUID matches a task imported earlier completes that
+// task instead (see app.ImportBatch.MatchExtra).
+// Deleted and recurring template tasks are skipped. UUIDs are kept in
+// Extra[TaskwarriorUUIDKey] so re-importing updates instead of duplicating.
+func ImportTaskwarrior(r io.Reader) (app.ImportBatch, []Skipped, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return app.ImportBatch{}, nil, err
+	}
+
+	type item struct {
+		line int
+		raw  json.RawMessage
+	}
+	var items []item
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		var raws []json.RawMessage
+		if err := json.Unmarshal(trimmed, &raws); err != nil {
+			return app.ImportBatch{}, nil, fmt.Errorf("taskwarrior: %w", err)
+		}
+		for i, raw := range raws {
+			items = append(items, item{line: i + 1, raw: raw})
+		}
+	} else {
+		for i, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSuffix(strings.TrimSpace(line), ",")
+			if line != "" {
+				items = append(items, item{line: i + 1, raw: json.RawMessage(line)})
+			}
+		}
+	}
+
+	b := newBatchBuilder()
+	b.batch.MatchExtra = []string{TaskwarriorUUIDKey}
+	var skipped []Skipped
+	for _, it := range items {
+		var tw twTask
+		if err := json.Unmarshal(it.raw, &tw); err != nil {
+			skipped = append(skipped, Skipped{Line: it.line, Text: string(it.raw), Reason: i18n.T("exchange.bad_json")})
+			continue
+		}
+		if reason := twAddTask(b, tw); reason != "" {
+			skipped = append(skipped, Skipped{Line: it.line, Text: tw.Description, Reason: reason})
+		}
+	}
+	return b.batch, skipped, nil
+}
+
+func twAddTask(b *batchBuilder, tw twTask) string {
+	text := strings.TrimSpace(tw.Description)
+	if text == "" {
+		return i18n.T("exchange.no_description")
+	}
+	switch tw.Status {
+	case "pending", "waiting", "completed":
+	case "deleted":
+		return i18n.T("exchange.deleted_task")
+	case "recurring":
+		return i18n.T("exchange.recurring_template")
+	default:
+		return i18n.T("exchange.unknown_status", tw.Status)
+	}
+
+	var (
+		times [5]time.Time
+		err   error
+	)
+	for i, raw := range []string{tw.Entry, tw.Modified, tw.End, tw.Due, tw.TodoCLIArchived} {
+		if times[i], err = twParseTime(raw); err != nil {
+			return i18n.T("exchange.bad_date", raw)
+		}
+	}
+	entry, modified, end, due, archivedAt := times[0], times[1], times[2], times[3], times[4]
+	done := tw.Status == "completed"
+	priority := twParsePriority(tw.Priority)
+
+	if done && (tw.TodoCLIID == "" || !archivedAt.IsZero()) {
+		id := tw.TodoCLIID
+		if id == "" {
+			id = tw.UUID
+		}
+		if archivedAt.IsZero() {
+			archivedAt = modified
+		}
+		b.batch.Archived = append(b.batch.Archived, model.ArchivedCompletedTask{
+			ID:         id,
+			TaskText:   text,
+			OriginList: tw.Project,
+			Priority:   priority,
+			DoneAt:     end,
+			ArchivedAt: archivedAt,
+		})
+		return ""
+	}
+
+	task := model.Task{
+		ID:        tw.TodoCLIID,
+		Text:      text,
+		Priority:  priority,
+		Done:      done,
+		CreatedAt: entry,
+		UpdatedAt: modified,
+		DoneAt:    end,
+		Extra:     map[string]string{},
+	}
+	if tw.UUID != "" && tw.UUID != twUUID(tw.TodoCLIID) {
+		task.Extra[TaskwarriorUUIDKey] = tw.UUID
+	}
+	if !due.IsZero() {
+		task.Extra[model.ExtraDue] = due.Format(model.DateLayout)
+	}
+	if len(tw.Tags) > 0 {
+		task.Extra[twTagsKey] = strings.Join(tw.Tags, ",")
+	}
+	if len(task.Extra) == 0 {
+		task.Extra = nil
+	}
+	b.addTask(tw.Project, task)
+	return ""
+}
+
+// twUUID derives a stable version-5-style UUID from a todo-cli ID.
+func twUUID(id string) string {
+	if id == "" {
+		return ""
+	}
+	sum := sha1.Sum([]byte("todo-cli:" + id))
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func twArchiveUUID(id string) string {
+	// IDs de entradas importadas do Taskwarrior já são UUIDs.
+	if len(id) == 36 && strings.Count(id, "-") == 4 {
+		return id
+	}
+	return twUUID(id)
+}
+
+func twFormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(twTime)
+}
+
+// twParseTime accepts the compact ISO form `task export` writes and RFC 3339.
+func twParseTime(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(twTime, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	return t.UTC(), err
+}
+
+func twPriority(p model.Priority) string {
+	switch p {
+	case model.PriorityHigh:
+		return "H"
+	case model.PriorityMedium:
+		return "M"
+	case model.PriorityLow:
+		return "L"
+	}
+	return ""
+}
+
+func twParsePriority(raw string) model.Priority {
+	switch strings.ToUpper(strings.TrimSpace(raw)) {
+	case "H":
+		return model.PriorityHigh
+	case "M":
+		return model.PriorityMedium
+	case "L":
+		return model.PriorityLow
+	}
+	return model.PriorityNone
+}
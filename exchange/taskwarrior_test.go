@@ -0,0 +1,143 @@
+package exchange
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func TestTaskwarriorRoundTrip(t *testing.T) {
+	original := richState(t)
+
+	var buf bytes.Buffer
+	if err := ExportTaskwarrior(&buf, original, ExportOptions{IncludeArchive: true}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	first := buf.String()
+	if !strings.Contains(first, `"project": "Casa Nova"`) || !strings.Contains(first, `"priority": "H"`) {
+		t.Fatalf("unexpected export:\n%s", first)
+	}
+	buf.Reset()
+	if err := ExportTaskwarrior(&buf, original, ExportOptions{IncludeArchive: true}); err != nil {
+		t.Fatalf("second export failed: %v", err)
+	}
+	if buf.String() != first {
+		t.Fatal("expected UUIDs to be stable across exports")
+	}
+
+	batch, skipped, err := ImportTaskwarrior(strings.NewReader(first))
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("import failed: %v %v", err, skipped)
+	}
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	got := svc.State()
+	want, have := sortedTasks(original.Tasks), sortedTasks(got.Tasks)
+	if len(want) != len(have) {
+		t.Fatalf("expected %d tasks, got %d", len(want), len(have))
+	}
+	for i := range want {
+		w, h := want[i], have[i]
+		if w.ID != h.ID || w.Text != h.Text || w.Priority != h.Priority || w.Done != h.Done ||
+			!w.CreatedAt.Truncate(time.Second).Equal(h.CreatedAt) || w.Extra["due"] != h.Extra["due"] {
+			t.Fatalf("task mismatch\nwant=%+v\ngot=%+v", w, h)
+		}
+		if h.Extra[TaskwarriorUUIDKey] != "" {
+			t.Fatalf("derived UUID should not be stored: %+v", h.Extra)
+		}
+	}
+	if len(got.ArchivedCompleted) != 1 || got.ArchivedCompleted[0].ID != original.ArchivedCompleted[0].ID {
+		t.Fatalf("archive mismatch: %+v", got.ArchivedCompleted)
+	}
+
+	result, err := svc.Import(batch)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if result.TasksCreated != 0 || len(svc.State().ArchivedCompleted) != 1 {
+		t.Fatalf("expected idempotent re-import, got %+v", result)
+	}
+}
+
+func TestImportTaskwarriorExport(t *testing.T) {
+	input := `[
+{"id":1,"description":"Revisar PR","entry":"20260301T120000Z","modified":"20260302T080000Z","project":"Trabalho","priority":"M","status":"pending","tags":["code","review"],"uuid":"5f2c1b8e-0000-4000-8000-000000000001","urgency":4.9},
+{"id":0,"description":"Pagar aluguel","end":"20260305T100000Z","entry":"20260301T120000Z","modified":"20260305T100000Z","project":"Casa","status":"completed","uuid":"5f2c1b8e-0000-4000-8000-000000000002"},
+{"id":0,"description":"Descartada","entry":"20260301T120000Z","status":"deleted","uuid":"5f2c1b8e-0000-4000-8000-000000000003"},
+{"id":2,"description":"Sem projeto","entry":"20260301T120000Z","status":"waiting","priority":"L","due":"20260320T030000Z","uuid":"5f2c1b8e-0000-4000-8000-000000000004"}
+]`
+	batch, skipped, err := ImportTaskwarrior(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0].Line != 3 {
+		t.Fatalf("expected deleted task to be skipped, got %v", skipped)
+	}
+	if len(batch.Lists) != 2 || batch.Lists[0].Name != "Trabalho" || batch.Lists[1].Name != DefaultListName {
+		t.Fatalf("unexpected lists: %+v", batch.Lists)
+	}
+	review := batch.Lists[0].Tasks[0]
+	wantExtra := map[string]string{TaskwarriorUUIDKey: "5f2c1b8e-0000-4000-8000-000000000001", "tags": "code,review"}
+	if review.Priority != model.PriorityMedium || review.Done || !reflect.DeepEqual(review.Extra, wantExtra) ||
+		review.UpdatedAt.Format(twTime) != "20260302T080000Z" {
+		t.Fatalf("unexpected task: %+v", review)
+	}
+	if due := batch.Lists[1].Tasks[0].Extra["due"]; due != "2026-03-20" {
+		t.Fatalf("expected due date, got %q", due)
+	}
+	if len(batch.Archived) != 1 || batch.Archived[0].ID != "5f2c1b8e-0000-4000-8000-000000000002" || batch.Archived[0].OriginList != "Casa" {
+		t.Fatalf("expected completed task to be archived, got %+v", batch.Archived)
+	}
+
+	svc := app.NewService(model.NewState())
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	legacy := `{"description":"Revisar PR com calma","project":"Trabalho","status":"pending","uuid":"5f2c1b8e-0000-4000-8000-000000000001"},`
+	batch, _, err = ImportTaskwarrior(strings.NewReader(legacy))
+	if err != nil {
+		t.Fatalf("legacy import failed: %v", err)
+	}
+	result, err := svc.Import(batch)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if result.TasksUpdated != 1 || len(svc.State().Tasks) != 2 {
+		t.Fatalf("expected UUID match to update the task, got %+v", result)
+	}
+}
+
+func TestTaskwarriorCompletedTaskUpdatesImportedOne(t *testing.T) {
+	pending := `[{"description":"Revisar PR","entry":"20260301T120000Z","project":"Trabalho","status":"pending","uuid":"5f2c1b8e-0000-4000-8000-000000000001"}]`
+	completed := `[{"description":"Revisar PR","entry":"20260301T120000Z","end":"20260303T090000Z","modified":"20260303T090000Z","project":"Trabalho","status":"completed","uuid":"5f2c1b8e-0000-4000-8000-000000000001"}]`
+
+	svc := app.NewService(model.NewState())
+	for _, input := range []string{pending, completed, completed} {
+		batch, skipped, err := ImportTaskwarrior(strings.NewReader(input))
+		if err != nil || len(skipped) != 0 {
+			t.Fatalf("import failed: %v %v", err, skipped)
+		}
+		if _, err := svc.Import(batch); err != nil {
+			t.Fatalf("service import failed: %v", err)
+		}
+	}
+
+	state := svc.State()
+	if len(state.ArchivedCompleted) != 0 {
+		t.Fatalf("expected no archive entry next to the imported task, got %+v", state.ArchivedCompleted)
+	}
+	if len(state.Tasks) != 1 {
+		t.Fatalf("expected a single task, got %+v", state.Tasks)
+	}
+	task := state.Tasks[0]
+	if !task.Done || task.Status != model.StatusDone || task.DoneAt.Format(twTime) != "20260303T090000Z" {
+		t.Fatalf("expected the task to be completed, got %+v", task)
+	}
+}
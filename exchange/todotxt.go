@@ -0,0 +1,298 @@
+package exchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+const todoTxtDate = "2006-01-02"
+
+// Extensões reservadas: pri guarda a prioridade de tarefas concluídas (o
+// formato remove o "(A)" ao concluir), archived marca entradas do arquivo,
+// status guarda a coluna do quadro, statuses as colunas da lista e pinned
+// as tarefas fixadas.
+const (
+	todoTxtPriKey      = "pri"
+	todoTxtArchivedKey = "archived"
+	todoTxtStatusKey   = "status"
+	todoTxtStatusesKey = "statuses"
+	todoTxtPinnedKey   = "pinned"
+)
+
+var (
+	todoTxtPriority  = regexp.MustCompile(`^\(([A-Z])\)$`)
+	todoTxtExtension = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*):([^\s:]+)$`)
+)
+
+func init() {
+	register(Format{Name: "todotxt", Ext: ".txt", Export: ExportTodoTxt, Import: withoutOptions(ImportTodoTxt)})
+}
+
+// ExportTodoTxt writes one todo.txt line per task. The list becomes a
+// +project (spaces as underscores) and Task.Extra becomes key:value pairs,
+// with spaces, colons and percent signs in values percent-encoded.
+func ExportTodoTxt(w io.Writer, state model.AppState, opts ExportOptions) error {
+	bw := bufio.NewWriter(w)
+	for _, l := range exportLists(state, opts) {
+		for _, t := range listTasks(state, l.ID) {
+			fmt.Fprintln(bw, todoTxtLine(t, l))
+		}
+	}
+	for _, a := range exportArchive(state, opts) {
+		fmt.Fprintln(bw, todoTxtArchivedLine(a))
+	}
+	return bw.Flush()
+}
+
+func todoTxtLine(t model.Task, l model.List) string {
+	var parts []string
+	extra := maps.Clone(t.Extra)
+	if extra == nil {
+		extra = map[string]string{}
+	}
+	// A coluna só é escrita quando o "x" não a deixa implícita.
+	if columns := l.BoardStatuses(); !t.Done && t.Status != "" && t.Status != columns[0] {
+		extra[todoTxtStatusKey] = todoTxtWord(t.Status)
+	}
+	if len(l.Statuses) > 0 {
+		extra[todoTxtStatusesKey] = todoTxtWord(joinStatuses(l.Statuses))
+	}
+	if t.Pinned {
+		extra[todoTxtPinnedKey] = "true"
+	}
+	if t.Done {
+		doneAt := t.DoneAt
+		if doneAt.IsZero() {
+			doneAt = t.UpdatedAt
+		}
+		parts = append(parts, "x", doneAt.Format(todoTxtDate))
+		if t.Priority != model.PriorityNone {
+			extra[todoTxtPriKey] = todoTxtPriorityLetter(t.Priority)
+		}
+	} else if t.Priority != model.PriorityNone {
+		parts = append(parts, "("+todoTxtPriorityLetter(t.Priority)+")")
+	}
+	if !t.CreatedAt.IsZero() {
+		parts = append(parts, t.CreatedAt.Format(todoTxtDate))
+	}
+	parts = append(parts, t.Text, todoTxtProject(l.Name))
+	return strings.Join(append(parts, todoTxtExtensions(extra)...), " ")
+}
+
+func todoTxtArchivedLine(a model.ArchivedCompletedTask) string {
+	extra := map[string]string{todoTxtArchivedKey: a.ArchivedAt.Format(todoTxtDate)}
+	if a.Priority != model.PriorityNone {
+		extra[todoTxtPriKey] = todoTxtPriorityLetter(a.Priority)
+	}
+	parts := []string{"x", a.DoneAt.Format(todoTxtDate), a.TaskText}
+	if a.OriginList != "" {
+		parts = append(parts, todoTxtProject(a.OriginList))
+	}
+	return strings.Join(append(parts, todoTxtExtensions(extra)...), " ")
+}
+
+func todoTxtExtensions(extra map[string]string) []string {
+	out := make([]string, 0, len(extra))
+	for _, key := range slices.Sorted(maps.Keys(extra)) {
+		out = append(out, key+":"+todoTxtValueEscaper.Replace(extra[key]))
+	}
+	return out
+}
+
+// Extension values are percent-encoded where they would end the field or
+// split the pair, so any Task.Extra value survives a round trip.
+var (
+	todoTxtValueEscaper = strings.NewReplacer(
+		"%", "%25", ":", "%3A", " ", "%20", "\t", "%09", "\n", "%0A", "\r", "%0D",
+	)
+	todoTxtValueUnescaper = strings.NewReplacer(
+		"%25", "%", "%3A", ":", "%20", " ", "%09", "\t", "%0A", "\n", "%0D", "\r",
+	)
+)
+
+func todoTxtProject(listName string) string {
+	return "+" + todoTxtWord(listName)
+}
+
+// todoTxtWord makes a value fit in one field, spaces as underscores; the
+// import turns them back.
+func todoTxtWord(value string) string {
+	return strings.Join(strings.Fields(value), "_")
+}
+
+func todoTxtPriorityLetter(p model.Priority) string {
+	switch p {
+	case model.PriorityHigh:
+		return "A"
+	case model.PriorityMedium:
+		return "B"
+	default:
+		return "C"
+	}
+}
+
+// todoTxtPriorityValue maps A/B/C to high/medium/low; D..Z count as low.
+func todoTxtPriorityValue(letter string) model.Priority {
+	switch letter {
+	case "":
+		return model.PriorityNone
+	case "A":
+		return model.PriorityHigh
+	case "B":
+		return model.PriorityMedium
+	default:
+		return model.PriorityLow
+	}
+}
+
+// ImportTodoTxt parses todo.txt lines. The last +project names the list;
+// other +projects and @contexts stay in the text. Unknown key:value pairs
+// are kept in Task.Extra.
+func ImportTodoTxt(r io.Reader) (app.ImportBatch, []Skipped, error) {
+	b := newBatchBuilder()
+	var skipped []Skipped
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		entry := parseTodoTxtLine(line)
+		if entry.text == "" {
+			skipped = append(skipped, Skipped{Line: n, Text: line, Reason: i18n.T("exchange.no_text")})
+			continue
+		}
+
+		if archivedAt, ok := entry.extra[todoTxtArchivedKey]; ok && entry.done {
+			at, err := time.Parse(todoTxtDate, archivedAt)
+			if err != nil {
+				skipped = append(skipped, Skipped{Line: n, Text: line, Reason: i18n.T("exchange.bad_archive_date")})
+				continue
+			}
+			b.batch.Archived = append(b.batch.Archived, model.ArchivedCompletedTask{
+				TaskText:   entry.text,
+				OriginList: entry.list,
+				Priority:   todoTxtPriorityValue(entry.extra[todoTxtPriKey]),
+				DoneAt:     entry.doneAt,
+				ArchivedAt: at,
+			})
+			continue
+		}
+
+		priority := entry.priority
+		if entry.done {
+			priority = entry.extra[todoTxtPriKey]
+			delete(entry.extra, todoTxtPriKey)
+		}
+		if raw, ok := entry.extra[todoTxtStatusesKey]; ok {
+			b.columns(entry.list, splitStatuses(strings.ReplaceAll(raw, "_", " ")))
+			delete(entry.extra, todoTxtStatusesKey)
+		}
+		status := strings.ReplaceAll(entry.extra[todoTxtStatusKey], "_", " ")
+		delete(entry.extra, todoTxtStatusKey)
+		pinned := entry.extra[todoTxtPinnedKey] == "true"
+		delete(entry.extra, todoTxtPinnedKey)
+		task := model.Task{
+			Text:      entry.text,
+			Done:      entry.done,
+			Status:    status,
+			Pinned:    pinned,
+			Priority:  todoTxtPriorityValue(priority),
+			CreatedAt: entry.createdAt,
+			UpdatedAt: entry.createdAt,
+			DoneAt:    entry.doneAt,
+		}
+		if entry.done && !entry.doneAt.IsZero() {
+			task.UpdatedAt = entry.doneAt
+		}
+		if len(entry.extra) > 0 {
+			task.Extra = entry.extra
+		}
+		b.addTask(entry.list, task)
+	}
+	if err := sc.Err(); err != nil {
+		return app.ImportBatch{}, nil, err
+	}
+	return b.batch, skipped, nil
+}
+
+type todoTxtEntry struct {
+	done      bool
+	priority  string
+	doneAt    time.Time
+	createdAt time.Time
+	text      string
+	list      string
+	extra     map[string]string
+}
+
+func parseTodoTxtLine(line string) todoTxtEntry {
+	var e todoTxtEntry
+	fields := strings.Fields(line)
+	parseDate := func() (time.Time, bool) {
+		if len(fields) == 0 {
+			return time.Time{}, false
+		}
+		d, err := time.Parse(todoTxtDate, fields[0])
+		if err != nil {
+			return time.Time{}, false
+		}
+		fields = fields[1:]
+		return d, true
+	}
+
+	if fields[0] == "x" {
+		e.done = true
+		fields = fields[1:]
+		if d, ok := parseDate(); ok {
+			e.doneAt = d
+			if c, ok := parseDate(); ok {
+				e.createdAt = c
+			}
+		}
+	} else {
+		if m := todoTxtPriority.FindStringSubmatch(fields[0]); m != nil {
+			e.priority = m[1]
+			fields = fields[1:]
+		}
+		if c, ok := parseDate(); ok {
+			e.createdAt = c
+		}
+	}
+
+	projectAt := -1
+	for i, f := range fields {
+		if len(f) > 1 && strings.HasPrefix(f, "+") {
+			projectAt = i
+		}
+	}
+	words := make([]string, 0, len(fields))
+	for i, f := range fields {
+		if i == projectAt {
+			e.list = strings.ReplaceAll(f[1:], "_", " ")
+			continue
+		}
+		// "https://..." casaria com chave:valor; URLs ficam no texto.
+		if m := todoTxtExtension.FindStringSubmatch(f); m != nil && !strings.HasPrefix(m[2], "//") {
+			if e.extra == nil {
+				e.extra = map[string]string{}
+			}
+			e.extra[m[1]] = todoTxtValueUnescaper.Replace(m[2])
+			continue
+		}
+		words = append(words, f)
+	}
+	e.text = strings.Join(words, " ")
+	return e
+}
@@ -0,0 +1,175 @@
+package exchange
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestImportTodoTxt(t *testing.T) {
+	input := `(A) 2026-03-01 Ligar para o banco +Casa_Nova @telefone due:2026-03-10
+x 2026-03-05 2026-03-02 Pagar aluguel +Casa_Nova pri:B
+Sem projeto
+x 2026-02-01 Velha +Trabalho archived:2026-02-03
+
+(B)
+`
+	batch, skipped, err := ImportTodoTxt(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0].Line != 6 {
+		t.Fatalf("expected line 6 to be skipped, got %v", skipped)
+	}
+	if len(batch.Lists) != 2 || batch.Lists[0].Name != "Casa Nova" || batch.Lists[1].Name != DefaultListName {
+		t.Fatalf("unexpected lists: %+v", batch.Lists)
+	}
+
+	open := batch.Lists[0].Tasks[0]
+	if open.Text != "Ligar para o banco @telefone" || open.Priority != model.PriorityHigh || open.Done {
+		t.Fatalf("unexpected open task: %+v", open)
+	}
+	if !open.CreatedAt.Equal(day("2026-03-01")) || open.Extra["due"] != "2026-03-10" {
+		t.Fatalf("expected created date and due extension, got %+v", open)
+	}
+
+	done := batch.Lists[0].Tasks[1]
+	if !done.Done || done.Priority != model.PriorityMedium || !done.DoneAt.Equal(day("2026-03-05")) || done.Extra != nil {
+		t.Fatalf("unexpected done task: %+v", done)
+	}
+
+	if len(batch.Archived) != 1 || batch.Archived[0].OriginList != "Trabalho" || !batch.Archived[0].ArchivedAt.Equal(day("2026-02-03")) {
+		t.Fatalf("unexpected archive: %+v", batch.Archived)
+	}
+}
+
+func TestTodoTxtKeepsURLsInText(t *testing.T) {
+	batch, _, err := ImportTodoTxt(strings.NewReader("Ler https://go.dev/doc/effective_go hoje +Estudo due:2026-03-10\n"))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	task := batch.Lists[0].Tasks[0]
+	if task.Text != "Ler https://go.dev/doc/effective_go hoje" {
+		t.Fatalf("expected the URL to stay in the text, got %q", task.Text)
+	}
+	if want := map[string]string{"due": "2026-03-10"}; !reflect.DeepEqual(task.Extra, want) {
+		t.Fatalf("expected only the due extension, got %+v", task.Extra)
+	}
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	svc := app.NewService(model.NewState())
+	home, _ := svc.CreateList("Casa Nova", "green")
+	work, _ := svc.CreateList("Trabalho", "blue")
+	first, _ := svc.CreateTask(home.ID, "Comprar tinta @loja")
+	second, _ := svc.CreateTask(home.ID, "Pintar sala")
+	third, _ := svc.CreateTask(work.ID, "Relatório")
+	if _, err := svc.SetTaskPriority(first.ID, model.PriorityHigh); err != nil {
+		t.Fatalf("priority failed: %v", err)
+	}
+	if _, err := svc.SetTaskPriority(second.ID, model.PriorityLow); err != nil {
+		t.Fatalf("priority failed: %v", err)
+	}
+	if _, err := svc.ToggleDone(second.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if _, err := svc.ToggleDone(third.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if _, err := svc.ClearCompletedToArchive(work.ID); err != nil {
+		t.Fatalf("archive failed: %v", err)
+	}
+	if _, err := svc.SetListStatuses(home.ID, []string{"A fazer", "Em andamento", "Feito"}); err != nil {
+		t.Fatalf("set statuses failed: %v", err)
+	}
+	if _, err := svc.SetTaskStatus(first.ID, "Em andamento"); err != nil {
+		t.Fatalf("set status failed: %v", err)
+	}
+	if _, err := svc.SetTaskPinned(first.ID, true); err != nil {
+		t.Fatalf("pin failed: %v", err)
+	}
+	original := svc.State()
+
+	var buf bytes.Buffer
+	if err := ExportTodoTxt(&buf, original, ExportOptions{IncludeArchive: true}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	batch, skipped, err := ImportTodoTxt(&buf)
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("import failed: %v %v", err, skipped)
+	}
+
+	imported := app.NewService(model.NewState())
+	if _, err := imported.Import(batch); err != nil {
+		t.Fatalf("service import failed: %v", err)
+	}
+	got := imported.State()
+
+	type flat struct {
+		List, Text, Status string
+		Done, Pinned       bool
+		Priority           model.Priority
+	}
+	flatten := func(s model.AppState) []flat {
+		names := map[string]string{}
+		for _, l := range s.Lists {
+			names[l.ID] = l.Name
+		}
+		var out []flat
+		for _, l := range s.Lists {
+			for _, task := range listTasks(s, l.ID) {
+				out = append(out, flat{names[task.ListID], task.Text, task.Status, task.Done, task.Pinned, task.Priority})
+			}
+		}
+		return out
+	}
+	if !reflect.DeepEqual(flatten(original), flatten(got)) {
+		t.Fatalf("round-trip mismatch\nwant=%+v\ngot=%+v", flatten(original), flatten(got))
+	}
+	if !reflect.DeepEqual(got.Lists[0].Statuses, original.Lists[0].Statuses) {
+		t.Fatalf("expected board columns to round-trip, got %q", got.Lists[0].Statuses)
+	}
+	if len(got.ArchivedCompleted) != 1 || got.ArchivedCompleted[0].TaskText != "Relatório" || got.ArchivedCompleted[0].OriginList != "Trabalho" {
+		t.Fatalf("expected archive to round-trip, got %+v", got.ArchivedCompleted)
+	}
+}
+
+func TestTodoTxtRoundTripsExtraValues(t *testing.T) {
+	extra := map[string]string{
+		"onde":  "Rua das Flores 10",
+		"hora":  "10:30",
+		"desc":  "50% off",
+		"plain": "x",
+	}
+	state := model.NewState()
+	state.Lists = []model.List{{ID: "l1", Name: "Casa"}}
+	state.Tasks = []model.Task{{ID: "t1", ListID: "l1", Text: "Ligar", Position: 1, Extra: extra}}
+
+	var buf bytes.Buffer
+	if err := ExportTodoTxt(&buf, state, ExportOptions{}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if line := buf.String(); !strings.Contains(line, "onde:Rua%20das%20Flores%2010") || !strings.Contains(line, "hora:10%3A30") {
+		t.Fatalf("expected escaped values, got %q", line)
+	}
+	batch, skipped, err := ImportTodoTxt(&buf)
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("import failed: %v %v", err, skipped)
+	}
+	if got := batch.Lists[0].Tasks[0]; got.Text != "Ligar" || !reflect.DeepEqual(got.Extra, extra) {
+		t.Fatalf("expected extra values to survive, got %q %v", got.Text, got.Extra)
+	}
+}
@@ -0,0 +1,302 @@
+// Package hooks runs user scripts on task events, in the spirit of git
+// hooks. Executables named after an event live in one directory and read
+// the event as JSON on stdin:
+//
+//	on-add, on-edit, on-move, on-done, on-delete, on-archive, on-import  after the change (failures are reported)
+//	pre-add, pre-edit, pre-move, pre-done, pre-delete, pre-archive         before the change (non-zero exit vetoes it)
+//
+// A pre-add, pre-edit, pre-move or pre-done hook may also print JSON on
+// stdout to amend the task, e.g. {"task":{"priority":3}}; fields it leaves
+// out keep their proposed values. Only the text, priority, pin and extra
+// fields are taken, plus listId and position for pre-move. Pre-hooks run
+// without holding the app state, so a slow one does not freeze the UI.
+//
+// An import runs pre-add and pre-done for each task it would create or
+// complete, and a veto rejects the whole import. Afterwards on-import runs
+// once, with the created and updated tasks in "tasks" and the new archive
+// entries in "entries", instead of on-add and on-done per task.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// DirName is the hooks directory looked up next to the state file.
+const DirName = "hooks"
+
+// DefaultTimeout bounds each hook run.
+const DefaultTimeout = 5 * time.Second
+
+// Event kinds; hook names are "on-<kind>" and "pre-<kind>".
+const (
+	KindAdd     = "add"
+	KindEdit    = "edit"
+	KindMove    = "move"
+	KindDone    = "done"
+	KindDelete  = "delete"
+	KindArchive = "archive"
+	KindImport  = "import"
+)
+
+// ErrTimeout is wrapped by hook runs that exceed the timeout.
+var ErrTimeout = errors.New("hook timed out")
+
+// Options configures a Runner.
+type Options struct {
+	Dir     string
+	Timeout time.Duration
+}
+
+// Payload is the JSON document a hook reads on stdin. Event is the
+// app.Event name (e.g. "task.toggled"), so one script can tell apart the
+// changes that share a hook, like deleting a task or a whole list.
+type Payload struct {
+	Hook    string                        `json:"hook"`
+	Event   string                        `json:"event"`
+	Task    *model.Task                   `json:"task,omitempty"`
+	Before  *model.Task                   `json:"before,omitempty"`
+	List    *model.List                   `json:"list,omitempty"`
+	ListID  string                        `json:"listId,omitempty"`
+	Tasks   []model.Task                  `json:"tasks,omitempty"`
+	Entries []model.ArchivedCompletedTask `json:"entries,omitempty"`
+}
+
+// Runner executes the hooks of one directory.
+type Runner struct {
+	opts Options
+}
+
+// New returns a Runner; a zero Timeout means DefaultTimeout.
+func New(opts Options) *Runner {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	return &Runner{opts: opts}
+}
+
+// Attach installs the pre-hooks on svc and runs post-hooks for its events
+// on a background goroutine, in order. Post-hook failures go to report,
+// which may be nil. detach stops both and waits for queued hooks to finish.
+func (r *Runner) Attach(svc *app.Service, report func(error)) (detach func()) {
+	var (
+		mu     sync.Mutex
+		queue  []Payload
+		closed bool
+		wake   = make(chan struct{}, 1)
+		done   = make(chan struct{})
+	)
+	svc.SetPreHook(r.pre)
+	unsubscribe := svc.Subscribe(func(ev app.Event) {
+		p, ok := payloadFor(ev, "on-")
+		if !ok {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		queue = append(queue, p)
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	})
+
+	go func() {
+		defer close(done)
+		for range wake {
+			for {
+				mu.Lock()
+				if len(queue) == 0 {
+					mu.Unlock()
+					break
+				}
+				p := queue[0]
+				queue = queue[1:]
+				mu.Unlock()
+				if _, err := r.Run(p); err != nil && report != nil {
+					report(err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		unsubscribe()
+		svc.SetPreHook(nil)
+		mu.Lock()
+		if !closed {
+			closed = true
+			close(wake)
+		}
+		mu.Unlock()
+		<-done
+	}
+}
+
+// pre is the app.PreHook: it runs the pre-<kind> hook for ev, if any.
+func (r *Runner) pre(ev app.Event) (app.Event, error) {
+	p, ok := payloadFor(ev, "pre-")
+	if !ok {
+		return ev, nil
+	}
+	out, err := r.Run(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 || p.Task == nil {
+		return ev, nil
+	}
+	// A resposta é aplicada sobre o payload enviado: só os campos presentes mudam.
+	if err := json.Unmarshal(out, &p); err != nil {
+		return nil, i18n.Errorf("hooks.bad_reply", p.Hook, err)
+	}
+	if p.Task == nil {
+		return ev, nil
+	}
+	switch ev := ev.(type) {
+	case app.TaskCreated:
+		ev.Task = *p.Task
+		return ev, nil
+	case app.TaskUpdated:
+		ev.After = *p.Task
+		return ev, nil
+	case app.TaskToggled:
+		ev.After = *p.Task
+		return ev, nil
+	case app.TaskMoved:
+		ev.After = *p.Task
+		return ev, nil
+	}
+	return ev, nil
+}
+
+// Run executes the hook named in p.Hook with p on stdin and returns its
+// stdout. A missing or non-executable hook is not an error and yields nil.
+func (r *Runner) Run(p Payload) ([]byte, error) {
+	path := filepath.Join(r.opts.Dir, p.Hook)
+	if !isExecutable(path) {
+		return nil, nil
+	}
+	input, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.opts.Timeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "TODO_HOOK="+p.Hook)
+	// Filhos que herdam os pipes não podem segurar o Wait além do limite.
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, i18n.Errorf("hooks.timed_out", p.Hook, ErrTimeout, r.opts.Timeout)
+	}
+	if err != nil {
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, i18n.Errorf("hooks.failed", p.Hook, msg)
+		}
+		return nil, i18n.Errorf("hooks.run_failed", p.Hook, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// payloadFor maps an event to its hook; ok is false for events without one.
+func payloadFor(ev app.Event, prefix string) (Payload, bool) {
+	p := Payload{Event: ev.Name()}
+	var kind string
+	switch ev := ev.(type) {
+	case app.TaskCreated:
+		kind = KindAdd
+		p.Task = taskRef(ev.Task)
+	case app.TaskUpdated:
+		kind = KindEdit
+		p.Task = taskRef(ev.After)
+		p.Before = taskRef(ev.Before)
+	case app.TaskMoved:
+		kind = KindMove
+		p.Task = taskRef(ev.After)
+		p.Before = taskRef(ev.Before)
+	case app.TaskToggled:
+		if !ev.After.Done {
+			return Payload{}, false
+		}
+		kind = KindDone
+		p.Task = taskRef(ev.After)
+		p.Before = taskRef(ev.Before)
+	case app.TaskDeleted:
+		kind = KindDelete
+		p.Task = taskRef(ev.Task)
+	case app.TasksCleared:
+		kind = KindDelete
+		p.ListID = ev.ListID
+		p.Tasks = ev.Tasks
+	case app.ListDeleted:
+		kind = KindDelete
+		list := ev.List
+		p.List = &list
+		p.ListID = list.ID
+		p.Tasks = ev.Tasks
+	case app.Archived:
+		kind = KindArchive
+		p.ListID = ev.ListID
+		p.Tasks = ev.Tasks
+		p.Entries = ev.Entries
+	case app.Imported:
+		kind = KindImport
+		diff := app.DiffStates(ev.Before, ev.After)
+		p.Tasks = diff.AddedTasks
+		for _, c := range diff.ChangedTasks {
+			p.Tasks = append(p.Tasks, c.After)
+		}
+		known := make(map[string]bool, len(ev.Before.ArchivedCompleted))
+		for _, a := range ev.Before.ArchivedCompleted {
+			known[a.ID] = true
+		}
+		for _, a := range ev.After.ArchivedCompleted {
+			if !known[a.ID] {
+				p.Entries = append(p.Entries, a)
+			}
+		}
+	default:
+		return Payload{}, false
+	}
+	p.Hook = prefix + kind
+	return p, true
+}
+
+func taskRef(t model.Task) *model.Task {
+	t.Extra = maps.Clone(t.Extra)
+	return &t
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,201 @@
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func writeHook(t *testing.T, dir, name, script string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatalf("write hook failed: %v", err)
+	}
+}
+
+func TestPostHooksReceiveEventJSON(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "done.json")
+	writeHook(t, dir, "on-done", "cat > "+out+"\n")
+	writeHook(t, dir, "on-delete", "echo 'sem rede' >&2\nexit 1\n")
+
+	svc := app.NewService(model.NewState())
+	var failures []error
+	detach := New(Options{Dir: dir}).Attach(svc, func(err error) { failures = append(failures, err) })
+
+	list, _ := svc.CreateList("Casa", "")
+	task, _ := svc.CreateTask(list.ID, "Pintar")
+	if _, err := svc.ToggleDone(task.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if err := svc.DeleteTask(task.ID); err != nil {
+		t.Fatalf("post-hook failures must not undo the change: %v", err)
+	}
+	detach()
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("on-done did not run: %v", err)
+	}
+	var p Payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("decode payload failed: %v", err)
+	}
+	if p.Hook != "on-done" || p.Event != "task.toggled" || p.Task == nil || !p.Task.Done || p.Before == nil || p.Before.Done {
+		t.Fatalf("unexpected payload: %s", data)
+	}
+	if len(failures) != 1 || failures[0].Error() != "on-delete: sem rede" {
+		t.Fatalf("expected on-delete failure to be reported, got %v", failures)
+	}
+}
+
+func TestPreHooksVetoAndAmend(t *testing.T) {
+	dir := t.TempDir()
+	writeHook(t, dir, "pre-add", `if grep -q '"text":"proibida"'; then echo 'texto bloqueado' >&2; exit 1; fi
+echo '{"task":{"priority":3,"extra":{"origem":"hook"}}}'
+`)
+	writeHook(t, dir, "pre-archive", "exit 2\n")
+
+	svc := app.NewService(model.NewState())
+	defer New(Options{Dir: dir}).Attach(svc, nil)()
+	list, _ := svc.CreateList("Casa", "")
+
+	if _, err := svc.CreateTask(list.ID, "proibida"); !errors.Is(err, app.ErrVetoed) || !strings.Contains(err.Error(), "texto bloqueado") {
+		t.Fatalf("expected veto with hook reason, got %v", err)
+	}
+	if tasks := svc.Tasks(list.ID); len(tasks) != 0 {
+		t.Fatalf("vetoed task must not be created, got %+v", tasks)
+	}
+
+	task, err := svc.CreateTask(list.ID, "Pintar")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if task.Text != "Pintar" || task.Priority != model.PriorityHigh || task.Extra["origem"] != "hook" {
+		t.Fatalf("expected hook amendments on top of the proposal, got %+v", task)
+	}
+
+	_, _ = svc.ToggleDone(task.ID)
+	if _, err := svc.ClearCompletedToArchive(list.ID); !errors.Is(err, app.ErrVetoed) {
+		t.Fatalf("expected archive veto, got %v", err)
+	}
+	if len(svc.ArchivedCompleted()) != 0 {
+		t.Fatal("vetoed archive must not change state")
+	}
+}
+
+func TestPreHooksAmendEditsAndMoves(t *testing.T) {
+	dir := t.TempDir()
+	writeHook(t, dir, "pre-edit", `echo '{"task":{"text":"revisada"}}'
+`)
+	writeHook(t, dir, "pre-move", "echo 'fixa' >&2\nexit 1\n")
+
+	svc := app.NewService(model.NewState())
+	defer New(Options{Dir: dir}).Attach(svc, nil)()
+	list, _ := svc.CreateList("Casa", "")
+	a, _ := svc.CreateTask(list.ID, "A")
+	svc.CreateTask(list.ID, "B")
+
+	if got, err := svc.UpdateTask(a.ID, "outra"); err != nil || got.Text != "revisada" {
+		t.Fatalf("expected pre-edit to amend the text, got %+v (%v)", got, err)
+	}
+	if _, err := svc.MoveTaskDown(a.ID); !errors.Is(err, app.ErrVetoed) {
+		t.Fatalf("expected pre-move to veto, got %v", err)
+	}
+}
+
+func TestSlowPreHookDoesNotBlockReaders(t *testing.T) {
+	dir := t.TempDir()
+	writeHook(t, dir, "pre-add", "sleep 1\n")
+
+	svc := app.NewService(model.NewState())
+	defer New(Options{Dir: dir}).Attach(svc, nil)()
+	list, _ := svc.CreateList("Casa", "")
+
+	created := make(chan error, 1)
+	go func() {
+		_, err := svc.CreateTask(list.ID, "Pintar")
+		created <- err
+	}()
+	time.Sleep(200 * time.Millisecond)
+	start := time.Now()
+	_ = svc.State()
+	if waited := time.Since(start); waited > 500*time.Millisecond {
+		t.Fatalf("reads waited %s for the pre-hook", waited)
+	}
+	if err := <-created; err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	dir := t.TempDir()
+	writeHook(t, dir, "pre-delete", "exec sleep 5\n")
+	r := New(Options{Dir: dir, Timeout: 100 * time.Millisecond})
+
+	start := time.Now()
+	_, err := r.Run(Payload{Hook: "pre-delete"})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("timeout took too long: %s", elapsed)
+	}
+
+	if out, err := r.Run(Payload{Hook: "on-add"}); err != nil || out != nil {
+		t.Fatalf("missing hook must be a no-op, got %q %v", out, err)
+	}
+}
+
+func TestImportRunsHooks(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "import.json")
+	writeHook(t, dir, "pre-add", `if grep -q '"text":"proibida"'; then exit 1; fi
+echo '{"task":{"priority":3}}'
+`)
+	writeHook(t, dir, "on-import", "cat > "+out+"\n")
+	writeHook(t, dir, "on-add", "echo 'não devia rodar' >&2\nexit 1\n")
+
+	svc := app.NewService(model.NewState())
+	var failures []error
+	detach := New(Options{Dir: dir}).Attach(svc, func(err error) { failures = append(failures, err) })
+
+	batch := app.ImportBatch{Lists: []app.ImportList{{List: model.List{Name: "Casa"}, Tasks: []model.Task{{Text: "Pintar"}, {Text: "proibida"}}}}}
+	if _, err := svc.Import(batch); !errors.Is(err, app.ErrVetoed) {
+		t.Fatalf("expected the pre-add veto to reject the import, got %v", err)
+	}
+	if state := svc.State(); len(state.Lists) != 0 || len(state.Tasks) != 0 {
+		t.Fatalf("expected a vetoed import to change nothing, got %+v", state)
+	}
+
+	batch.Lists[0].Tasks = batch.Lists[0].Tasks[:1]
+	if _, err := svc.Import(batch); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	detach()
+
+	if tasks := svc.Tasks(""); len(tasks) != 1 || tasks[0].Priority != model.PriorityHigh {
+		t.Fatalf("expected pre-add to amend the imported task, got %+v", tasks)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("on-import did not run: %v", err)
+	}
+	var p Payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("decode payload failed: %v", err)
+	}
+	if p.Hook != "on-import" || p.Event != "imported" || len(p.Tasks) != 1 || p.Tasks[0].Text != "Pintar" {
+		t.Fatalf("unexpected payload: %s", data)
+	}
+	if len(failures) != 0 {
+		t.Fatalf("expected no per-task post-hooks, got %v", failures)
+	}
+}
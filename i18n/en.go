@@ -0,0 +1,490 @@
+package i18n
+
+var en = map[string]string{
+	// Labels
+	"focus.lists":     "lists",
+	"focus.tasks":     "tasks",
+	"filter.all":      "all",
+	"filter.todo":     "open",
+	"filter.done":     "done",
+	"priority.none":   "none",
+	"priority.low":    "low",
+	"priority.medium": "medium",
+	"priority.high":   "high",
+	"format.datetime": "01/02 15:04",
+	"format.datesecs": "01/02 15:04:05",
+	"format.date":     "01/02/2006",
+
+	// Status bar
+	"status.ready":                   "Ready",
+	"status.welcome":                 "Welcome. Press 'a' in Lists to create your first list.",
+	"status.undo_hint":               " • u undoes",
+	"status.hook_failed":             "Hook failed: %v",
+	"status.focus":                   "Focus on %s",
+	"status.search_active":           "Incremental search: type to filter as you go",
+	"status.help_opened":             "Shortcuts shown (press %s to close)",
+	"status.help_closed":             "Shortcuts hidden",
+	"status.search_cleared":          "Search cleared",
+	"status.search_applied":          "Search applied",
+	"status.cancelled":               "Cancelled",
+	"status.action_cancelled":        "Action cancelled",
+	"status.list_name_empty":         "List name must not be empty",
+	"status.task_text_empty":         "Task text must not be empty",
+	"status.create_list_first":       "Create a list before adding tasks",
+	"status.no_lists":                "No lists. Press 'a' to create the first one",
+	"status.no_list_selected":        "No list selected",
+	"status.no_task_selected":        "No task selected",
+	"status.no_active_list":          "No active list",
+	"status.active_list":             "Active list: %s",
+	"status.list_created":            "List created",
+	"status.list_renamed":            "List renamed",
+	"status.list_deleted":            "List deleted • u undoes",
+	"status.list_color":              "List color changed",
+	"status.list_at_top":             "The list is already at the top",
+	"status.list_at_bottom":          "The list is already at the bottom",
+	"status.lists_reordered":         "List order updated",
+	"status.task_created":            "Task created",
+	"status.task_updated":            "Task updated",
+	"status.task_done":               "Task completed",
+	"status.task_reopened":           "Task reopened",
+	"status.task_deleted":            "Task deleted • u undoes",
+	"status.task_at_top":             "The task is already at the top",
+	"status.task_at_bottom":          "The task is already at the bottom",
+	"status.tasks_reordered":         "Task order updated",
+	"status.dragging":                "Moving “%s” • release to drop it here",
+	"status.priority":                "Priority: %s",
+	"status.task_status":             "Status: %s",
+	"status.board_opened":            "Board view",
+	"status.board_closed":            "List view",
+	"status.columns":                 "Board columns updated",
+	"status.agenda_opened":           "Today: overdue, due today, pinned and high priority tasks of all lists",
+	"status.agenda_closed":           "Back to the list",
+	"status.agenda_no_reorder":       "Reorder tasks in their list, not in Today",
+	"status.agenda_close_to_add":     "Close Today ('t') to add tasks",
+	"status.pinned":                  "Pinned to Today",
+	"status.unpinned":                "Unpinned from Today",
+	"status.due_set":                 "Due: %s",
+	"status.due_cleared":             "Due date cleared",
+	"status.due_invalid":             "Invalid date %q: use YYYY-MM-DD",
+	"status.task_moved_to":           "Task moved to %s",
+	"status.no_other_list":           "There is no other list to move the task to",
+	"status.jumped_task":             "Task in %s",
+	"status.finder_gone":             "That item no longer exists",
+	"status.filter":                  "Filter: %s",
+	"status.undone":                  "Undo applied",
+	"status.nothing_to_undo":         "Nothing to undo",
+	"status.archived_items":          "%d items archived • u undoes",
+	"status.archived_todos":          "%d to-dos archived • u undoes",
+	"status.deleted_todos":           "%d to-dos deleted • u undoes",
+	"status.copied":                  "%d to-dos copied to the clipboard",
+	"status.history_opened":          "Completed history opened",
+	"status.history_closed":          "Back to active tasks",
+	"status.history_empty":           "History is empty. Archive completed tasks with 'C'.",
+	"status.history_read_only":       "History is read-only. Press 'h' to go back.",
+	"status.history_already_open":    "You are already in the history. Press 'h' to go back.",
+	"status.history_close_to_add":    "Close the history ('h') to add tasks",
+	"status.history_close_to_arch":   "Close the history ('h') to archive all to-dos",
+	"status.filter_in_history":       "Filters apply to active tasks. Press 'h' to go back.",
+	"status.filter_needs_list":       "Create a list to use filters",
+	"status.nothing_done_to_archive": "No completed tasks to archive in this list",
+	"status.nothing_to_archive":      "No to-dos to archive in this list",
+	"status.nothing_to_delete":       "No to-dos to delete in this list",
+	"status.nothing_to_copy":         "No active to-dos to copy",
+	"status.nothing_valid_to_copy":   "No valid to-dos to copy",
+	"status.pending_keys":            "%s …",
+
+	// Actions that need another focus
+	"focus.need_lists.rename":  "Rename list: switch focus to Lists (Tab)",
+	"focus.need_lists.columns": "Board columns: switch focus to Lists (Tab)",
+	"focus.need_lists.color":   "List color: switch focus to Lists (Tab)",
+	"focus.need_tasks.edit":    "Edit task: switch focus to Tasks (Tab)",
+	"focus.need_tasks.toggle":  "Complete task: switch focus to Tasks (Tab)",
+	"focus.need_tasks.move":    "Reorder task: switch focus to Tasks (Tab)",
+	"focus.need_tasks.prio":    "Priority: switch focus to Tasks (Tab)",
+	"focus.need_tasks.filter":  "Task filter: switch focus to Tasks (Tab)",
+	"focus.need_tasks.arch":    "Archive completed: switch focus to Tasks (Tab)",
+	"focus.need_tasks.archall": "Archive all: switch focus to Tasks (Tab)",
+	"focus.need_tasks.history": "History: switch focus to Tasks (Tab)",
+	"focus.need_tasks.delall":  "Delete all: switch focus to Tasks (Tab)",
+	"focus.need_tasks.copy":    "Copy to-dos: switch focus to Tasks (Tab)",
+	"focus.need_tasks.status":  "Move card: switch focus to Tasks (Tab)",
+	"focus.need_tasks.pin":     "Pin: switch focus to Tasks (Tab)",
+	"focus.need_tasks.due":     "Due date: switch focus to Tasks (Tab)",
+
+	// Failures (the argument is the already translated error)
+	"error.create_list":  "Could not create list: %v",
+	"error.rename_list":  "Could not rename list: %v",
+	"error.move_list":    "Could not move list: %v",
+	"error.list_color":   "Could not change list color: %v",
+	"error.delete_list":  "Could not delete list: %v",
+	"error.create_task":  "Could not create task: %v",
+	"error.edit_task":    "Could not edit task: %v",
+	"error.toggle_task":  "Could not toggle task: %v",
+	"error.move_task":    "Could not move task: %v",
+	"error.task_status":  "Could not move card: %v",
+	"error.columns":      "Could not change board columns: %v",
+	"error.pin":          "Could not pin task: %v",
+	"error.due":          "Could not set due date: %v",
+	"error.priority":     "Could not set priority: %v",
+	"error.delete_task":  "Could not delete task: %v",
+	"error.delete_all":   "Could not delete to-dos: %v",
+	"error.filter":       "Could not change filter: %v",
+	"error.undo":         "Could not undo: %v",
+	"error.archive":      "Could not archive: %v",
+	"error.copy":         "Could not copy: %v",
+	"error.session":      "Could not update session context: %v",
+	"error.save":         "Change applied, but saving to disk failed: %v",
+	"error.no_clipboard": "no clipboard command available (install wl-copy or xclip)",
+
+	// Header, footer and prompts
+	"view.loading":         "loading...",
+	"view.summary":         "focus: %s • filter: %s",
+	"view.summary_query":   " • search: \"%s\"",
+	"view.summary_history": " • history: on",
+	"view.help_hint":       "%s shortcuts",
+	"view.help_close_hint": "%s close shortcuts",
+	"prompt.add_list":      "New list: ",
+	"prompt.add_task":      "New task: ",
+	"prompt.rename_list":   "Rename list: ",
+	"prompt.edit_task":     "Edit task: ",
+	"prompt.search":        "Search (/): ",
+	"prompt.search_hint":   "  (incremental; Enter confirms, Esc clears)",
+	"prompt.export_md":     "Export Markdown to: ",
+	"prompt.import_md":     "Import Markdown from: ",
+	"prompt.columns":       "Board columns (comma-separated, the last is done): ",
+	"prompt.due":           "Due date (YYYY-MM-DD, empty clears): ",
+	"prompt.command":       ": ",
+	"prompt.find":          "Go to: ",
+	"confirm.item":         "item",
+	"confirm.list":         "list",
+	"confirm.task":         "task",
+	"confirm.all_todos":    "all to-dos",
+	"confirm.delete":       "Delete %s \"%s\"? [y/N]",
+	"confirm.list_count":   "%s (%d tasks)",
+	"confirm.todo_count":   "%s (%d to-dos)",
+	"confirm.restore":      "Restore backup %s? The current state is saved first. [y/N]",
+	"confirm.overwrite":    "%s already exists. Overwrite it? [y/N]",
+	"confirm.archive_all":  "Archive ALL %d to-dos of list \"%s\"? [y/N]",
+	"confirm.archive_done": "Archive %d completed tasks of list \"%s\"? [y/N]",
+
+	// Help
+	"help.title":      "Shortcuts",
+	"help.global":     "Global",
+	"help.lists":      "Lists (with focus on Lists)",
+	"help.tasks":      "Tasks (with focus on Tasks)",
+	"help.onboarding": "First run:\n1) In Lists: '%s' creates a list\n2) %s to Tasks and '%s' to add one\n3) '%s' completes, '%s' archives completed, '%s' opens the history",
+	"hint.input":      "Type text • ←/→ move the cursor • ↑/↓ history • Enter confirm • Esc cancel",
+	"hint.path":       "File path • Enter confirm • Esc cancel",
+	"hint.search":     "Incremental search • Type to filter • Enter confirms • Esc clears",
+	"hint.confirm":    "Confirm action • y confirms • n/Esc cancels",
+	"hint.backups":    "Backups • j/k navigate • r restore • Esc close",
+	"hint.history":    "History",
+	"hint.lists":      "Lists",
+	"hint.tasks":      "Tasks",
+	"hint.board":      "Board",
+	"hint.agenda":     "Today",
+	"hint.commands":   "Commands • type to filter • ↑/↓ choose • Enter run • Esc close",
+	"hint.finder":     "Go to • type to search lists and tasks • ↑/↓ choose • Enter jump • Esc close",
+
+	// Keymap actions (help and footer hints)
+	"keys.space":             "Space",
+	"action.quit":            "quit",
+	"action.focus":           "switch focus",
+	"action.navigate":        "navigate",
+	"action.page":            "page",
+	"action.jump":            "top/bottom",
+	"action.open":            "set active list",
+	"action.add":             "create",
+	"action.rename":          "rename",
+	"action.edit":            "edit",
+	"action.toggle":          "complete/reopen",
+	"action.delete":          "delete",
+	"action.undo":            "undo",
+	"action.filter":          "filter",
+	"action.reorder":         "reorder",
+	"action.priority":        "priority",
+	"action.color":           "color",
+	"action.archive_done":    "archive completed",
+	"action.archive_all":     "archive all",
+	"action.delete_all":      "delete all",
+	"action.copy":            "copy Markdown checklist",
+	"action.history":         "history",
+	"action.close_history":   "back",
+	"action.backups":         "backups (compare and restore)",
+	"action.export_markdown": "export Markdown",
+	"action.import_markdown": "import Markdown",
+	"action.search":          "search",
+	"action.help":            "toggle shortcuts",
+	"action.back":            "close",
+	"action.board":           "board/list view",
+	"action.column":          "column",
+	"action.status":          "move card",
+	"action.columns":         "board columns",
+	"action.agenda":          "Today (all lists)",
+	"action.close_agenda":    "back",
+	"action.pin":             "pin to Today",
+	"action.due":             "due date",
+	"action.command_palette": "command palette",
+	"action.find":            "go to list/task",
+
+	// Panels
+	"panel.lists":              "Lists",
+	"panel.lists_meta":         "%d lists • %d open",
+	"panel.no_lists":           "No lists. Press 'a' to create the first one.",
+	"panel.tasks":              "Tasks",
+	"panel.tasks_of":           "Tasks — %s",
+	"panel.tasks_meta":         "%d open • %d done",
+	"panel.no_active_list":     "No active list. Go to Lists and press 'a'.",
+	"panel.empty_list":         "Empty list. Press 'a' to add a task.",
+	"panel.no_match_query":     "No task matches the current search/filter.",
+	"panel.no_match_filter":    "No task for the current filter (use 'f').",
+	"panel.history":            "Completed history",
+	"panel.history_of":         "Completed history — %s",
+	"panel.history_meta":       "%d items",
+	"panel.scroll":             "%d–%d of %d",
+	"panel.history_empty":      "History is empty. Use 'C' to archive completed tasks of the active list.",
+	"panel.history_empty_list": "No archived items for this list. Use 'C' or 'A' on the active list.",
+	"panel.board_of":           "Board — %s",
+	"panel.agenda":             "Today — all lists",
+	"panel.agenda_meta":        "%d tasks • %d overdue",
+	"panel.agenda_empty":       "Nothing for today. Pin tasks with '%s' or set due dates with '%s'.",
+
+	// Board
+	"board.todo":   "To do",
+	"board.doing":  "Doing",
+	"board.review": "Review",
+	"board.done":   "Done",
+
+	// Command palette (one label per keymap action)
+	"commands.title":          "Commands",
+	"commands.none":           "No command matches",
+	"command.quit":            "Quit",
+	"command.focus":           "Switch focus between lists and tasks",
+	"command.down":            "Move down",
+	"command.up":              "Move up",
+	"command.page-down":       "Page down",
+	"command.page-up":         "Page up",
+	"command.top":             "Go to the first item",
+	"command.bottom":          "Go to the last item",
+	"command.open":            "Set active list",
+	"command.add":             "Create list or task",
+	"command.rename":          "Rename list",
+	"command.edit":            "Edit task",
+	"command.toggle":          "Complete / reopen task",
+	"command.delete":          "Delete list or task",
+	"command.undo":            "Undo",
+	"command.filter":          "Cycle task filter",
+	"command.move-down":       "Move down in the order",
+	"command.move-up":         "Move up in the order",
+	"command.priority-none":   "Priority: none",
+	"command.priority-low":    "Priority: low",
+	"command.priority-medium": "Priority: medium",
+	"command.priority-high":   "Priority: high",
+	"command.color":           "Change list color",
+	"command.archive-done":    "Archive completed tasks",
+	"command.archive-all":     "Archive all to-dos",
+	"command.delete-all":      "Delete all to-dos",
+	"command.copy":            "Copy as Markdown checklist",
+	"command.history":         "Completed history",
+	"command.backups":         "Backups (compare and restore)",
+	"command.export-markdown": "Export Markdown",
+	"command.import-markdown": "Import Markdown",
+	"command.search":          "Search tasks",
+	"command.help":            "Show shortcuts",
+	"command.board":           "Board / list view",
+	"command.column-left":     "Card in the previous column",
+	"command.column-right":    "Card in the next column",
+	"command.status-left":     "Move card to the previous column",
+	"command.status-right":    "Move card to the next column",
+	"command.columns":         "Edit board columns",
+	"command.agenda":          "Today (all lists)",
+	"command.pin":             "Pin / unpin to Today",
+	"command.due":             "Set due date",
+	"command.move-to-list":    "Move task to list…",
+	"command.set-priority":    "Set priority…",
+	"command.set-status":      "Set status…",
+	"command.find":            "Go to list or task",
+	"command.back":            "Close / clear search",
+
+	// Finder
+	"finder.title": "Go to",
+	"finder.count": "%d of %d",
+	"finder.none":  "Nothing matches",
+
+	// Backups
+	"backups.unavailable":     "Backups unavailable: the state is not being saved to disk",
+	"backups.list_failed":     "Could not list backups: %v",
+	"backups.none":            "No backups found yet",
+	"backups.opened":          "%d backups • r restores the selected one",
+	"backups.closed":          "Backups closed",
+	"backups.invalid_restore": "An invalid backup cannot be restored",
+	"backups.cancelled":       "Restore cancelled",
+	"backups.read_failed":     "Could not read backup: %v",
+	"backups.snapshot_failed": "Could not back up the current state: %v",
+	"backups.restored":        "Backup %s restored • u undoes",
+	"backups.title":           "Backups",
+	"backups.task_count":      "%d tasks",
+	"backups.invalid":         "invalid",
+	"backups.on_restore":      "Restoring the selected one",
+	"backups.footer":          "j/k navigate • r restore (the current state is saved first) • Esc close",
+	"backups.no_diff":         "No differences from the current state",
+	"backups.diff_summary":    "lists: +%d −%d ~%d • tasks: +%d −%d ~%d • archive: %d → %d",
+	"backups.diff_list":       "list %s",
+	"backups.diff_more":       "… and %d more",
+	"backups.change_done":     "done",
+	"backups.change_reopened": "reopened",
+	"backups.change_priority": "priority %s",
+	"backups.change_list":     "another list",
+
+	// Markdown
+	"markdown.export_prompt":       "Export Markdown: confirm the file (Enter) or edit the path",
+	"markdown.import_prompt":       "Import Markdown: confirm the file (Enter) or edit the path",
+	"markdown.need_dest":           "Enter the destination file",
+	"markdown.need_source":         "Enter the file to import",
+	"markdown.export_failed":       "Could not export: %v",
+	"markdown.import_failed":       "Could not import: %v",
+	"markdown.exported":            "Markdown exported to %s",
+	"markdown.overwrite_cancelled": "File kept: edit the path or press Esc",
+	"markdown.imported":            "Imported: %d new lists, %d new tasks, %d updated",
+	"markdown.skipped":             " • %d lines skipped",
+
+	// Store
+	"store.recovered":           "Corrupted state recovered from %s",
+	"store.reset":               "Corrupted state with no valid backup; started with an empty state",
+	"store.moved_bad_file":      " (bad file moved to %s)",
+	"store.journal_recovered":   "Corrupted journal: %d records replayed; the rest moved to %s",
+	"store.move_corrupt_failed": "could not move the corrupted file: %v",
+	"store.restore_failed":      "could not restore backup: %v",
+	"store.inspect_failed":      "could not inspect backups: %v",
+	"store.reset_failed":        "could not start a new state after corruption: %v",
+	"store.preserve_failed":     "could not preserve corrupted records: %v",
+	"store.truncate_failed":     "could not cut the corrupted tail from the journal: %v",
+	"store.invalid_backup":      "backup %s is invalid: %v",
+	"store.unsupported_format":  "%v: unsupported format %q/%q",
+	"store.bad_header":          "%v: invalid header",
+	"store.bad_nonce":           "%v: invalid nonce",
+	"store.bad_record":          "%v: invalid format",
+	"store.record_checksum":     "%v: checksum does not match",
+	"store.truncated_record":    "%v: incomplete final record",
+	"store.out_of_order":        "%v: out-of-order sequence (%d after %d)",
+	"store.checksum_mismatch":   "%v: expected %s, computed %s",
+
+	// Hooks
+	"hooks.bad_reply":  "%s: invalid reply: %v",
+	"hooks.timed_out":  "%s: %v (%s)",
+	"hooks.failed":     "%s: %s",
+	"hooks.run_failed": "%s could not run: %v",
+
+	// Command line
+	"cli.error":        "error: %v",
+	"cli.config_error": "error loading config: %v",
+	"cli.load_error":   "error loading state: %v",
+	"cli.hook_failed":  "hook failed: %v",
+	"cli.usage_line":   "usage: todo %s",
+	"cli.usage": `usage: todo [-state file] [command]
+
+without a command, opens the terminal interface.
+
+commands:
+  backups list              lists backups (date, size, tasks)
+  backups restore <id>      restores a backup (the current state is saved first)
+  doctor [--fix]            checks the integrity of the state and the backups
+  export <format> [-o file] exports tasks (%s)
+  import <format> [file]    imports tasks (a single, undoable change)
+  serve [--addr host:port]  exposes lists and tasks through a local REST API (JSON)
+`,
+	"cli.unknown_command":     "unknown command: %s",
+	"cli.flag_state":          "state file path (JSON)",
+	"cli.flag_config":         "config file (default: config.json next to the state)",
+	"cli.passphrase_env":      "encrypted state: set %s",
+	"cli.passphrase_prompt":   "State passphrase: ",
+	"cli.backups_none":        "no backups found for %s",
+	"cli.backups_header":      "ID\tDATE\tSIZE\tTASKS",
+	"cli.backups_invalid":     "invalid",
+	"cli.backups_restored":    "backup %s restored: %d lists, %d tasks",
+	"cli.doctor_flag_fix":     "repairs the problems found",
+	"cli.doctor_checking":     "checking %s",
+	"cli.doctor_load_failed":  "[%s] load: %v",
+	"cli.doctor_recovered":    "recovered: %s",
+	"cli.doctor_bad_backup":   "[%s] backup: %s unreadable: %v",
+	"cli.doctor_summary":      "%d errors, %d warnings",
+	"cli.doctor_fixed":        "%d problems fixed",
+	"cli.doctor_fixed_backup": "%d problems fixed (previous state kept in backup %s)",
+	"cli.export_usage":        "export <format> [-o file] [-list name] [-archive] [-columns a,b] [-since date] [-until date]",
+	"cli.export_flag_out":     "output file (default: stdout)",
+	"cli.export_flag_list":    "exports only this list",
+	"cli.export_flag_archive": "includes archived tasks",
+	"cli.export_flag_columns": "comma-separated columns (tabular formats)",
+	"cli.export_flag_since":   "only entries from this date on (YYYY-MM-DD)",
+	"cli.export_flag_until":   "only entries before this date (YYYY-MM-DD)",
+	"cli.export_bad_date":     "export -%s YYYY-MM-DD (got %q)",
+	"cli.import_usage":        "import <format> [-map header=column,...] [file|-]",
+	"cli.import_flag_map":     "maps headers to columns, e.g. Title=text,Project=list",
+	"cli.import_skipped":      "skipped: %s",
+	"cli.imported":            "imported: %d new lists, %d new tasks, %d updated, %d archived",
+	"cli.serve_usage":         "serve [--addr 127.0.0.1:PORT]",
+	"cli.serve_flag_addr":     "listen address (host:port)",
+	"cli.serve_public":        "warning: the API has no authentication and is reachable from other machines",
+	"cli.serving":             "serving %s at http://%s (Ctrl+C to stop)",
+
+	// Integrity check
+	"validate.error":            "error",
+	"validate.warning":          "warning",
+	"validate.empty_list_id":    "list #%d (%q) has no ID",
+	"validate.dup_list_id":      "list ID %s repeated (%q)",
+	"validate.empty_task_id":    "task #%d (%q) has no ID",
+	"validate.dup_task_id":      "task ID %s repeated (%q)",
+	"validate.orphan_task":      "task %s (%q) points at missing list %q",
+	"validate.task_priority":    "task %s has priority %d",
+	"validate.positions":        "positions of list %s are not the sequence 1..%d",
+	"validate.dup_archive_id":   "archived ID %s repeated (%q)",
+	"validate.archive_priority": "archived %s has priority %d",
+	"validate.session_list":     "session points at missing list %q",
+	"validate.filter":           "unknown filter %q",
+	"validate.focus":            "unknown session focus %q",
+	"validate.orphan_list":      "Recovered",
+
+	// Config
+	"config.invalid_duration": "%v: invalid duration %q",
+	"config.duration_string":  "%v: duration must be a string like \"1h\" or \"30d\"",
+	"config.negative":         "%v: %s must not be negative",
+	"config.positive":         "%v: %s must be positive",
+	"config.persistence_mode": "%v: persistence.mode must be %q or %q",
+	"config.locale":           "%v: locale must be one of %v",
+	"config.tier_for":         "%v: backup.tiers[%d].for must be at least every",
+
+	// Keymap and theme files
+	"keymap.unknown_action": "%v: unknown action %q",
+	"keymap.empty_sequence": "%v: %s: empty key sequence",
+	"keymap.reserved":       "%v: %s: %s is reserved",
+	"keymap.bound_twice":    "%v: %q is bound to both %s and %s",
+	"keymap.shadows":        "%v: %q (%s) shadows %q (%s)",
+	"theme.unknown":         "%v: unknown theme %q (built-in: %s)",
+	"theme.unknown_base":    "%v: unknown base %q",
+	"theme.unknown_style":   "%v: unknown style %q",
+	"theme.invalid_color":   "%v: %s: invalid color %q",
+
+	// Import and export
+	"exchange.skipped":            "line %d: %s (%q)",
+	"exchange.unknown_format":     "%v: %q (available: %s)",
+	"exchange.no_text":            "task without text",
+	"exchange.bad_date":           "invalid date %q",
+	"exchange.bad_date_in":        "invalid date in %s",
+	"exchange.bad_archive_date":   "invalid archive date",
+	"exchange.bad_priority":       "invalid priority %q",
+	"exchange.bad_done":           "invalid done value %q",
+	"exchange.bad_position":       "invalid position %q",
+	"exchange.bad_pinned":         "invalid pinned value %q",
+	"exchange.not_markdown_item":  "line is neither a heading nor a list item",
+	"exchange.bad_org_property":   "invalid property",
+	"exchange.stray_org_text":     "text not understood",
+	"exchange.no_org_keyword":     "heading without TODO/DONE",
+	"exchange.bad_ical_line":      "invalid iCalendar line",
+	"exchange.bad_ical_property":  "invalid %s: %v",
+	"exchange.no_summary":         "VTODO without SUMMARY",
+	"exchange.bad_json":           "invalid JSON",
+	"exchange.no_description":     "task without description",
+	"exchange.deleted_task":       "task deleted in Taskwarrior",
+	"exchange.recurring_template": "recurrence template",
+	"exchange.unknown_status":     "unknown status %q",
+}
@@ -0,0 +1,180 @@
+// Package i18n holds the UI message catalog. The locale is process-wide:
+// it is picked once at startup (config, then LC_ALL/LC_MESSAGES/LANG) and
+// defaults to pt-BR, the language the app was written in.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Locale identifies a message catalog.
+type Locale string
+
+const (
+	PtBR Locale = "pt-BR"
+	En   Locale = "en"
+
+	// Default is used when neither the config nor the environment picks one.
+	Default = PtBR
+)
+
+// ErrUnknownLocale is returned by Parse for languages without a catalog.
+var ErrUnknownLocale = errors.New("unknown locale")
+
+var current atomic.Value // Locale
+
+func init() {
+	current.Store(Default)
+}
+
+// Locales lists the available catalogs.
+func Locales() []Locale {
+	return []Locale{PtBR, En}
+}
+
+// SetLocale switches the catalog used by T, Errorf and Error.
+func SetLocale(l Locale) {
+	current.Store(l)
+}
+
+// Current returns the active locale.
+func Current() Locale {
+	return current.Load().(Locale)
+}
+
+// Parse maps a language tag such as "en", "en_US.UTF-8" or "pt-BR" to a
+// catalog.
+func Parse(tag string) (Locale, error) {
+	lang := strings.ToLower(strings.TrimSpace(tag))
+	lang, _, _ = strings.Cut(lang, ".")
+	lang, _, _ = strings.Cut(lang, "@")
+	switch {
+	case lang == "pt" || strings.HasPrefix(lang, "pt_") || strings.HasPrefix(lang, "pt-"):
+		return PtBR, nil
+	case lang == "en" || strings.HasPrefix(lang, "en_") || strings.HasPrefix(lang, "en-"):
+		return En, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownLocale, tag)
+}
+
+// Detect picks the locale from the configured tag or, when empty, from the
+// environment. Languages without a catalog fall back to English; an unset
+// or "C" environment keeps Default.
+func Detect(configured string) Locale {
+	if l, err := Parse(configured); err == nil {
+		return l
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := strings.TrimSpace(os.Getenv(name))
+		if v == "" {
+			continue
+		}
+		if l, err := Parse(v); err == nil {
+			return l
+		}
+		if v == "C" || v == "POSIX" || strings.HasPrefix(v, "C.") {
+			return Default
+		}
+		return En
+	}
+	return Default
+}
+
+// T returns the message for key in the active locale, formatted with args.
+// Missing entries fall back to Default and then to the key itself.
+func T(key string, args ...any) string {
+	return lookup(Current(), key, args)
+}
+
+// In returns the message for key in locale l, whatever the active one.
+func In(l Locale, key string, args ...any) string {
+	return lookup(l, key, args)
+}
+
+func lookup(l Locale, key string, args []any) string {
+	msg, ok := catalogs[l][key]
+	if !ok {
+		if msg, ok = catalogs[Default][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Message is an error whose text comes from the catalog. Error arguments
+// are unwrapped, so errors.Is still finds sentinels passed as arguments.
+// Its Error method always renders in Default, because wrappers such as
+// fmt.Errorf freeze the text when they are created; use Error(err) to get
+// the active locale.
+type Message struct {
+	Key  string
+	Args []any
+}
+
+// Errorf returns a catalog-backed error; key's format uses %v for errors.
+func Errorf(key string, args ...any) error {
+	return Message{Key: key, Args: args}
+}
+
+func (m Message) Error() string {
+	return m.format(Default, error.Error)
+}
+
+func (m Message) format(l Locale, errText func(error) string) string {
+	args := make([]any, len(m.Args))
+	for i, a := range m.Args {
+		if err, ok := a.(error); ok {
+			a = errText(err)
+		}
+		args[i] = a
+	}
+	return lookup(l, m.Key, args)
+}
+
+func (m Message) Unwrap() []error {
+	var errs []error
+	for _, a := range m.Args {
+		if err, ok := a.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Error renders err in the active locale. Sentinel errors of the app and
+// store packages are plain English errors.New values; their text is looked
+// up in the catalog wherever it appears in the wrap chain, so wrapped
+// details such as "invalid priority: 9" survive translation.
+func Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	if m, ok := err.(Message); ok {
+		return m.format(Current(), Error)
+	}
+	text := err.Error()
+	if msg, ok := errorTexts[Current()][text]; ok {
+		return msg
+	}
+	var children []error
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		children = []error{u.Unwrap()}
+	case interface{ Unwrap() []error }:
+		children = u.Unwrap()
+	}
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		text = strings.Replace(text, child.Error(), Error(child), 1)
+	}
+	return text
+}
@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsHaveSameKeysAndVerbs(t *testing.T) {
+	for _, l := range Locales() {
+		if l == Default {
+			continue
+		}
+		for key, want := range catalogs[Default] {
+			got, ok := catalogs[l][key]
+			if !ok {
+				t.Errorf("%s: missing key %q", l, key)
+				continue
+			}
+			if a, b := verbs(want), verbs(got); fmt.Sprint(a) != fmt.Sprint(b) {
+				t.Errorf("%s: %q has verbs %v, want %v", l, key, b, a)
+			}
+		}
+		for key := range catalogs[l] {
+			if _, ok := catalogs[Default][key]; !ok {
+				t.Errorf("%s: key %q not in %s", l, key, Default)
+			}
+		}
+	}
+}
+
+func verbs(s string) []string {
+	v := verbPattern.FindAllString(s, -1)
+	sort.Strings(v)
+	return v
+}
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		configured, lcAll, lang string
+		want                    Locale
+	}{
+		{"", "", "", Default},
+		{"", "", "C.UTF-8", Default},
+		{"", "", "en_US.UTF-8", En},
+		{"", "", "pt_BR.UTF-8", PtBR},
+		{"", "", "de_DE.UTF-8", En},
+		{"", "pt_PT", "en_US.UTF-8", PtBR},
+		{"en", "", "pt_BR.UTF-8", En},
+	}
+	for _, c := range cases {
+		t.Setenv("LC_ALL", c.lcAll)
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", c.lang)
+		if got := Detect(c.configured); got != c.want {
+			t.Fatalf("Detect(%q) with LC_ALL=%q LANG=%q = %s, want %s", c.configured, c.lcAll, c.lang, got, c.want)
+		}
+	}
+	if _, err := Parse("fr"); !errors.Is(err, ErrUnknownLocale) {
+		t.Fatalf("expected ErrUnknownLocale, got %v", err)
+	}
+}
+
+func TestErrorTranslatesWrappedSentinels(t *testing.T) {
+	defer SetLocale(Current())
+	sentinel := errors.New("invalid priority")
+	veto := errors.New("change vetoed by hook")
+	err := fmt.Errorf("%w: %w", veto, Errorf("store.restore_failed", fmt.Errorf("%w: 9", sentinel)))
+
+	SetLocale(PtBR)
+	if got, want := Error(err), "alteração vetada pelo hook: falha ao restaurar backup: prioridade inválida: 9"; got != want {
+		t.Fatalf("pt-BR: got %q, want %q", got, want)
+	}
+	SetLocale(En)
+	if got, want := Error(err), "change vetoed by hook: could not restore backup: invalid priority: 9"; got != want {
+		t.Fatalf("en: got %q, want %q", got, want)
+	}
+	if !errors.Is(err, sentinel) {
+		t.Fatal("catalog errors must keep the wrap chain")
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Fatalf("missing keys should fall back to the key, got %q", got)
+	}
+}
@@ -0,0 +1,543 @@
+package i18n
+
+var catalogs = map[Locale]map[string]string{
+	PtBR: ptBR,
+	En:   en,
+}
+
+// errorTexts translates the English sentinel errors of app and store.
+var errorTexts = map[Locale]map[string]string{
+	PtBR: ptBRErrors,
+}
+
+var ptBR = map[string]string{
+	// Rótulos
+	"focus.lists":     "listas",
+	"focus.tasks":     "tarefas",
+	"filter.all":      "todas",
+	"filter.todo":     "abertas",
+	"filter.done":     "concluídas",
+	"priority.none":   "nenhuma",
+	"priority.low":    "baixa",
+	"priority.medium": "média",
+	"priority.high":   "alta",
+	"format.datetime": "02/01 15:04",
+	"format.datesecs": "02/01 15:04:05",
+	"format.date":     "02/01/2006",
+
+	// Barra de status
+	"status.ready":                   "Pronto",
+	"status.welcome":                 "Bem-vindo. Pressione 'a' em Listas para criar sua primeira lista.",
+	"status.undo_hint":               " • u desfaz",
+	"status.hook_failed":             "Falha no hook %v",
+	"status.focus":                   "Foco em %s",
+	"status.search_active":           "Busca incremental ativa: digite para filtrar em tempo real",
+	"status.help_opened":             "Atalhos abertos (pressione %s para fechar)",
+	"status.help_closed":             "Atalhos ocultos",
+	"status.search_cleared":          "Busca limpa",
+	"status.search_applied":          "Busca aplicada",
+	"status.cancelled":               "Cancelado",
+	"status.action_cancelled":        "Ação cancelada",
+	"status.list_name_empty":         "Nome da lista não pode ser vazio",
+	"status.task_text_empty":         "Texto da tarefa não pode ser vazio",
+	"status.create_list_first":       "Crie uma lista antes de adicionar tarefas",
+	"status.no_lists":                "Sem listas. Pressione 'a' para criar a primeira",
+	"status.no_list_selected":        "Nenhuma lista selecionada",
+	"status.no_task_selected":        "Nenhuma tarefa selecionada",
+	"status.no_active_list":          "Nenhuma lista ativa",
+	"status.active_list":             "Lista ativa: %s",
+	"status.list_created":            "Lista criada",
+	"status.list_renamed":            "Lista renomeada",
+	"status.list_deleted":            "Lista excluída • u desfaz",
+	"status.list_color":              "Cor da lista alterada",
+	"status.list_at_top":             "A lista já está no topo",
+	"status.list_at_bottom":          "A lista já está no fim",
+	"status.lists_reordered":         "Ordem das listas atualizada",
+	"status.task_created":            "Tarefa criada",
+	"status.task_updated":            "Tarefa atualizada",
+	"status.task_done":               "Tarefa concluída",
+	"status.task_reopened":           "Tarefa reaberta",
+	"status.task_deleted":            "Tarefa excluída • u desfaz",
+	"status.task_at_top":             "A tarefa já está no topo",
+	"status.task_at_bottom":          "A tarefa já está no fim",
+	"status.tasks_reordered":         "Ordem das tarefas atualizada",
+	"status.dragging":                "Movendo “%s” • solte para posicionar aqui",
+	"status.priority":                "Prioridade: %s",
+	"status.task_status":             "Status: %s",
+	"status.board_opened":            "Visão de quadro",
+	"status.board_closed":            "Visão de lista",
+	"status.columns":                 "Colunas do quadro atualizadas",
+	"status.agenda_opened":           "Hoje: tarefas atrasadas, para hoje, fixadas e de prioridade alta de todas as listas",
+	"status.agenda_closed":           "De volta à lista",
+	"status.agenda_no_reorder":       "Reordene as tarefas na lista delas, não em Hoje",
+	"status.agenda_close_to_add":     "Feche Hoje ('t') para adicionar tarefas",
+	"status.pinned":                  "Fixada em Hoje",
+	"status.unpinned":                "Removida de Hoje",
+	"status.due_set":                 "Prazo: %s",
+	"status.due_cleared":             "Prazo removido",
+	"status.due_invalid":             "Data inválida %q: use AAAA-MM-DD",
+	"status.task_moved_to":           "Tarefa movida para %s",
+	"status.no_other_list":           "Não há outra lista para onde mover a tarefa",
+	"status.jumped_task":             "Tarefa em %s",
+	"status.finder_gone":             "Esse item não existe mais",
+	"status.filter":                  "Filtro: %s",
+	"status.undone":                  "Undo aplicado",
+	"status.nothing_to_undo":         "Nada para desfazer",
+	"status.archived_items":          "%d itens arquivados • u desfaz",
+	"status.archived_todos":          "%d to-dos arquivados • u desfaz",
+	"status.deleted_todos":           "%d to-dos deletados • u desfaz",
+	"status.copied":                  "%d to-dos copiados para a área de transferência",
+	"status.history_opened":          "Histórico de concluídas aberto",
+	"status.history_closed":          "Voltando para tarefas ativas",
+	"status.history_empty":           "Histórico vazio. Arquive concluídas com 'C'.",
+	"status.history_read_only":       "Histórico é somente leitura. Pressione 'h' para voltar.",
+	"status.history_already_open":    "Você já está no histórico. Pressione 'h' para voltar.",
+	"status.history_close_to_add":    "Feche o histórico ('h') para adicionar tarefas",
+	"status.history_close_to_arch":   "Feche o histórico ('h') para arquivar todos os to-dos",
+	"status.filter_in_history":       "Filtro vale para tarefas ativas. Pressione 'h' para voltar.",
+	"status.filter_needs_list":       "Crie uma lista para usar filtros",
+	"status.nothing_done_to_archive": "Não há tarefas concluídas para arquivar nesta lista",
+	"status.nothing_to_archive":      "Não há to-dos para arquivar nesta lista",
+	"status.nothing_to_delete":       "Não há to-dos para deletar nesta lista",
+	"status.nothing_to_copy":         "Sem to-dos ativos para copiar",
+	"status.nothing_valid_to_copy":   "Sem to-dos válidos para copiar",
+	"status.pending_keys":            "%s …",
+
+	// Ações que pedem outro foco
+	"focus.need_lists.rename":  "Renomear lista: mude o foco para Listas (Tab)",
+	"focus.need_lists.columns": "Colunas do quadro: mude o foco para Listas (Tab)",
+	"focus.need_lists.color":   "Cor da lista: mude o foco para Listas (Tab)",
+	"focus.need_tasks.edit":    "Editar tarefa: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.toggle":  "Marcar tarefa: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.move":    "Ordenar tarefa: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.prio":    "Prioridade: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.filter":  "Filtro de tarefas: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.arch":    "Arquivar concluídas: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.archall": "Arquivar todos: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.history": "Histórico: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.delall":  "Deletar todos: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.copy":    "Copiar to-dos: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.status":  "Mover cartão: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.pin":     "Fixar: mude o foco para Tarefas (Tab)",
+	"focus.need_tasks.due":     "Prazo: mude o foco para Tarefas (Tab)",
+
+	// Falhas (o argumento é o erro já traduzido)
+	"error.create_list":  "Erro ao criar lista: %v",
+	"error.rename_list":  "Erro ao renomear lista: %v",
+	"error.move_list":    "Erro ao mover lista: %v",
+	"error.list_color":   "Erro ao trocar cor da lista: %v",
+	"error.delete_list":  "Erro ao excluir lista: %v",
+	"error.create_task":  "Erro ao criar tarefa: %v",
+	"error.edit_task":    "Erro ao editar tarefa: %v",
+	"error.toggle_task":  "Erro ao alternar tarefa: %v",
+	"error.move_task":    "Erro ao mover tarefa: %v",
+	"error.task_status":  "Erro ao mover cartão: %v",
+	"error.columns":      "Erro ao alterar colunas do quadro: %v",
+	"error.pin":          "Erro ao fixar tarefa: %v",
+	"error.due":          "Erro ao definir prazo: %v",
+	"error.priority":     "Erro ao ajustar prioridade: %v",
+	"error.delete_task":  "Erro ao excluir tarefa: %v",
+	"error.delete_all":   "Erro ao deletar to-dos: %v",
+	"error.filter":       "Erro ao alterar filtro: %v",
+	"error.undo":         "Erro ao desfazer: %v",
+	"error.archive":      "Erro ao arquivar: %v",
+	"error.copy":         "Falha ao copiar: %v",
+	"error.session":      "Falha ao atualizar contexto da sessão: %v",
+	"error.save":         "Alteração aplicada, mas falhou ao salvar em disco: %v",
+	"error.no_clipboard": "nenhum comando de clipboard disponível (instale wl-copy ou xclip)",
+
+	// Cabeçalho, rodapé e prompts
+	"view.loading":         "carregando...",
+	"view.summary":         "foco: %s • filtro: %s",
+	"view.summary_query":   " • busca: \"%s\"",
+	"view.summary_history": " • histórico: ligado",
+	"view.help_hint":       "%s atalhos",
+	"view.help_close_hint": "%s fechar atalhos",
+	"prompt.add_list":      "Nova lista: ",
+	"prompt.add_task":      "Nova tarefa: ",
+	"prompt.rename_list":   "Renomear lista: ",
+	"prompt.edit_task":     "Editar tarefa: ",
+	"prompt.search":        "Busca (/): ",
+	"prompt.search_hint":   "  (incremental; Enter confirma, Esc limpa)",
+	"prompt.export_md":     "Exportar Markdown para: ",
+	"prompt.import_md":     "Importar Markdown de: ",
+	"prompt.columns":       "Colunas do quadro (separadas por vírgula, a última é concluída): ",
+	"prompt.due":           "Prazo (AAAA-MM-DD, vazio remove): ",
+	"prompt.command":       ": ",
+	"prompt.find":          "Ir para: ",
+	"confirm.item":         "item",
+	"confirm.list":         "lista",
+	"confirm.task":         "tarefa",
+	"confirm.all_todos":    "todos os to-dos",
+	"confirm.delete":       "Excluir %s \"%s\"? [y/N]",
+	"confirm.list_count":   "%s (%d tarefas)",
+	"confirm.todo_count":   "%s (%d to-dos)",
+	"confirm.restore":      "Restaurar backup %s? O estado atual será salvo antes. [y/N]",
+	"confirm.overwrite":    "%s já existe. Sobrescrever? [y/N]",
+	"confirm.archive_all":  "Arquivar TODOS os %d to-dos da lista \"%s\"? [y/N]",
+	"confirm.archive_done": "Arquivar %d concluídas da lista \"%s\"? [y/N]",
+
+	// Ajuda
+	"help.title":      "Atalhos",
+	"help.global":     "Globais",
+	"help.lists":      "Listas (com foco em Listas)",
+	"help.tasks":      "Tarefas (com foco em Tarefas)",
+	"help.onboarding": "Primeiro uso:\n1) Em Listas: '%s' para criar lista\n2) %s para Tarefas e '%s' para adicionar\n3) '%s' conclui, '%s' arquiva concluídas, '%s' abre histórico",
+	"hint.input":      "Digite texto • ←/→ move o cursor • ↑/↓ histórico • Enter confirmar • Esc cancelar",
+	"hint.path":       "Caminho do arquivo • Enter confirmar • Esc cancelar",
+	"hint.search":     "Busca incremental • Digite para filtrar • Enter confirma • Esc limpa",
+	"hint.confirm":    "Confirmar ação • y confirma • n/Esc cancela",
+	"hint.backups":    "Backups • j/k navegar • r restaurar • Esc fechar",
+	"hint.history":    "Histórico",
+	"hint.lists":      "Listas",
+	"hint.tasks":      "Tarefas",
+	"hint.board":      "Quadro",
+	"hint.agenda":     "Hoje",
+	"hint.commands":   "Comandos • digite para filtrar • ↑/↓ escolhe • Enter executa • Esc fecha",
+	"hint.finder":     "Ir para • digite para buscar listas e tarefas • ↑/↓ escolhe • Enter vai • Esc fecha",
+
+	// Ações do mapa de teclas (ajuda e dicas do rodapé)
+	"keys.space":             "Espaço",
+	"action.quit":            "sai",
+	"action.focus":           "alterna foco",
+	"action.navigate":        "navega",
+	"action.page":            "página",
+	"action.jump":            "início/fim",
+	"action.open":            "define lista ativa",
+	"action.add":             "cria",
+	"action.rename":          "renomeia",
+	"action.edit":            "edita",
+	"action.toggle":          "conclui/reabre",
+	"action.delete":          "exclui",
+	"action.undo":            "desfaz",
+	"action.filter":          "filtro",
+	"action.reorder":         "reordena",
+	"action.priority":        "prioridade",
+	"action.color":           "cor",
+	"action.archive_done":    "arquiva concluídas",
+	"action.archive_all":     "arquiva todos",
+	"action.delete_all":      "deleta todos",
+	"action.copy":            "copia checklist Markdown",
+	"action.history":         "histórico",
+	"action.close_history":   "volta",
+	"action.backups":         "backups (comparar e restaurar)",
+	"action.export_markdown": "exporta Markdown",
+	"action.import_markdown": "importa Markdown",
+	"action.search":          "busca",
+	"action.help":            "abre/fecha atalhos",
+	"action.back":            "fecha",
+	"action.board":           "quadro/lista",
+	"action.column":          "coluna",
+	"action.status":          "move cartão",
+	"action.columns":         "colunas do quadro",
+	"action.agenda":          "Hoje (todas as listas)",
+	"action.close_agenda":    "volta",
+	"action.pin":             "fixa em Hoje",
+	"action.due":             "prazo",
+	"action.command_palette": "paleta de comandos",
+	"action.find":            "ir para lista/tarefa",
+
+	// Painéis
+	"panel.lists":              "Listas",
+	"panel.lists_meta":         "%d listas • %d abertas",
+	"panel.no_lists":           "Sem listas. Pressione 'a' para criar a primeira.",
+	"panel.tasks":              "Tarefas",
+	"panel.tasks_of":           "Tarefas — %s",
+	"panel.tasks_meta":         "%d abertas • %d concluídas",
+	"panel.no_active_list":     "Sem lista ativa. Vá em Listas e pressione 'a'.",
+	"panel.empty_list":         "Lista vazia. Pressione 'a' para adicionar tarefa.",
+	"panel.no_match_query":     "Nenhuma tarefa corresponde à busca/filtro atual.",
+	"panel.no_match_filter":    "Nenhuma tarefa para o filtro atual (use 'f').",
+	"panel.history":            "Histórico de concluídas",
+	"panel.history_of":         "Histórico de concluídas — %s",
+	"panel.history_meta":       "%d itens",
+	"panel.scroll":             "%d–%d de %d",
+	"panel.history_empty":      "Histórico vazio. Use 'C' para arquivar concluídas da lista ativa.",
+	"panel.history_empty_list": "Sem itens arquivados para esta lista. Use 'C' ou 'A' na lista ativa.",
+	"panel.board_of":           "Quadro — %s",
+	"panel.agenda":             "Hoje — todas as listas",
+	"panel.agenda_meta":        "%d tarefas • %d atrasadas",
+	"panel.agenda_empty":       "Nada para hoje. Fixe tarefas com '%s' ou defina prazos com '%s'.",
+
+	// Board
+	"board.todo":   "A fazer",
+	"board.doing":  "Fazendo",
+	"board.review": "Revisão",
+	"board.done":   "Feito",
+
+	// Paleta de comandos (um rótulo por ação do keymap)
+	"commands.title":          "Comandos",
+	"commands.none":           "Nenhum comando corresponde",
+	"command.quit":            "Sair",
+	"command.focus":           "Alternar foco entre listas e tarefas",
+	"command.down":            "Descer",
+	"command.up":              "Subir",
+	"command.page-down":       "Página abaixo",
+	"command.page-up":         "Página acima",
+	"command.top":             "Ir para o primeiro item",
+	"command.bottom":          "Ir para o último item",
+	"command.open":            "Definir lista ativa",
+	"command.add":             "Criar lista ou tarefa",
+	"command.rename":          "Renomear lista",
+	"command.edit":            "Editar tarefa",
+	"command.toggle":          "Concluir / reabrir tarefa",
+	"command.delete":          "Deletar lista ou tarefa",
+	"command.undo":            "Desfazer",
+	"command.filter":          "Alternar filtro de tarefas",
+	"command.move-down":       "Descer na ordem",
+	"command.move-up":         "Subir na ordem",
+	"command.priority-none":   "Prioridade: nenhuma",
+	"command.priority-low":    "Prioridade: baixa",
+	"command.priority-medium": "Prioridade: média",
+	"command.priority-high":   "Prioridade: alta",
+	"command.color":           "Mudar cor da lista",
+	"command.archive-done":    "Arquivar concluídas",
+	"command.archive-all":     "Arquivar todos os to-dos",
+	"command.delete-all":      "Deletar todos os to-dos",
+	"command.copy":            "Copiar como checklist Markdown",
+	"command.history":         "Histórico de concluídas",
+	"command.backups":         "Backups (comparar e restaurar)",
+	"command.export-markdown": "Exportar Markdown",
+	"command.import-markdown": "Importar Markdown",
+	"command.search":          "Buscar tarefas",
+	"command.help":            "Mostrar atalhos",
+	"command.board":           "Quadro / lista",
+	"command.column-left":     "Cartão na coluna anterior",
+	"command.column-right":    "Cartão na coluna seguinte",
+	"command.status-left":     "Mover cartão para a coluna anterior",
+	"command.status-right":    "Mover cartão para a coluna seguinte",
+	"command.columns":         "Editar colunas do quadro",
+	"command.agenda":          "Hoje (todas as listas)",
+	"command.pin":             "Fixar / soltar de Hoje",
+	"command.due":             "Definir prazo",
+	"command.move-to-list":    "Mover tarefa para a lista…",
+	"command.set-priority":    "Definir prioridade…",
+	"command.set-status":      "Definir status…",
+	"command.find":            "Ir para lista ou tarefa",
+	"command.back":            "Fechar / limpar busca",
+
+	// Busca rápida
+	"finder.title": "Ir para",
+	"finder.count": "%d de %d",
+	"finder.none":  "Nada corresponde",
+
+	// Backups
+	"backups.unavailable":     "Backups indisponíveis: estado não está sendo salvo em disco",
+	"backups.list_failed":     "Erro ao listar backups: %v",
+	"backups.none":            "Nenhum backup encontrado ainda",
+	"backups.opened":          "%d backups • r restaura o selecionado",
+	"backups.closed":          "Backups fechados",
+	"backups.invalid_restore": "Backup inválido não pode ser restaurado",
+	"backups.cancelled":       "Restauração cancelada",
+	"backups.read_failed":     "Erro ao ler backup: %v",
+	"backups.snapshot_failed": "Erro ao fazer backup do estado atual: %v",
+	"backups.restored":        "Backup %s restaurado • u desfaz",
+	"backups.title":           "Backups",
+	"backups.task_count":      "%d tarefas",
+	"backups.invalid":         "inválido",
+	"backups.on_restore":      "Ao restaurar o selecionado",
+	"backups.footer":          "j/k navega • r restaura (estado atual é salvo antes) • Esc fecha",
+	"backups.no_diff":         "Sem diferenças em relação ao estado atual",
+	"backups.diff_summary":    "listas: +%d −%d ~%d • tarefas: +%d −%d ~%d • arquivo: %d → %d",
+	"backups.diff_list":       "lista %s",
+	"backups.diff_more":       "… e mais %d",
+	"backups.change_done":     "concluída",
+	"backups.change_reopened": "reaberta",
+	"backups.change_priority": "prioridade %s",
+	"backups.change_list":     "outra lista",
+
+	// Markdown
+	"markdown.export_prompt":       "Exportar Markdown: confirme o arquivo (Enter) ou edite o caminho",
+	"markdown.import_prompt":       "Importar Markdown: confirme o arquivo (Enter) ou edite o caminho",
+	"markdown.need_dest":           "Informe o arquivo de destino",
+	"markdown.need_source":         "Informe o arquivo a importar",
+	"markdown.export_failed":       "Erro ao exportar: %v",
+	"markdown.import_failed":       "Erro ao importar: %v",
+	"markdown.exported":            "Markdown exportado para %s",
+	"markdown.overwrite_cancelled": "Arquivo mantido: edite o caminho ou tecle Esc",
+	"markdown.imported":            "Importado: %d listas novas, %d tarefas novas, %d atualizadas",
+	"markdown.skipped":             " • %d linhas ignoradas",
+
+	// Store
+	"store.recovered":           "Estado corrompido recuperado de %s",
+	"store.reset":               "Estado corrompido sem backup válido; iniciado com estado vazio",
+	"store.moved_bad_file":      " (arquivo ruim movido para %s)",
+	"store.journal_recovered":   "Journal corrompido: %d registros reaplicados; restante movido para %s",
+	"store.move_corrupt_failed": "falha ao mover arquivo corrompido: %v",
+	"store.restore_failed":      "falha ao restaurar backup: %v",
+	"store.inspect_failed":      "falha ao inspecionar backups: %v",
+	"store.reset_failed":        "falha ao inicializar novo estado após corrupção: %v",
+	"store.preserve_failed":     "falha ao preservar registros corrompidos: %v",
+	"store.truncate_failed":     "falha ao cortar a cauda corrompida do journal: %v",
+	"store.invalid_backup":      "backup %s inválido: %v",
+	"store.unsupported_format":  "%v: formato %q/%q não suportado",
+	"store.bad_header":          "%v: cabeçalho inválido",
+	"store.bad_nonce":           "%v: nonce inválido",
+	"store.bad_record":          "%v: formato inválido",
+	"store.record_checksum":     "%v: checksum não confere",
+	"store.truncated_record":    "%v: registro final incompleto",
+	"store.out_of_order":        "%v: sequência fora de ordem (%d após %d)",
+	"store.checksum_mismatch":   "%v: esperado %s, calculado %s",
+
+	// Hooks
+	"hooks.bad_reply":  "%s: resposta inválida: %v",
+	"hooks.timed_out":  "%s: %v (%s)",
+	"hooks.failed":     "%s: %s",
+	"hooks.run_failed": "%s não pôde rodar: %v",
+
+	// Linha de comando
+	"cli.error":        "erro: %v",
+	"cli.config_error": "erro ao carregar configuração: %v",
+	"cli.load_error":   "erro ao carregar estado: %v",
+	"cli.hook_failed":  "falha no hook %v",
+	"cli.usage_line":   "uso: todo %s",
+	"cli.usage": `uso: todo [-state arquivo] [comando]
+
+sem comando, abre a interface de terminal.
+
+comandos:
+  backups list              lista backups (data, tamanho, tarefas)
+  backups restore <id>      restaura um backup (o estado atual é salvo antes)
+  doctor [--fix]            verifica a integridade do estado e dos backups
+  export <formato> [-o arq] exporta tarefas (%s)
+  import <formato> [arq]    importa tarefas (uma única alteração, desfazível)
+  serve [--addr host:porta] expõe listas e tarefas numa API REST local (JSON)
+`,
+	"cli.unknown_command":     "comando desconhecido: %s",
+	"cli.flag_state":          "caminho do arquivo de estado (JSON)",
+	"cli.flag_config":         "arquivo de configuração (padrão: config.json ao lado do estado)",
+	"cli.passphrase_env":      "estado criptografado: defina %s",
+	"cli.passphrase_prompt":   "Senha do estado: ",
+	"cli.backups_none":        "nenhum backup encontrado para %s",
+	"cli.backups_header":      "ID\tDATA\tTAMANHO\tTAREFAS",
+	"cli.backups_invalid":     "inválido",
+	"cli.backups_restored":    "backup %s restaurado: %d listas, %d tarefas",
+	"cli.doctor_flag_fix":     "corrige os problemas encontrados",
+	"cli.doctor_checking":     "verificando %s",
+	"cli.doctor_load_failed":  "[%s] carregamento: %v",
+	"cli.doctor_recovered":    "recuperado: %s",
+	"cli.doctor_bad_backup":   "[%s] backup: %s ilegível: %v",
+	"cli.doctor_summary":      "%d erros, %d avisos",
+	"cli.doctor_fixed":        "%d problemas corrigidos",
+	"cli.doctor_fixed_backup": "%d problemas corrigidos (estado anterior guardado no backup %s)",
+	"cli.export_usage":        "export <formato> [-o arquivo] [-list nome] [-archive] [-columns a,b] [-since data] [-until data]",
+	"cli.export_flag_out":     "arquivo de saída (padrão: stdout)",
+	"cli.export_flag_list":    "exporta só esta lista",
+	"cli.export_flag_archive": "inclui tarefas arquivadas",
+	"cli.export_flag_columns": "colunas separadas por vírgula (formatos tabulares)",
+	"cli.export_flag_since":   "só entradas a partir desta data (AAAA-MM-DD)",
+	"cli.export_flag_until":   "só entradas antes desta data (AAAA-MM-DD)",
+	"cli.export_bad_date":     "export -%s AAAA-MM-DD (recebido %q)",
+	"cli.import_usage":        "import <formato> [-map cabeçalho=coluna,...] [arquivo|-]",
+	"cli.import_flag_map":     "mapeia cabeçalhos para colunas, ex.: Título=text,Projeto=list",
+	"cli.import_skipped":      "ignorada: %s",
+	"cli.imported":            "importado: %d listas novas, %d tarefas novas, %d atualizadas, %d arquivadas",
+	"cli.serve_usage":         "serve [--addr 127.0.0.1:PORTA]",
+	"cli.serve_flag_addr":     "endereço de escuta (host:porta)",
+	"cli.serve_public":        "atenção: a API não tem autenticação e está acessível fora desta máquina",
+	"cli.serving":             "servindo %s em http://%s (Ctrl+C para parar)",
+
+	// Verificação de integridade
+	"validate.error":            "erro",
+	"validate.warning":          "aviso",
+	"validate.empty_list_id":    "lista #%d (%q) sem ID",
+	"validate.dup_list_id":      "ID de lista %s repetido (%q)",
+	"validate.empty_task_id":    "tarefa #%d (%q) sem ID",
+	"validate.dup_task_id":      "ID de tarefa %s repetido (%q)",
+	"validate.orphan_task":      "tarefa %s (%q) aponta para lista inexistente %q",
+	"validate.task_priority":    "tarefa %s com prioridade %d",
+	"validate.positions":        "posições da lista %s não formam a sequência 1..%d",
+	"validate.dup_archive_id":   "ID de arquivada %s repetido (%q)",
+	"validate.archive_priority": "arquivada %s com prioridade %d",
+	"validate.session_list":     "sessão aponta para lista inexistente %q",
+	"validate.filter":           "filtro desconhecido %q",
+	"validate.focus":            "foco de sessão desconhecido %q",
+	"validate.orphan_list":      "Recuperadas",
+
+	// Configuração
+	"config.invalid_duration": "%v: duração inválida %q",
+	"config.duration_string":  "%v: a duração deve ser um texto como \"1h\" ou \"30d\"",
+	"config.negative":         "%v: %s não pode ser negativo",
+	"config.positive":         "%v: %s deve ser positivo",
+	"config.persistence_mode": "%v: persistence.mode deve ser %q ou %q",
+	"config.locale":           "%v: locale deve ser um de %v",
+	"config.tier_for":         "%v: backup.tiers[%d].for deve ser pelo menos every",
+
+	// Arquivos de keymap e tema
+	"keymap.unknown_action": "%v: ação desconhecida %q",
+	"keymap.empty_sequence": "%v: %s: sequência de teclas vazia",
+	"keymap.reserved":       "%v: %s: %s é reservada",
+	"keymap.bound_twice":    "%v: %q está ligada a %s e a %s",
+	"keymap.shadows":        "%v: %q (%s) encobre %q (%s)",
+	"theme.unknown":         "%v: tema desconhecido %q (embutidos: %s)",
+	"theme.unknown_base":    "%v: base desconhecida %q",
+	"theme.unknown_style":   "%v: estilo desconhecido %q",
+	"theme.invalid_color":   "%v: %s: cor inválida %q",
+
+	// Importação e exportação
+	"exchange.skipped":            "linha %d: %s (%q)",
+	"exchange.unknown_format":     "%v: %q (disponíveis: %s)",
+	"exchange.no_text":            "tarefa sem texto",
+	"exchange.bad_date":           "data inválida %q",
+	"exchange.bad_date_in":        "data inválida em %s",
+	"exchange.bad_archive_date":   "data de arquivamento inválida",
+	"exchange.bad_priority":       "prioridade inválida %q",
+	"exchange.bad_done":           "valor de concluída inválido %q",
+	"exchange.bad_position":       "posição inválida %q",
+	"exchange.bad_pinned":         "valor de fixada inválido %q",
+	"exchange.not_markdown_item":  "linha não é título nem item de lista",
+	"exchange.bad_org_property":   "propriedade inválida",
+	"exchange.stray_org_text":     "texto não interpretado",
+	"exchange.no_org_keyword":     "título sem TODO/DONE",
+	"exchange.bad_ical_line":      "linha iCalendar inválida",
+	"exchange.bad_ical_property":  "%s inválido: %v",
+	"exchange.no_summary":         "VTODO sem SUMMARY",
+	"exchange.bad_json":           "JSON inválido",
+	"exchange.no_description":     "tarefa sem descrição",
+	"exchange.deleted_task":       "tarefa apagada no Taskwarrior",
+	"exchange.recurring_template": "modelo de recorrência",
+	"exchange.unknown_status":     "status desconhecido %q",
+}
+
+var ptBRErrors = map[string]string{
+	// app
+	"list not found":                         "lista não encontrada",
+	"task not found":                         "tarefa não encontrada",
+	"name must not be empty":                 "o nome não pode ser vazio",
+	"task text must not be empty":            "o texto da tarefa não pode ser vazio",
+	"invalid filter":                         "filtro inválido",
+	"invalid priority":                       "prioridade inválida",
+	"invalid status":                         "status inválido",
+	"duplicate status":                       "status repetido",
+	"a board needs at least two statuses":    "o quadro precisa de pelo menos duas colunas",
+	"nothing to undo":                        "nada para desfazer",
+	"list id must not be empty":              "o ID da lista não pode ser vazio",
+	"task is already at top":                 "a tarefa já está no topo",
+	"task is already at bottom":              "a tarefa já está no fim",
+	"list is already at top":                 "a lista já está no topo",
+	"list is already at bottom":              "a lista já está no fim",
+	"no completed tasks to clear":            "não há tarefas concluídas para arquivar",
+	"no tasks in list":                       "não há tarefas na lista",
+	"invalid session focus":                  "foco de sessão inválido",
+	"hook timed out":                         "tempo limite do hook excedido",
+	"nothing to import":                      "nada para importar",
+	"change vetoed by hook":                  "alteração vetada pelo hook",
+	"state changed while the hook ran":       "o estado mudou enquanto o hook rodava",
+	"backup not found":                       "backup não encontrado",
+	"backup id matches more than one backup": "o ID corresponde a mais de um backup",
+	// store
+	"state is encrypted: passphrase required": "estado criptografado: senha obrigatória",
+	"wrong passphrase":                        "senha incorreta",
+	"encrypted state is corrupted":            "estado criptografado corrompido",
+	"corrupted journal record":                "registro do journal corrompido",
+	"no valid backup found":                   "nenhum backup válido encontrado",
+	"state checksum mismatch":                 "checksum do estado não confere",
+	// config e exchange
+	"invalid config": "configuração inválida",
+	"invalid keymap": "keymap inválido",
+	"invalid theme":  "tema inválido",
+	"unknown format": "formato desconhecido",
+	"unknown column": "coluna desconhecida",
+	// cmd/todo
+	"issues found; run `todo doctor --fix` to repair them": "problemas encontrados; rode `todo doctor --fix` para corrigir",
+}
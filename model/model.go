@@ -22,25 +22,71 @@ const (
 	PriorityHigh   Priority = 3
 )
 
+// Default task statuses, the board columns of lists without their own.
+const (
+	StatusTodo   = "todo"
+	StatusDoing  = "doing"
+	StatusReview = "review"
+	StatusDone   = "done"
+)
+
+// DefaultStatuses returns the board columns used when a list sets none.
+func DefaultStatuses() []string {
+	return []string{StatusTodo, StatusDoing, StatusReview, StatusDone}
+}
+
 // List is a task container/category.
 type List struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Color     string    `json:"color"`
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+	// Statuses are the board columns in order; the last one means done.
+	// Empty means DefaultStatuses.
+	Statuses  []string  `json:"statuses,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// BoardStatuses returns the list's statuses, or the defaults.
+func (l List) BoardStatuses() []string {
+	if len(l.Statuses) == 0 {
+		return DefaultStatuses()
+	}
+	return l.Statuses
+}
+
 // Task is an individual todo item.
 type Task struct {
-	ID        string    `json:"id"`
-	ListID    string    `json:"listId"`
-	Text      string    `json:"text"`
-	Done      bool      `json:"done"`
-	Priority  Priority  `json:"priority,omitempty"`
+	ID     string `json:"id"`
+	ListID string `json:"listId"`
+	Text   string `json:"text"`
+	Done   bool   `json:"done"`
+	// Status is the board column. Done is kept in step with it: a task is
+	// done exactly when its status is the last of its list's statuses.
+	Status   string   `json:"status,omitempty"`
+	Priority Priority `json:"priority,omitempty"`
+	// Pinned puts the task in the "today" agenda until it is unpinned.
+	Pinned    bool      `json:"pinned,omitempty"`
 	Position  int       `json:"position,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+	DoneAt    time.Time `json:"doneAt,omitzero"`
+	// Extra keeps fields from other tools that todo-cli does not model
+	// (todo.txt extensions, calendar UIDs, ...), so they survive a round trip.
+	Extra map[string]string `json:"extra,omitempty"`
+}
+
+// ExtraDue is the Extra key of a task's due date, written as DateLayout. The
+// todo.txt, iCalendar, Org and Taskwarrior formats read and write it.
+const ExtraDue = "due"
+
+// DateLayout is the layout of dates stored in Extra.
+const DateLayout = "2006-01-02"
+
+// Due returns the task's due date, if it has a valid one.
+func (t Task) Due() (time.Time, bool) {
+	due, err := time.Parse(DateLayout, t.Extra[ExtraDue])
+	return due, err == nil
 }
 
 // ArchivedCompletedTask keeps a historic record of completed items moved out of active list view.
@@ -70,6 +116,9 @@ type Metadata struct {
 	Version  int            `json:"version"`
 	FirstRun bool           `json:"firstRun,omitempty"`
 	Session  SessionContext `json:"session,omitempty"`
+	// Checksum is written by the store and verified on load; it is always
+	// empty in memory.
+	Checksum string `json:"checksum,omitempty"`
 }
 
 // AppState is the full persisted state.
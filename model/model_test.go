@@ -15,6 +15,7 @@ func TestAppStateSerializationRoundTrip(t *testing.T) {
 				ID:        "l1",
 				Name:      "Inbox",
 				Color:     "blue",
+				Statuses:  []string{"todo", "doing", "done"},
 				CreatedAt: now,
 				UpdatedAt: now,
 			},
@@ -25,6 +26,7 @@ func TestAppStateSerializationRoundTrip(t *testing.T) {
 				ListID:    "l1",
 				Text:      "write tests",
 				Done:      true,
+				Status:    "done",
 				Priority:  PriorityHigh,
 				Position:  3,
 				CreatedAt: now,
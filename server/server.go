@@ -0,0 +1,419 @@
+// Package server exposes an app.Service as a local JSON REST API, for
+// editor plugins and small dashboards talking to a running todo-cli.
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+// ErrPreconditionFailed is returned when If-Match does not match the
+// resource's current ETag.
+var ErrPreconditionFailed = errors.New("resource changed since it was read")
+
+// maxBodyBytes caps request bodies; the API only receives small JSON objects.
+const maxBodyBytes = 1 << 20
+
+// Saver persists the state after each mutation; *store.Store satisfies it.
+type Saver interface {
+	Autosave(state model.AppState) error
+}
+
+// Server serves one app.Service. Requests are serialized so that the ETag
+// check and the mutation it guards happen atomically.
+type Server struct {
+	mu    sync.Mutex
+	svc   *app.Service
+	saver Saver
+	mux   *http.ServeMux
+}
+
+// New creates a server over svc. saver may be nil, in which case changes
+// only live in memory.
+func New(svc *app.Service, saver Saver) *Server {
+	s := &Server{svc: svc, saver: saver, mux: http.NewServeMux()}
+	s.routes()
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() {
+	s.handle("GET /lists", s.listLists)
+	s.handle("POST /lists", s.createList)
+	s.handle("GET /lists/{id}", s.getList)
+	s.handle("PATCH /lists/{id}", s.updateList)
+	s.handle("DELETE /lists/{id}", s.deleteList)
+	s.handle("GET /lists/{id}/tasks", s.listTasksOf)
+	s.handle("POST /lists/{id}/tasks", s.createTask)
+	s.handle("POST /lists/{id}/archive", s.archiveList)
+	s.handle("GET /tasks", s.listTasks)
+	s.handle("GET /tasks/{id}", s.getTask)
+	s.handle("PATCH /tasks/{id}", s.updateTask)
+	s.handle("DELETE /tasks/{id}", s.deleteTask)
+	s.handle("GET /archive", s.listArchive)
+	s.handle("POST /undo", s.undo)
+}
+
+// handlerFunc returns the status and body to encode, or an error mapped by
+// statusFor. A nil body with status 204 writes nothing.
+type handlerFunc func(r *http.Request) (status int, body any, err error)
+
+func (s *Server) handle(pattern string, fn handlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		status, body, err := fn(r)
+		s.mu.Unlock()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if status == http.StatusNoContent {
+			w.WriteHeader(status)
+			return
+		}
+		tag := etag(body)
+		w.Header().Set("ETag", tag)
+		if r.Method == http.MethodGet && matchesETag(r.Header.Get("If-None-Match"), tag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		writeJSON(w, status, body)
+	})
+}
+
+// mutate runs fn and persists the resulting state.
+func (s *Server) mutate(fn func() error) error {
+	if err := fn(); err != nil {
+		return err
+	}
+	if s.saver == nil {
+		return nil
+	}
+	if err := s.saver.Autosave(s.svc.State()); err != nil {
+		return fmt.Errorf("persist: %w", err)
+	}
+	return nil
+}
+
+// checkIfMatch compares If-Match with the ETag of the resource as it is now.
+func checkIfMatch(r *http.Request, current any) error {
+	header := r.Header.Get("If-Match")
+	if header == "" || matchesETag(header, etag(current)) {
+		return nil
+	}
+	return ErrPreconditionFailed
+}
+
+func (s *Server) listLists(r *http.Request) (int, any, error) {
+	return http.StatusOK, s.svc.Lists(), nil
+}
+
+type listInput struct {
+	Name     *string   `json:"name"`
+	Color    *string   `json:"color"`
+	Statuses *[]string `json:"statuses"`
+}
+
+func (s *Server) createList(r *http.Request) (int, any, error) {
+	var in listInput
+	if err := decode(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if in.Statuses != nil {
+		if err := app.CheckStatuses(*in.Statuses); err != nil {
+			return 0, nil, err
+		}
+	}
+	var list model.List
+	err := s.mutate(func() (err error) {
+		if list, err = s.svc.CreateList(deref(in.Name), deref(in.Color)); err != nil || in.Statuses == nil {
+			return err
+		}
+		list, err = s.svc.SetListStatuses(list.ID, *in.Statuses)
+		return err
+	})
+	return http.StatusCreated, list, err
+}
+
+func (s *Server) getList(r *http.Request) (int, any, error) {
+	list, err := s.svc.GetList(r.PathValue("id"))
+	return http.StatusOK, list, err
+}
+
+func (s *Server) updateList(r *http.Request) (int, any, error) {
+	current, err := s.svc.GetList(r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	var in listInput
+	if err := decode(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if err := checkIfMatch(r, current); err != nil {
+		return 0, nil, err
+	}
+	if in.Statuses != nil {
+		if err := app.CheckStatuses(*in.Statuses); err != nil {
+			return 0, nil, err
+		}
+	}
+	name, color := current.Name, current.Color
+	if in.Name != nil {
+		name = *in.Name
+	}
+	if in.Color != nil {
+		color = *in.Color
+	}
+	var list model.List
+	err = s.mutate(func() (err error) {
+		if list, err = s.svc.UpdateList(current.ID, name, color); err != nil || in.Statuses == nil {
+			return err
+		}
+		list, err = s.svc.SetListStatuses(current.ID, *in.Statuses)
+		return err
+	})
+	return http.StatusOK, list, err
+}
+
+func (s *Server) deleteList(r *http.Request) (int, any, error) {
+	current, err := s.svc.GetList(r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := checkIfMatch(r, current); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, s.mutate(func() error {
+		return s.svc.DeleteList(current.ID)
+	})
+}
+
+func (s *Server) listTasksOf(r *http.Request) (int, any, error) {
+	list, err := s.svc.GetList(r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, s.svc.Tasks(list.ID), nil
+}
+
+type taskInput struct {
+	Text     *string         `json:"text"`
+	Done     *bool           `json:"done"`
+	Status   *string         `json:"status"`
+	Priority *model.Priority `json:"priority"`
+}
+
+func (s *Server) createTask(r *http.Request) (int, any, error) {
+	var in taskInput
+	if err := decode(r, &in); err != nil {
+		return 0, nil, err
+	}
+	var task model.Task
+	err := s.mutate(func() (err error) {
+		task, err = s.svc.CreateTaskWith(r.PathValue("id"), deref(in.Text), app.TaskPatch{Done: in.Done, Status: in.Status, Priority: in.Priority})
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return s.currentTask(http.StatusCreated, task.ID)
+}
+
+// currentTask re-reads a task after a mutation, since positions may have
+// been renumbered; the response ETag then matches a later GET.
+func (s *Server) currentTask(status int, id string) (int, any, error) {
+	task, err := s.svc.GetTask(id)
+	return status, task, err
+}
+
+func (s *Server) listTasks(r *http.Request) (int, any, error) {
+	q := r.URL.Query()
+	tasks := s.svc.Tasks(q.Get("list"))
+	filter := model.Filter(q.Get("filter"))
+	if filter == "" {
+		filter = model.FilterAll
+	}
+	switch filter {
+	case model.FilterAll, model.FilterTodo, model.FilterDone:
+	default:
+		return 0, nil, fmt.Errorf("%w: %q", app.ErrInvalidFilter, filter)
+	}
+	query := strings.ToLower(strings.TrimSpace(q.Get("q")))
+	out := make([]model.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if filter == model.FilterTodo && t.Done || filter == model.FilterDone && !t.Done {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(t.Text), query) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return http.StatusOK, out, nil
+}
+
+func (s *Server) getTask(r *http.Request) (int, any, error) {
+	task, err := s.svc.GetTask(r.PathValue("id"))
+	return http.StatusOK, task, err
+}
+
+func (s *Server) updateTask(r *http.Request) (int, any, error) {
+	task, err := s.svc.GetTask(r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	var in taskInput
+	if err := decode(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if err := checkIfMatch(r, task); err != nil {
+		return 0, nil, err
+	}
+	err = s.mutate(func() (err error) {
+		task, err = s.svc.PatchTask(task.ID, app.TaskPatch(in))
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return s.currentTask(http.StatusOK, task.ID)
+}
+
+func (s *Server) deleteTask(r *http.Request) (int, any, error) {
+	task, err := s.svc.GetTask(r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := checkIfMatch(r, task); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, s.mutate(func() error {
+		return s.svc.DeleteTask(task.ID)
+	})
+}
+
+type archiveResult struct {
+	Archived int `json:"archived"`
+}
+
+// archiveList moves completed tasks to the archive; ?all=true archives
+// every task of the list, as the TUI's "archive all" does.
+func (s *Server) archiveList(r *http.Request) (int, any, error) {
+	listID := r.PathValue("id")
+	var n int
+	err := s.mutate(func() (err error) {
+		if r.URL.Query().Get("all") == "true" {
+			n, err = s.svc.ArchiveAllToArchive(listID)
+		} else {
+			n, err = s.svc.ClearCompletedToArchive(listID)
+		}
+		return err
+	})
+	return http.StatusOK, archiveResult{Archived: n}, err
+}
+
+func (s *Server) listArchive(r *http.Request) (int, any, error) {
+	return http.StatusOK, s.svc.ArchivedCompleted(), nil
+}
+
+func (s *Server) undo(r *http.Request) (int, any, error) {
+	return http.StatusNoContent, nil, s.mutate(s.svc.Undo)
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// statusFor maps app and request errors to HTTP status codes.
+func statusFor(err error) int {
+	var maxBytes *http.MaxBytesError
+	switch {
+	case errors.Is(err, app.ErrTaskNotFound), errors.Is(err, app.ErrListNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, app.ErrInvalidTask), errors.Is(err, app.ErrInvalidName),
+		errors.Is(err, app.ErrInvalidPriority), errors.Is(err, app.ErrInvalidFilter),
+		errors.Is(err, app.ErrInvalidListRef), errors.Is(err, app.ErrInvalidStatus),
+		errors.Is(err, app.ErrDuplicateStatus), errors.Is(err, app.ErrTooFewStatuses),
+		errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	case errors.As(err, &maxBytes):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, app.ErrNothingToUndo), errors.Is(err, app.ErrNoCompletedToClear),
+		errors.Is(err, app.ErrNoTasksInList), errors.Is(err, app.ErrVetoed), errors.Is(err, app.ErrStateChanged):
+		return http.StatusConflict
+	case errors.Is(err, ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+var errBadRequest = errors.New("bad request")
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusFor(err), errorBody{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// decode reads a JSON object, rejecting unknown fields and trailing data.
+func decode(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var maxBytes *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytes):
+			return err
+		case errors.Is(err, io.EOF):
+			return fmt.Errorf("%w: empty body", errBadRequest)
+		default:
+			return fmt.Errorf("%w: %v", errBadRequest, err)
+		}
+	}
+	if dec.More() {
+		return fmt.Errorf("%w: trailing data after JSON object", errBadRequest)
+	}
+	return nil
+}
+
+// etag is a strong validator over the JSON representation of body.
+func etag(body any) string {
+	data, _ := json.Marshal(body)
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func matchesETag(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
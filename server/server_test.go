@@ -0,0 +1,235 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+type fakeSaver struct {
+	saves int
+	last  model.AppState
+	err   error
+}
+
+func (f *fakeSaver) Autosave(state model.AppState) error {
+	f.saves++
+	f.last = state
+	return f.err
+}
+
+func request(t *testing.T, h http.Handler, method, path, body string, header ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeBody[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode failed: %v (%s)", err, rec.Body.String())
+	}
+	return v
+}
+
+func TestListAndTaskLifecycle(t *testing.T) {
+	saver := &fakeSaver{}
+	srv := New(app.NewService(model.NewState()), saver)
+
+	rec := request(t, srv, "POST", "/lists", `{"name":"Casa","color":"green"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create list: expected 201, got %d %s", rec.Code, rec.Body)
+	}
+	list := decodeBody[model.List](t, rec)
+
+	rec = request(t, srv, "POST", "/lists/"+list.ID+"/tasks", `{"text":"Comprar tinta","priority":3}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create task: expected 201, got %d %s", rec.Code, rec.Body)
+	}
+	task := decodeBody[model.Task](t, rec)
+	if task.Priority != model.PriorityHigh || task.ListID != list.ID {
+		t.Fatalf("unexpected task: %+v", task)
+	}
+
+	rec = request(t, srv, "PATCH", "/tasks/"+task.ID, `{"done":true}`)
+	if rec.Code != http.StatusOK || !decodeBody[model.Task](t, rec).Done {
+		t.Fatalf("toggle: got %d %s", rec.Code, rec.Body)
+	}
+	rec = request(t, srv, "GET", "/tasks?filter=done", "")
+	if got := decodeBody[[]model.Task](t, rec); len(got) != 1 {
+		t.Fatalf("expected one done task, got %+v", got)
+	}
+
+	rec = request(t, srv, "POST", "/lists/"+list.ID+"/archive", "")
+	if rec.Code != http.StatusOK || decodeBody[archiveResult](t, rec).Archived != 1 {
+		t.Fatalf("archive: got %d %s", rec.Code, rec.Body)
+	}
+	rec = request(t, srv, "GET", "/archive", "")
+	if got := decodeBody[[]model.ArchivedCompletedTask](t, rec); len(got) != 1 || got[0].TaskText != "Comprar tinta" {
+		t.Fatalf("unexpected archive: %+v", got)
+	}
+
+	if rec := request(t, srv, "POST", "/undo", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("undo: got %d", rec.Code)
+	}
+	if rec := request(t, srv, "DELETE", "/tasks/"+task.ID, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: got %d %s", rec.Code, rec.Body)
+	}
+	if rec := request(t, srv, "DELETE", "/lists/"+list.ID, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete list: got %d %s", rec.Code, rec.Body)
+	}
+	if saver.saves != 7 || len(saver.last.Lists) != 0 {
+		t.Fatalf("expected every mutation to be persisted, got %d saves, last=%+v", saver.saves, saver.last)
+	}
+}
+
+func TestErrorStatusCodes(t *testing.T) {
+	svc := app.NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "")
+	srv := New(svc, nil)
+
+	cases := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"GET", "/tasks/nope", "", http.StatusNotFound},
+		{"PATCH", "/tasks/nope", `{"done":true}`, http.StatusNotFound},
+		{"GET", "/lists/nope/tasks", "", http.StatusNotFound},
+		{"POST", "/lists/" + list.ID + "/tasks", `{"text":"  "}`, http.StatusBadRequest},
+		{"POST", "/lists/" + list.ID + "/tasks", `{"text":"x","priority":9}`, http.StatusBadRequest},
+		{"POST", "/lists/" + list.ID + "/tasks", `{"text":"x","owner":"ana"}`, http.StatusBadRequest},
+		{"POST", "/lists", `{"name":`, http.StatusBadRequest},
+		{"POST", "/lists", ``, http.StatusBadRequest},
+		{"GET", "/tasks?filter=late", "", http.StatusBadRequest},
+		{"POST", "/lists/" + list.ID + "/archive", "", http.StatusConflict},
+		{"PUT", "/lists", "", http.StatusMethodNotAllowed},
+		{"POST", "/lists", `{"name":"x","statuses":["só"]}`, http.StatusBadRequest},
+		{"POST", "/lists/" + list.ID + "/tasks", `{"text":"x","status":"blocked"}`, http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		rec := request(t, srv, c.method, c.path, c.body)
+		if rec.Code != c.want {
+			t.Fatalf("%s %s: expected %d, got %d %s", c.method, c.path, c.want, rec.Code, rec.Body)
+		}
+	}
+
+	svc.SetPreHook(func(ev app.Event) (app.Event, error) { return nil, errors.New("bloqueado") })
+	if rec := request(t, srv, "POST", "/lists/"+list.ID+"/tasks", `{"text":"x"}`); rec.Code != http.StatusConflict {
+		t.Fatalf("expected vetoed change to be a 409, got %d %s", rec.Code, rec.Body)
+	}
+	svc.SetPreHook(nil)
+
+	failing := New(svc, &fakeSaver{err: errors.New("disk full")})
+	if rec := request(t, failing, "POST", "/lists", `{"name":"Outra"}`); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected persistence failure to be a 500, got %d", rec.Code)
+	}
+}
+
+func TestBoardStatuses(t *testing.T) {
+	svc := app.NewService(model.NewState())
+	srv := New(svc, nil)
+
+	rec := request(t, srv, "POST", "/lists", `{"name":"Sprint","statuses":["backlog","doing","shipped"]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create list: expected 201, got %d %s", rec.Code, rec.Body)
+	}
+	list := decodeBody[model.List](t, rec)
+
+	rec = request(t, srv, "POST", "/lists/"+list.ID+"/tasks", `{"text":"Deploy","status":"doing"}`)
+	task := decodeBody[model.Task](t, rec)
+	if rec.Code != http.StatusCreated || task.Status != "doing" || task.Done {
+		t.Fatalf("create task: got %d %+v", rec.Code, task)
+	}
+
+	rec = request(t, srv, "PATCH", "/tasks/"+task.ID, `{"status":"shipped"}`)
+	if task = decodeBody[model.Task](t, rec); !task.Done {
+		t.Fatalf("expected the last column to complete the task, got %+v", task)
+	}
+	if tasks := svc.Tasks(list.ID); len(tasks) != 1 {
+		t.Fatalf("expected exactly one task, got %d", len(tasks))
+	}
+}
+
+func TestTaskRequestsAreSingleChanges(t *testing.T) {
+	svc := app.NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "")
+	srv := New(svc, nil)
+
+	rec := request(t, srv, "POST", "/lists/"+list.ID+"/tasks", `{"text":"Pintar","priority":2,"status":"doing"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create task: expected 201, got %d %s", rec.Code, rec.Body)
+	}
+	task := decodeBody[model.Task](t, rec)
+
+	svc.SetPreHook(func(ev app.Event) (app.Event, error) {
+		if _, ok := ev.(app.TaskToggled); ok {
+			return nil, errors.New("bloqueado")
+		}
+		return ev, nil
+	})
+	if rec := request(t, srv, "PATCH", "/tasks/"+task.ID, `{"text":"Pintar a sala","done":true}`); rec.Code != http.StatusConflict {
+		t.Fatalf("expected the vetoed patch to be a 409, got %d %s", rec.Code, rec.Body)
+	}
+	if got, _ := svc.GetTask(task.ID); got.Text != "Pintar" || got.Done {
+		t.Fatalf("expected a vetoed patch to change nothing, got %+v", got)
+	}
+	svc.SetPreHook(nil)
+
+	if rec := request(t, srv, "POST", "/undo", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("undo: got %d", rec.Code)
+	}
+	if tasks := svc.Tasks(list.ID); len(tasks) != 0 {
+		t.Fatalf("expected one undo to remove the whole create, got %+v", tasks)
+	}
+}
+
+func TestETags(t *testing.T) {
+	svc := app.NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "")
+	task, _ := svc.CreateTask(list.ID, "Pintar")
+	srv := New(svc, nil)
+
+	rec := request(t, srv, "GET", "/tasks/"+task.ID, "")
+	tag := rec.Header().Get("ETag")
+	if tag == "" {
+		t.Fatal("expected an ETag")
+	}
+	if rec := request(t, srv, "GET", "/tasks/"+task.ID, "", "If-None-Match", tag); rec.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", rec.Code)
+	}
+
+	rec = request(t, srv, "PATCH", "/tasks/"+task.ID, `{"text":"Pintar a sala"}`, "If-Match", tag)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected matching If-Match to succeed, got %d %s", rec.Code, rec.Body)
+	}
+	newTag := rec.Header().Get("ETag")
+	if newTag == tag {
+		t.Fatal("expected ETag to change after update")
+	}
+	if got := request(t, srv, "GET", "/tasks/"+task.ID, "").Header().Get("ETag"); got != newTag {
+		t.Fatalf("expected PATCH ETag to match a later GET, got %s want %s", got, newTag)
+	}
+
+	rec = request(t, srv, "PATCH", "/tasks/"+task.ID, `{"text":"Pintar o quarto"}`, "If-Match", tag)
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected stale If-Match to fail with 412, got %d", rec.Code)
+	}
+	if rec := request(t, srv, "DELETE", "/tasks/"+task.ID, "", "If-Match", tag); rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected stale delete to fail with 412, got %d", rec.Code)
+	}
+	if got, _ := svc.GetTask(task.ID); got.Text != "Pintar a sala" {
+		t.Fatalf("stale writes must not apply, got %q", got.Text)
+	}
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// LatestBackupID identifies the `.bak` file written on every autosave.
+const LatestBackupID = "latest"
+
+const backupTimestampLayout = "20060102-150405.000000000"
+
+var (
+	ErrBackupNotFound  = errors.New("backup not found")
+	ErrAmbiguousBackup = errors.New("backup id matches more than one backup")
+)
+
+// BackupInfo describes one backup file found next to the state file.
+type BackupInfo struct {
+	ID        string
+	Path      string
+	TakenAt   time.Time
+	Size      int64
+	TaskCount int
+	Err       error
+}
+
+// Valid reports whether the backup could be decoded.
+func (b BackupInfo) Valid() bool {
+	return b.Err == nil
+}
+
+// ListBackups returns every backup of path, newest first.
+func ListBackups(path string) ([]BackupInfo, error) {
+	return New(path, DefaultOptions()).ListBackups()
+}
+
+// LoadBackup decodes the backup of path identified by id.
+func LoadBackup(path, id string) (model.AppState, BackupInfo, error) {
+	return New(path, DefaultOptions()).LoadBackup(id)
+}
+
+// RestoreBackup replaces the state at path with the backup identified by id.
+func RestoreBackup(path, id string) (model.AppState, error) {
+	return New(path, DefaultOptions()).RestoreBackup(id)
+}
+
+// ListBackups returns every backup, newest first.
+// Backups that fail to decode are still listed with Err set.
+func (s *Store) ListBackups() ([]BackupInfo, error) {
+	base := s.backupBase()
+	candidates := make([]string, 0, s.opts.Backup.Keep+1)
+	latest := base + ".bak"
+	if _, err := os.Stat(latest); err == nil {
+		candidates = append(candidates, latest)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	rotating, err := filepath.Glob(base + ".bak.*")
+	if err != nil {
+		return nil, err
+	}
+	candidates = append(candidates, rotating...)
+
+	out := make([]BackupInfo, 0, len(candidates))
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		b := BackupInfo{
+			ID:      backupID(base, candidate),
+			Path:    candidate,
+			TakenAt: info.ModTime().UTC(),
+			Size:    info.Size(),
+		}
+		if ts, err := time.Parse(backupTimestampLayout, b.ID); err == nil {
+			b.TakenAt = ts
+		}
+		state, err := s.readBackup(candidate)
+		if err != nil {
+			b.Err = err
+		} else {
+			b.TaskCount = len(state.Tasks)
+		}
+		out = append(out, b)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].TakenAt.Equal(out[j].TakenAt) {
+			return out[i].TakenAt.After(out[j].TakenAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	return out, nil
+}
+
+// LoadBackup decodes the backup identified by id.
+// The id may be LatestBackupID or any unambiguous prefix of a rotating timestamp.
+func (s *Store) LoadBackup(id string) (model.AppState, BackupInfo, error) {
+	backups, err := s.ListBackups()
+	if err != nil {
+		return model.AppState{}, BackupInfo{}, err
+	}
+	b, err := findBackup(backups, id)
+	if err != nil {
+		return model.AppState{}, BackupInfo{}, err
+	}
+	if b.Err != nil {
+		return model.AppState{}, b, i18n.Errorf("store.invalid_backup", b.ID, b.Err)
+	}
+	state, err := s.readBackup(b.Path)
+	if err != nil {
+		return model.AppState{}, b, err
+	}
+	return state, b, nil
+}
+
+// RestoreBackup replaces the stored state with the backup identified by id.
+// The current state is first written to a new rotating snapshot (see
+// ForceBackup), so a restore can itself be undone by restoring that one.
+func (s *Store) RestoreBackup(id string) (model.AppState, error) {
+	state, _, err := s.LoadBackup(id)
+	if err != nil {
+		return model.AppState{}, err
+	}
+	if err := s.ForceBackup(); err != nil {
+		return model.AppState{}, i18n.Errorf("store.restore_failed", err)
+	}
+	if err := s.replace(state); err != nil {
+		return model.AppState{}, i18n.Errorf("store.restore_failed", err)
+	}
+	return state, nil
+}
+
+func findBackup(backups []BackupInfo, id string) (BackupInfo, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return BackupInfo{}, ErrBackupNotFound
+	}
+	var matches []BackupInfo
+	for _, b := range backups {
+		if b.ID == id {
+			return b, nil
+		}
+		if strings.HasPrefix(b.ID, id) {
+			matches = append(matches, b)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return BackupInfo{}, fmt.Errorf("%w: %s", ErrBackupNotFound, id)
+	case 1:
+		return matches[0], nil
+	default:
+		return BackupInfo{}, fmt.Errorf("%w: %s", ErrAmbiguousBackup, id)
+	}
+}
+
+func backupID(base, backupPath string) string {
+	if backupPath == base+".bak" {
+		return LatestBackupID
+	}
+	return strings.TrimPrefix(backupPath, base+".bak.")
+}
+
+func (s *Store) readBackup(backupPath string) (model.AppState, error) {
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return model.AppState{}, err
+	}
+	return s.decode(data)
+}
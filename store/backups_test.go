@@ -0,0 +1,196 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"todo-cli/model"
+)
+
+func TestListBackupsNewestFirstWithTaskCounts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	st := New(path, Options{Backup: BackupPolicy{Keep: 10}})
+
+	if err := st.Save(sampleState("v1")); err != nil {
+		t.Fatalf("save v1 failed: %v", err)
+	}
+	if err := st.Autosave(sampleState("v2")); err != nil {
+		t.Fatalf("autosave v2 failed: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := st.Autosave(sampleState("v3")); err != nil {
+		t.Fatalf("autosave v3 failed: %v", err)
+	}
+	if err := os.WriteFile(path+".bak.20000101-000000.000000000", []byte("{broken"), 0o644); err != nil {
+		t.Fatalf("write broken backup failed: %v", err)
+	}
+
+	backups, err := st.ListBackups()
+	if err != nil {
+		t.Fatalf("list backups failed: %v", err)
+	}
+	if len(backups) != 4 {
+		t.Fatalf("expected latest + 2 rotating + broken backups, got %d", len(backups))
+	}
+	for i := 1; i < len(backups); i++ {
+		if backups[i].TakenAt.After(backups[i-1].TakenAt) {
+			t.Fatalf("expected newest first, got %s before %s", backups[i-1].ID, backups[i].ID)
+		}
+	}
+	last := backups[len(backups)-1]
+	if last.Valid() {
+		t.Fatalf("expected broken backup to be listed as invalid")
+	}
+	for _, b := range backups[:len(backups)-1] {
+		if !b.Valid() || b.TaskCount != 1 || b.Size == 0 {
+			t.Fatalf("unexpected backup info %+v", b)
+		}
+	}
+}
+
+func TestRestoreBackupSnapshotsCurrentStateFirst(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	v1 := sampleState("v1")
+	v2 := sampleState("v2")
+
+	if err := Save(path, v1); err != nil {
+		t.Fatalf("save v1 failed: %v", err)
+	}
+	if err := Autosave(path, v2); err != nil {
+		t.Fatalf("autosave v2 failed: %v", err)
+	}
+
+	backups, err := ListBackups(path)
+	if err != nil {
+		t.Fatalf("list backups failed: %v", err)
+	}
+	var rotatingID string
+	for _, b := range backups {
+		if b.ID != LatestBackupID {
+			rotatingID = b.ID
+		}
+	}
+	if rotatingID == "" {
+		t.Fatalf("expected a rotating backup")
+	}
+
+	restored, err := RestoreBackup(path, rotatingID[:12])
+	if err != nil {
+		t.Fatalf("restore by prefix failed: %v", err)
+	}
+	if !reflect.DeepEqual(v1, restored) {
+		t.Fatalf("expected v1 to be restored, got %+v", restored)
+	}
+	persisted, err := Load(path)
+	if err != nil {
+		t.Fatalf("load restored state failed: %v", err)
+	}
+	if !reflect.DeepEqual(v1, persisted) {
+		t.Fatalf("expected restored state on disk")
+	}
+	latest, _, err := LoadBackup(path, LatestBackupID)
+	if err != nil {
+		t.Fatalf("load latest backup failed: %v", err)
+	}
+	if !reflect.DeepEqual(v2, latest) {
+		t.Fatalf("expected state before restore to be kept as latest backup")
+	}
+
+	if _, err := RestoreBackup(path, "19990101"); !errors.Is(err, ErrBackupNotFound) {
+		t.Fatalf("expected ErrBackupNotFound, got %v", err)
+	}
+}
+
+// newestRotating returns the state kept in the newest rotating backup.
+func newestRotating(t *testing.T, st *Store) model.AppState {
+	t.Helper()
+	state, _, err := st.LoadBackup(newestRotatingID(t, st))
+	if err != nil {
+		t.Fatalf("load newest backup failed: %v", err)
+	}
+	return state
+}
+
+func TestRestoreBackupIgnoresMinInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	st := New(path, DefaultOptions())
+	v1, v3 := sampleState("v1"), sampleState("v3")
+
+	if err := st.Save(v1); err != nil {
+		t.Fatalf("save v1 failed: %v", err)
+	}
+	if err := st.Autosave(sampleState("v2")); err != nil {
+		t.Fatalf("autosave v2 failed: %v", err)
+	}
+	// Dentro do MinInterval: v2 só fica no .bak.
+	if err := st.Autosave(v3); err != nil {
+		t.Fatalf("autosave v3 failed: %v", err)
+	}
+	if _, err := st.RestoreBackup(newestRotatingID(t, st)); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if got := newestRotating(t, st); !reflect.DeepEqual(v3, got) {
+		t.Fatalf("expected the state before the restore in a rotating backup, got %+v", got)
+	}
+	// A próxima edição sobrescreve o .bak, mas não a rotação.
+	if err := st.Autosave(sampleState("v4")); err != nil {
+		t.Fatalf("autosave v4 failed: %v", err)
+	}
+	if got := newestRotating(t, st); !reflect.DeepEqual(v3, got) {
+		t.Fatalf("expected the pre-restore snapshot to survive the next edit, got %+v", got)
+	}
+}
+
+func TestRestoreBackupCompactsJournalFirst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	st := journalStore(path, 200)
+	v1, v3 := sampleState("v1"), sampleState("v3")
+
+	if err := st.Save(v1); err != nil {
+		t.Fatalf("save v1 failed: %v", err)
+	}
+	if err := st.Autosave(sampleState("v2")); err != nil {
+		t.Fatalf("autosave v2 failed: %v", err)
+	}
+	if err := st.Compact(); err != nil {
+		t.Fatalf("compact failed: %v", err)
+	}
+	// v3 existe só no journal.
+	if err := st.Autosave(v3); err != nil {
+		t.Fatalf("autosave v3 failed: %v", err)
+	}
+	if _, err := st.RestoreBackup(newestRotatingID(t, st)); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if got := newestRotating(t, st); !reflect.DeepEqual(v3, got) {
+		t.Fatalf("expected the journaled state in a rotating backup, got %+v", got)
+	}
+	persisted, err := st.Load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if !reflect.DeepEqual(v1, persisted) {
+		t.Fatalf("expected v1 restored, got %+v", persisted)
+	}
+}
+
+func newestRotatingID(t *testing.T, st *Store) string {
+	t.Helper()
+	backups, err := st.ListBackups()
+	if err != nil {
+		t.Fatalf("list backups failed: %v", err)
+	}
+	for _, b := range backups {
+		if b.ID != LatestBackupID {
+			return b.ID
+		}
+	}
+	t.Fatalf("expected a rotating backup")
+	return ""
+}
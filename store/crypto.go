@@ -0,0 +1,234 @@
+package store
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"todo-cli/i18n"
+)
+
+const (
+	encryptedFormat = "todo-cli-encrypted/v1"
+	kdfPBKDF2SHA256 = "pbkdf2-sha256"
+	saltSize        = 16
+	keyCheckLabel   = "todo-cli/key-check"
+)
+
+// pbkdf2Iterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256.
+// Tests lower it to keep the suite fast.
+var pbkdf2Iterations = 600_000
+
+var (
+	// ErrPassphraseRequired means the file is encrypted but no passphrase was given.
+	ErrPassphraseRequired = errors.New("state is encrypted: passphrase required")
+	// ErrWrongPassphrase means the key check failed; the file itself is intact.
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+	// ErrCorruptCiphertext means the key is right but the payload failed authentication.
+	ErrCorruptCiphertext = errors.New("encrypted state is corrupted")
+)
+
+// envelope is the on-disk form of an encrypted state or backup.
+// Format must stay the first field: isEncrypted sniffs it as a prefix.
+type envelope struct {
+	Format     string `json:"format"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Check      []byte `json:"check"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+	// Sum covers every field but Data, so a damaged header is reported as
+	// corruption rather than as a wrong passphrase. Envelopes written before
+	// it existed have none, and their key check does not cover the header.
+	Sum []byte `json:"sum,omitempty"`
+}
+
+func (e envelope) additionalData() []byte {
+	return []byte(e.Format + "|" + e.KDF + "|" + strconv.Itoa(e.Iterations) + "|" + string(e.Salt))
+}
+
+func (e envelope) headerSum() []byte {
+	h := sha256.New()
+	h.Write(e.additionalData())
+	h.Write([]byte("|"))
+	h.Write(e.Check)
+	h.Write([]byte("|"))
+	h.Write(e.Nonce)
+	return h.Sum(nil)
+}
+
+// IsEncrypted reports whether the file at path holds an encrypted envelope.
+func IsEncrypted(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return isEncrypted(data), nil
+}
+
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte(`{"format":"`+encryptedFormat+`"`))
+}
+
+type derivedKey struct {
+	enc []byte
+	mac []byte
+}
+
+// keyCache avoids re-running the KDF on every autosave. New files reuse the
+// salt of the last file read or written, so backups share a single key.
+type keyCache struct {
+	mu   sync.Mutex
+	salt []byte
+	keys map[string]derivedKey
+}
+
+func newKeyCache() *keyCache {
+	return &keyCache{keys: make(map[string]derivedKey)}
+}
+
+func (c *keyCache) derive(passphrase string, salt []byte, iterations int) (derivedKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := strconv.Itoa(iterations) + "|" + string(salt) + "|" + passphrase
+	if k, ok := c.keys[id]; ok {
+		return k, nil
+	}
+	raw, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 64)
+	if err != nil {
+		return derivedKey{}, err
+	}
+	k := derivedKey{enc: raw[:32], mac: raw[32:]}
+	c.keys[id] = k
+	if c.salt == nil {
+		c.salt = append([]byte(nil), salt...)
+	}
+	return k, nil
+}
+
+func (c *keyCache) currentSalt() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.salt == nil {
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		c.salt = salt
+	}
+	return append([]byte(nil), c.salt...), nil
+}
+
+func (c *keyCache) seal(passphrase string, plain []byte) ([]byte, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+	salt, err := c.currentSalt()
+	if err != nil {
+		return nil, err
+	}
+	env := envelope{
+		Format:     encryptedFormat,
+		KDF:        kdfPBKDF2SHA256,
+		Iterations: pbkdf2Iterations,
+		Salt:       salt,
+	}
+	key, err := c.derive(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key.enc)
+	if err != nil {
+		return nil, err
+	}
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, err
+	}
+	env.Check = keyCheck(key, env.additionalData())
+	env.Data = aead.Seal(nil, env.Nonce, plain, env.additionalData())
+	env.Sum = env.headerSum()
+
+	out, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+func (c *keyCache) open(passphrase string, data []byte) ([]byte, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptCiphertext, err)
+	}
+	if env.Format != encryptedFormat || env.KDF != kdfPBKDF2SHA256 {
+		return nil, i18n.Errorf("store.unsupported_format", ErrCorruptCiphertext, env.Format, env.KDF)
+	}
+	// Só um cabeçalho íntegro permite culpar a senha por um key check que não bate.
+	if env.Sum != nil && !hmac.Equal(env.Sum, env.headerSum()) {
+		return nil, i18n.Errorf("store.bad_header", ErrCorruptCiphertext)
+	}
+	if env.Iterations <= 0 || env.Iterations > 10*pbkdf2Iterations || len(env.Salt) != saltSize || len(env.Check) != sha256.Size {
+		return nil, i18n.Errorf("store.bad_header", ErrCorruptCiphertext)
+	}
+
+	key, err := c.derive(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key.enc)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, i18n.Errorf("store.bad_nonce", ErrCorruptCiphertext)
+	}
+	var header []byte
+	if env.Sum != nil {
+		header = env.additionalData()
+	}
+	if !hmac.Equal(env.Check, keyCheck(key, header)) {
+		return nil, ErrWrongPassphrase
+	}
+	plain, err := aead.Open(nil, env.Nonce, env.Data, env.additionalData())
+	if err != nil {
+		return nil, ErrCorruptCiphertext
+	}
+	return plain, nil
+}
+
+// keyCheck lets a wrong passphrase be told apart from a damaged payload,
+// without revealing anything about the encryption key itself. It also
+// authenticates header, which is nil for envelopes without a Sum.
+func keyCheck(key derivedKey, header []byte) []byte {
+	mac := hmac.New(sha256.New, key.mac)
+	mac.Write([]byte(keyCheckLabel))
+	mac.Write(header)
+	return mac.Sum(nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func init() {
+	pbkdf2Iterations = 1000
+}
+
+func encryptedStore(path, passphrase string) *Store {
+	return New(path, Options{Passphrase: passphrase, Encrypt: true})
+}
+
+func TestEncryptedSaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	want := sampleState("secret")
+
+	st := encryptedStore(path, "correct horse")
+	if err := st.Save(want); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read raw failed: %v", err)
+	}
+	if bytes.Contains(raw, []byte("Task-secret")) {
+		t.Fatalf("expected task text not to appear in plain text on disk")
+	}
+	if ok, err := IsEncrypted(path); err != nil || !ok {
+		t.Fatalf("expected file to be detected as encrypted, got %v %v", ok, err)
+	}
+
+	got, err := encryptedStore(path, "correct horse").Load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("round-trip mismatch\nwant=%+v\ngot=%+v", want, got)
+	}
+
+	if _, err := New(path, DefaultOptions()).Load(); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("expected ErrPassphraseRequired, got %v", err)
+	}
+}
+
+func TestEncryptedBackupsAndMigrationFromPlain(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	if err := Save(path, sampleState("plain")); err != nil {
+		t.Fatalf("plain save failed: %v", err)
+	}
+
+	st := encryptedStore(path, "pw")
+	if err := st.Autosave(sampleState("v2")); err != nil {
+		t.Fatalf("encrypted autosave failed: %v", err)
+	}
+
+	backups, err := filepath.Glob(path + ".bak*")
+	if err != nil || len(backups) == 0 {
+		t.Fatalf("expected backups, got %v %v", backups, err)
+	}
+	for _, b := range backups {
+		if ok, err := IsEncrypted(b); err != nil || !ok {
+			t.Fatalf("expected backup %s to be encrypted", filepath.Base(b))
+		}
+	}
+
+	restored, _, err := st.LoadBackup(LatestBackupID)
+	if err != nil {
+		t.Fatalf("load encrypted backup failed: %v", err)
+	}
+	if restored.Tasks[0].Text != "Task-plain" {
+		t.Fatalf("expected plain state preserved in encrypted backup, got %+v", restored.Tasks)
+	}
+}
+
+func TestLoadWithRecoveryDistinguishesWrongPassphraseFromCorruption(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	st := encryptedStore(path, "pw")
+	v1 := sampleState("v1")
+	if err := st.Save(v1); err != nil {
+		t.Fatalf("save v1 failed: %v", err)
+	}
+	if err := st.Autosave(sampleState("v2")); err != nil {
+		t.Fatalf("autosave v2 failed: %v", err)
+	}
+
+	if _, _, err := encryptedStore(path, "nope").LoadWithRecovery(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("expected ErrWrongPassphrase, got %v", err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "state.corrupt-*")); len(matches) != 0 {
+		t.Fatalf("wrong passphrase must not move the state file aside")
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read raw failed: %v", err)
+	}
+	// Troca um caractere do payload base64 mantendo o JSON válido.
+	idx := bytes.Index(raw, []byte(`"data":"`)) + len(`"data":"`) + 4
+	if raw[idx] == 'A' {
+		raw[idx] = 'B'
+	} else {
+		raw[idx] = 'A'
+	}
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatalf("tamper write failed: %v", err)
+	}
+
+	recovered, status, err := encryptedStore(path, "pw").LoadWithRecovery()
+	if err != nil {
+		t.Fatalf("recovery of tampered ciphertext failed: %v", err)
+	}
+	if status == "" || !reflect.DeepEqual(v1, recovered) {
+		t.Fatalf("expected recovery from encrypted backup, got status=%q state=%+v", status, recovered)
+	}
+}
+
+func TestDamagedEnvelopeHeaderIsCorruption(t *testing.T) {
+	tamper := map[string]func(env *envelope){
+		"salt":  func(env *envelope) { env.Salt[0] ^= 1 },
+		"check": func(env *envelope) { env.Check = env.Check[:8] },
+		"nonce": func(env *envelope) { env.Nonce[0] ^= 1 },
+	}
+	for name, damage := range tamper {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "state.json")
+			st := encryptedStore(path, "pw")
+			v1 := sampleState("v1")
+			if err := st.Save(v1); err != nil {
+				t.Fatalf("save v1 failed: %v", err)
+			}
+			if err := st.Autosave(sampleState("v2")); err != nil {
+				t.Fatalf("autosave v2 failed: %v", err)
+			}
+
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read raw failed: %v", err)
+			}
+			var env envelope
+			if err := json.Unmarshal(raw, &env); err != nil {
+				t.Fatalf("decode envelope failed: %v", err)
+			}
+			damage(&env)
+			if raw, err = json.Marshal(env); err != nil {
+				t.Fatalf("encode envelope failed: %v", err)
+			}
+			if err := os.WriteFile(path, raw, 0o600); err != nil {
+				t.Fatalf("tamper write failed: %v", err)
+			}
+
+			if _, err := encryptedStore(path, "pw").Load(); !errors.Is(err, ErrCorruptCiphertext) {
+				t.Fatalf("expected ErrCorruptCiphertext, got %v", err)
+			}
+			recovered, _, err := encryptedStore(path, "pw").LoadWithRecovery()
+			if err != nil {
+				t.Fatalf("recovery failed: %v", err)
+			}
+			if !reflect.DeepEqual(v1, recovered) {
+				t.Fatalf("expected recovery from the backup, got %+v", recovered)
+			}
+		})
+	}
+}
+
+func TestEnvelopeWithoutSumStillOpens(t *testing.T) {
+	c := newKeyCache()
+	sealed, err := c.seal("pw", []byte("segredo"))
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	// Reescreve o envelope como antes do Sum: key check só com o rótulo.
+	var env envelope
+	if err := json.Unmarshal(sealed, &env); err != nil {
+		t.Fatalf("decode envelope failed: %v", err)
+	}
+	key, err := c.derive("pw", env.Salt, env.Iterations)
+	if err != nil {
+		t.Fatalf("derive failed: %v", err)
+	}
+	env.Sum = nil
+	env.Check = keyCheck(key, nil)
+	legacy, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("encode envelope failed: %v", err)
+	}
+
+	if plain, err := newKeyCache().open("pw", legacy); err != nil || string(plain) != "segredo" {
+		t.Fatalf("expected the old envelope to open, got %q (%v)", plain, err)
+	}
+	if _, err := newKeyCache().open("nope", legacy); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("expected ErrWrongPassphrase, got %v", err)
+	}
+}
@@ -0,0 +1,609 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+const defaultCompactEvery = 200
+
+// ErrCorruptJournal means a journal record failed its checksum or could not be decoded.
+var ErrCorruptJournal = errors.New("corrupted journal record")
+
+// Journal operations, named after the app event a record was written for.
+// Saves of a whole state, without an attached Service, use OpReplace (or
+// OpMeta when only the filter, query or metadata changed).
+const (
+	OpCreate  = "create"
+	OpUpdate  = "update"
+	OpToggle  = "toggle"
+	OpMove    = "move"
+	OpArchive = "archive"
+	OpDelete  = "delete"
+	OpImport  = "import"
+	OpUndo    = "undo"
+	OpReplace = "replace"
+	OpMeta    = "meta"
+)
+
+// journalRecord carries the entities a mutation touched, with their full
+// values, so replaying a record twice yields the same state.
+type journalRecord struct {
+	Seq          int64                         `json:"seq"`
+	At           time.Time                     `json:"at"`
+	Op           string                        `json:"op"`
+	Lists        []model.List                  `json:"lists,omitempty"`
+	DeletedLists []string                      `json:"deletedLists,omitempty"`
+	ListOrder    []string                      `json:"listOrder,omitempty"`
+	Tasks        []model.Task                  `json:"tasks,omitempty"`
+	DeletedTasks []string                      `json:"deletedTasks,omitempty"`
+	Archived     []model.ArchivedCompletedTask `json:"archived,omitempty"`
+	Unarchived   []string                      `json:"unarchived,omitempty"`
+	Meta         *journalMeta                  `json:"meta,omitempty"`
+}
+
+type journalMeta struct {
+	Filter   model.Filter   `json:"filter"`
+	Query    string         `json:"query"`
+	Metadata model.Metadata `json:"metadata"`
+}
+
+// journalState tracks what has been persisted so the next save can be a delta.
+type journalState struct {
+	last    *model.AppState
+	seq     int64
+	records int
+
+	// attached counts the Services whose events are journaled as they
+	// happen; failed is the newest of their changes that could not be.
+	attached int
+	failed   *journalChange
+}
+
+type journalChange struct {
+	op    string
+	after model.AppState
+}
+
+// JournalPath returns the append-only log that accompanies the snapshot.
+func (s *Store) JournalPath() string {
+	return s.path + ".journal"
+}
+
+func (s *Store) compactEvery() int {
+	if s.opts.CompactEvery <= 0 {
+		return defaultCompactEvery
+	}
+	return s.opts.CompactEvery
+}
+
+// Compact folds the journal into a fresh snapshot and truncates the journal.
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.opts.Journal || s.journal.last == nil {
+		return nil
+	}
+	return s.compactLocked(*s.journal.last)
+}
+
+func (s *Store) compactLocked(state model.AppState) error {
+	// Os backups já acompanharam cada registro; o snapshot antigo está atrasado.
+	if err := s.writeSnapshot(state); err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.JournalPath(), nil, s.fileMode()); err != nil {
+		return err
+	}
+	s.rememberLocked(state, 0)
+	return nil
+}
+
+func (s *Store) rememberLocked(state model.AppState, records int) {
+	last := cloneState(state)
+	s.journal.last = &last
+	s.journal.records = records
+}
+
+// ensureJournalBaseLocked loads what is on disk when nothing was loaded or
+// saved through this Store yet, so the next record has a base to diff from.
+func (s *Store) ensureJournalBaseLocked() error {
+	if s.journal.last != nil {
+		return nil
+	}
+	base, err := s.loadSnapshot()
+	if err != nil {
+		return err
+	}
+	_, _, err = s.loadJournaledLocked(base, false)
+	return err
+}
+
+// Attach journals the changes made through svc as they happen: each event
+// becomes one record, named after it, holding what that change touched.
+// While attached, Autosave only retries a record that could not be written.
+// Outside journal mode Attach does nothing.
+func (s *Store) Attach(svc *app.Service) (detach func()) {
+	if !s.opts.Journal {
+		return func() {}
+	}
+	s.mu.Lock()
+	s.journal.attached++
+	s.mu.Unlock()
+	unsubscribe := svc.SubscribeWithState(s.record)
+	return func() {
+		unsubscribe()
+		s.mu.Lock()
+		s.journal.attached--
+		s.mu.Unlock()
+	}
+}
+
+func (s *Store) record(ev app.Event, after model.AppState) {
+	op := eventOp(ev)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.appendJournalLocked(op, after); err != nil {
+		s.journal.failed = &journalChange{op: op, after: cloneState(after)}
+		return
+	}
+	// O registro parte do último estado gravado, então cobre o que falhou antes.
+	s.journal.failed = nil
+}
+
+// retryLocked appends the change an attached Service could not journal.
+func (s *Store) retryLocked() error {
+	f := s.journal.failed
+	if f == nil {
+		return nil
+	}
+	if err := s.appendJournalLocked(f.op, f.after); err != nil {
+		return err
+	}
+	s.journal.failed = nil
+	return nil
+}
+
+func eventOp(ev app.Event) string {
+	switch ev.(type) {
+	case app.ListCreated, app.TaskCreated:
+		return OpCreate
+	case app.ListUpdated, app.TaskUpdated:
+		return OpUpdate
+	case app.TaskToggled:
+		return OpToggle
+	case app.ListMoved, app.TaskMoved:
+		return OpMove
+	case app.ListDeleted, app.TaskDeleted, app.TasksCleared:
+		return OpDelete
+	case app.Archived:
+		return OpArchive
+	case app.Imported:
+		return OpImport
+	case app.Undone:
+		return OpUndo
+	case app.SessionChanged:
+		return OpMeta
+	default:
+		return OpReplace
+	}
+}
+
+// appendJournalLocked appends the record that turns the last persisted state
+// into state. An empty op names it after what changed (OpMeta or OpReplace).
+// As in snapshot mode, the state it replaces goes to the backups first.
+func (s *Store) appendJournalLocked(op string, state model.AppState) error {
+	if err := s.ensureJournalBaseLocked(); err != nil {
+		return err
+	}
+
+	rec, changed := diffRecord(*s.journal.last, state)
+	if !changed {
+		return nil
+	}
+	if op == "" {
+		op = OpReplace
+		if onlyMeta(rec) {
+			op = OpMeta
+		}
+	}
+	now := time.Now().UTC()
+	if s.persistedLocked() {
+		if err := s.backupState(*s.journal.last, now); err != nil {
+			return err
+		}
+	}
+	s.journal.seq++
+	rec.Seq = s.journal.seq
+	rec.At = now
+	rec.Op = op
+
+	line, err := s.encodeRecord(rec)
+	if err != nil {
+		return err
+	}
+	if err := ensureDir(s.path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.JournalPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, s.fileMode())
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	s.rememberLocked(state, s.journal.records+1)
+	if s.journal.records >= s.compactEvery() {
+		return s.compactLocked(state)
+	}
+	return nil
+}
+
+// persistedLocked reports whether the last state was ever written, so a
+// first record does not back up the empty state it starts from.
+func (s *Store) persistedLocked() bool {
+	if s.journal.records > 0 {
+		return true
+	}
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+// encodeRecord renders `<crc32> <json>\n`; with encryption on, the JSON is an envelope.
+func (s *Store) encodeRecord(rec journalRecord) ([]byte, error) {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	if s.opts.Encrypt {
+		sealed, err := s.keys.seal(s.opts.Passphrase, payload)
+		if err != nil {
+			return nil, err
+		}
+		payload = bytes.TrimSpace(sealed)
+	}
+	line := fmt.Sprintf("%08x %s\n", crc32.ChecksumIEEE(payload), payload)
+	return []byte(line), nil
+}
+
+func (s *Store) decodeRecord(line []byte) (journalRecord, error) {
+	sum, payload, ok := bytes.Cut(line, []byte(" "))
+	if !ok || len(sum) != 8 {
+		return journalRecord{}, i18n.Errorf("store.bad_record", ErrCorruptJournal)
+	}
+	want, err := strconv.ParseUint(string(sum), 16, 32)
+	if err != nil || uint32(want) != crc32.ChecksumIEEE(payload) {
+		return journalRecord{}, i18n.Errorf("store.record_checksum", ErrCorruptJournal)
+	}
+	if isEncrypted(payload) {
+		payload, err = s.keys.open(s.opts.Passphrase, payload)
+		if err != nil {
+			if errors.Is(err, ErrCorruptCiphertext) {
+				return journalRecord{}, fmt.Errorf("%w: %v", ErrCorruptJournal, err)
+			}
+			return journalRecord{}, err
+		}
+	}
+	var rec journalRecord
+	if err := json.Unmarshal(payload, &rec); err != nil {
+		return journalRecord{}, fmt.Errorf("%w: %v", ErrCorruptJournal, err)
+	}
+	return rec, nil
+}
+
+// journalReplay describes how far a replay got.
+type journalReplay struct {
+	applied int
+	valid   int64
+	badTail []byte
+	badErr  error
+}
+
+// replayJournalLocked applies every valid record on top of state and stops at
+// the first torn or corrupted one.
+func (s *Store) replayJournalLocked(state model.AppState) (model.AppState, journalReplay, error) {
+	data, err := os.ReadFile(s.JournalPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, journalReplay{}, nil
+		}
+		return model.AppState{}, journalReplay{}, err
+	}
+
+	var (
+		replay  journalReplay
+		lastSeq int64
+		offset  int
+	)
+	for offset < len(data) {
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end < 0 {
+			replay.badErr = i18n.Errorf("store.truncated_record", ErrCorruptJournal)
+			break
+		}
+		line := data[offset : offset+end]
+		rec, err := s.decodeRecord(line)
+		if err != nil {
+			if !errors.Is(err, ErrCorruptJournal) {
+				return model.AppState{}, journalReplay{}, err
+			}
+			replay.badErr = err
+			break
+		}
+		if rec.Seq <= lastSeq {
+			replay.badErr = i18n.Errorf("store.out_of_order", ErrCorruptJournal, rec.Seq, lastSeq)
+			break
+		}
+		state = applyRecord(state, rec)
+		lastSeq = rec.Seq
+		replay.applied++
+		offset += end + 1
+	}
+	replay.valid = int64(offset)
+	if offset < len(data) {
+		replay.badTail = data[offset:]
+	}
+
+	if lastSeq > s.journal.seq {
+		s.journal.seq = lastSeq
+	}
+	return state, replay, nil
+}
+
+// loadJournaledLocked replays the journal onto a loaded snapshot. A journal
+// past compactEvery is only compacted by the next write. With recovering
+// set, a corrupted tail is moved aside and cut from the journal; otherwise
+// the corruption is returned.
+func (s *Store) loadJournaledLocked(snapshot model.AppState, recovering bool) (model.AppState, string, error) {
+	state, replay, err := s.replayJournalLocked(snapshot)
+	if err != nil {
+		return model.AppState{}, "", err
+	}
+	if replay.badErr == nil {
+		s.rememberLocked(state, replay.applied)
+		return state, "", nil
+	}
+	if !recovering {
+		return model.AppState{}, "", replay.badErr
+	}
+
+	tailPath := fmt.Sprintf("%s.corrupt-%s", s.JournalPath(), time.Now().UTC().Format("20060102-150405"))
+	if err := os.WriteFile(tailPath, replay.badTail, s.fileMode()); err != nil {
+		return model.AppState{}, "", i18n.Errorf("store.preserve_failed", err)
+	}
+	// Sem a cauda, os próximos registros entram logo após o último válido.
+	if err := os.Truncate(s.JournalPath(), replay.valid); err != nil {
+		return model.AppState{}, "", i18n.Errorf("store.truncate_failed", err)
+	}
+	s.rememberLocked(state, replay.applied)
+	msg := i18n.T("store.journal_recovered", replay.applied, filepath.Base(tailPath))
+	return state, msg, nil
+}
+
+// diffRecord builds the record that turns from into to.
+func diffRecord(from, to model.AppState) (journalRecord, bool) {
+	var rec journalRecord
+
+	fromLists := make(map[string]model.List, len(from.Lists))
+	for _, l := range from.Lists {
+		fromLists[l.ID] = l
+	}
+	toListIDs := make(map[string]bool, len(to.Lists))
+	for _, l := range to.Lists {
+		toListIDs[l.ID] = true
+		if old, ok := fromLists[l.ID]; !ok || !reflect.DeepEqual(old, l) {
+			rec.Lists = append(rec.Lists, l)
+		}
+	}
+	for _, l := range from.Lists {
+		if !toListIDs[l.ID] {
+			rec.DeletedLists = append(rec.DeletedLists, l.ID)
+		}
+	}
+	if !sameListOrder(from.Lists, to.Lists) {
+		for _, l := range to.Lists {
+			rec.ListOrder = append(rec.ListOrder, l.ID)
+		}
+	}
+
+	fromTasks := make(map[string]model.Task, len(from.Tasks))
+	for _, t := range from.Tasks {
+		fromTasks[t.ID] = t
+	}
+	toTaskIDs := make(map[string]bool, len(to.Tasks))
+	for _, t := range to.Tasks {
+		toTaskIDs[t.ID] = true
+		if old, ok := fromTasks[t.ID]; !ok || !reflect.DeepEqual(old, t) {
+			rec.Tasks = append(rec.Tasks, t)
+		}
+	}
+	for _, t := range from.Tasks {
+		if !toTaskIDs[t.ID] {
+			rec.DeletedTasks = append(rec.DeletedTasks, t.ID)
+		}
+	}
+
+	fromArchived := make(map[string]bool, len(from.ArchivedCompleted))
+	for _, a := range from.ArchivedCompleted {
+		fromArchived[a.ID] = true
+	}
+	toArchived := make(map[string]bool, len(to.ArchivedCompleted))
+	for _, a := range to.ArchivedCompleted {
+		toArchived[a.ID] = true
+		if !fromArchived[a.ID] {
+			rec.Archived = append(rec.Archived, a)
+		}
+	}
+	for _, a := range from.ArchivedCompleted {
+		if !toArchived[a.ID] {
+			rec.Unarchived = append(rec.Unarchived, a.ID)
+		}
+	}
+
+	if from.Filter != to.Filter || from.Query != to.Query || !reflect.DeepEqual(from.Metadata, to.Metadata) {
+		rec.Meta = &journalMeta{Filter: to.Filter, Query: to.Query, Metadata: to.Metadata}
+	}
+
+	return rec, !onlyMeta(rec) || rec.Meta != nil
+}
+
+// onlyMeta reports whether rec changes nothing but the filter, query or metadata.
+func onlyMeta(rec journalRecord) bool {
+	return len(rec.Lists)+len(rec.DeletedLists)+len(rec.ListOrder)+
+		len(rec.Tasks)+len(rec.DeletedTasks)+len(rec.Archived)+len(rec.Unarchived) == 0
+}
+
+func applyRecord(state model.AppState, rec journalRecord) model.AppState {
+	state = cloneState(state)
+
+	for _, l := range rec.Lists {
+		replaced := false
+		for i := range state.Lists {
+			if state.Lists[i].ID == l.ID {
+				state.Lists[i] = l
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			state.Lists = append(state.Lists, l)
+		}
+	}
+	if len(rec.DeletedLists) > 0 {
+		drop := toSet(rec.DeletedLists)
+		kept := state.Lists[:0]
+		for _, l := range state.Lists {
+			if !drop[l.ID] {
+				kept = append(kept, l)
+			}
+		}
+		state.Lists = kept
+	}
+	if len(rec.ListOrder) > 0 {
+		state.Lists = reorderLists(state.Lists, rec.ListOrder)
+	}
+
+	for _, t := range rec.Tasks {
+		replaced := false
+		for i := range state.Tasks {
+			if state.Tasks[i].ID == t.ID {
+				state.Tasks[i] = t
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			state.Tasks = append(state.Tasks, t)
+		}
+	}
+	if len(rec.DeletedTasks) > 0 {
+		drop := toSet(rec.DeletedTasks)
+		kept := state.Tasks[:0]
+		for _, t := range state.Tasks {
+			if !drop[t.ID] {
+				kept = append(kept, t)
+			}
+		}
+		state.Tasks = kept
+	}
+
+	for _, a := range rec.Archived {
+		replaced := false
+		for i := range state.ArchivedCompleted {
+			if state.ArchivedCompleted[i].ID == a.ID {
+				state.ArchivedCompleted[i] = a
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			state.ArchivedCompleted = append(state.ArchivedCompleted, a)
+		}
+	}
+	if len(rec.Unarchived) > 0 {
+		drop := toSet(rec.Unarchived)
+		kept := state.ArchivedCompleted[:0]
+		for _, a := range state.ArchivedCompleted {
+			if !drop[a.ID] {
+				kept = append(kept, a)
+			}
+		}
+		state.ArchivedCompleted = kept
+	}
+
+	if rec.Meta != nil {
+		state.Filter = rec.Meta.Filter
+		state.Query = rec.Meta.Query
+		state.Metadata = rec.Meta.Metadata
+	}
+	return state
+}
+
+func sameListOrder(a, b []model.List) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			return false
+		}
+	}
+	return true
+}
+
+func reorderLists(lists []model.List, order []string) []model.List {
+	byID := make(map[string]model.List, len(lists))
+	for _, l := range lists {
+		byID[l.ID] = l
+	}
+	out := make([]model.List, 0, len(lists))
+	for _, id := range order {
+		if l, ok := byID[id]; ok {
+			out = append(out, l)
+			delete(byID, id)
+		}
+	}
+	for _, l := range lists {
+		if _, ok := byID[l.ID]; ok {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func toSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func cloneState(state model.AppState) model.AppState {
+	out := state
+	out.Lists = append([]model.List{}, state.Lists...)
+	out.Tasks = append([]model.Task{}, state.Tasks...)
+	out.ArchivedCompleted = append([]model.ArchivedCompletedTask{}, state.ArchivedCompleted...)
+	return out
+}
@@ -0,0 +1,350 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func journalStore(path string, compactEvery int) *Store {
+	opts := DefaultOptions()
+	opts.Journal = true
+	opts.CompactEvery = compactEvery
+	return New(path, opts)
+}
+
+func journalLines(t *testing.T, st *Store) []string {
+	t.Helper()
+	data, err := os.ReadFile(st.JournalPath())
+	if err != nil {
+		t.Fatalf("read journal failed: %v", err)
+	}
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	return lines
+}
+
+func TestJournalAppendsRecordsAndReplays(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	base := sampleState("j")
+
+	st := journalStore(path, 100)
+	if err := st.Save(base); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	now := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	created := cloneState(base)
+	created.Tasks = append(created.Tasks, model.Task{ID: "task-new", ListID: "list-j", Text: "Nova", Position: 2, CreatedAt: now, UpdatedAt: now})
+
+	filtered := cloneState(created)
+	filtered.Query = "Nova"
+
+	for _, state := range []model.AppState{created, created, filtered} {
+		if err := st.Autosave(state); err != nil {
+			t.Fatalf("autosave failed: %v", err)
+		}
+	}
+
+	lines := journalLines(t, st)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records (unchanged saves are skipped), got %d", len(lines))
+	}
+	for i, op := range []string{OpReplace, OpMeta} {
+		if !strings.Contains(lines[i], `"op":"`+op+`"`) {
+			t.Fatalf("record %d: expected op %q, got %s", i, op, lines[i])
+		}
+	}
+
+	snapshot, err := New(path, DefaultOptions()).Load()
+	if err != nil {
+		t.Fatalf("snapshot load failed: %v", err)
+	}
+	if !reflect.DeepEqual(base, snapshot) {
+		t.Fatalf("expected snapshot to stay untouched between compactions")
+	}
+
+	got, err := journalStore(path, 100).Load()
+	if err != nil {
+		t.Fatalf("journal load failed: %v", err)
+	}
+	if !reflect.DeepEqual(filtered, got) {
+		t.Fatalf("replay mismatch\nwant=%+v\ngot=%+v", filtered, got)
+	}
+}
+
+func TestAttachedJournalWritesOneRecordPerChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	st := journalStore(path, 100)
+	if err := st.Save(sampleState("j")); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	state, err := st.Load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	svc := app.NewService(state)
+	detach := st.Attach(svc)
+	defer detach()
+
+	task, err := svc.CreateTask("list-j", "Nova")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	// Subir a tarefa renumera a vizinha: as duas vão no mesmo registro.
+	if _, err := svc.MoveTaskTo(task.ID, 0); err != nil {
+		t.Fatalf("move failed: %v", err)
+	}
+	if _, err := svc.ToggleDone(task.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if _, err := svc.ClearCompletedToArchive("list-j"); err != nil {
+		t.Fatalf("archive failed: %v", err)
+	}
+	if err := st.Autosave(svc.State()); err != nil {
+		t.Fatalf("autosave failed: %v", err)
+	}
+
+	lines := journalLines(t, st)
+	ops := []string{OpCreate, OpMove, OpToggle, OpArchive}
+	if len(lines) != len(ops) {
+		t.Fatalf("expected one record per change and none from Autosave, got %d", len(lines))
+	}
+	for i, op := range ops {
+		if !strings.Contains(lines[i], `"op":"`+op+`"`) {
+			t.Fatalf("record %d: expected op %q, got %s", i, op, lines[i])
+		}
+	}
+	if strings.Count(lines[1], `"listId"`) != 2 {
+		t.Fatalf("expected the move record to carry both renumbered tasks, got %s", lines[1])
+	}
+
+	got, err := journalStore(path, 100).Load()
+	if err != nil {
+		t.Fatalf("journal load failed: %v", err)
+	}
+	if want := svc.State(); !reflect.DeepEqual(want, got) {
+		t.Fatalf("replay mismatch\nwant=%+v\ngot=%+v", want, got)
+	}
+
+	// Como no modo snapshot, o .bak acompanha cada registro.
+	latest, _, err := st.LoadBackup(LatestBackupID)
+	if err != nil {
+		t.Fatalf("load latest backup failed: %v", err)
+	}
+	if len(latest.Tasks) != 2 || !latest.Tasks[1].Done {
+		t.Fatalf("expected .bak to hold the state before the last record, got %+v", latest.Tasks)
+	}
+}
+
+func TestJournalLoadLeavesCompactionToNextWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	state := sampleState("l")
+
+	st := journalStore(path, 100)
+	if err := st.Save(state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		state = cloneState(state)
+		state.Tasks[0].Text += "!"
+		if err := st.Autosave(state); err != nil {
+			t.Fatalf("autosave %d failed: %v", i, err)
+		}
+	}
+
+	small := journalStore(path, 3)
+	if _, err := small.Load(); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if lines := journalLines(t, small); len(lines) != 3 {
+		t.Fatalf("expected load to leave the journal alone, got %d records", len(lines))
+	}
+	state = cloneState(state)
+	state.Query = "!"
+	if err := small.Autosave(state); err != nil {
+		t.Fatalf("autosave failed: %v", err)
+	}
+	if lines := journalLines(t, small); len(lines) != 0 {
+		t.Fatalf("expected the next write to compact, got %d records", len(lines))
+	}
+}
+
+func TestJournalCompactsIntoSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	state := sampleState("c")
+
+	st := journalStore(path, 3)
+	if err := st.Save(state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		state = cloneState(state)
+		state.Tasks[0].Text += "!"
+		if err := st.Autosave(state); err != nil {
+			t.Fatalf("autosave %d failed: %v", i, err)
+		}
+	}
+
+	if lines := journalLines(t, st); len(lines) != 0 {
+		t.Fatalf("expected journal to be truncated after compaction, got %d records", len(lines))
+	}
+	snapshot, err := New(path, DefaultOptions()).Load()
+	if err != nil {
+		t.Fatalf("snapshot load failed: %v", err)
+	}
+	if !reflect.DeepEqual(state, snapshot) {
+		t.Fatalf("expected compacted snapshot to hold latest state")
+	}
+	if _, err := os.Stat(path + ".bak"); err != nil {
+		t.Fatalf("expected compaction to take a backup: %v", err)
+	}
+}
+
+func TestJournalRecoveryStopsAtLastValidRecord(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	state := sampleState("r")
+
+	st := journalStore(path, 100)
+	if err := st.Save(state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	var valid model.AppState
+	for i := 0; i < 3; i++ {
+		state = cloneState(state)
+		state.Tasks[0].Position = i + 10
+		if err := st.Autosave(state); err != nil {
+			t.Fatalf("autosave %d failed: %v", i, err)
+		}
+		if i == 1 {
+			valid = state
+		}
+	}
+
+	// Simula uma escrita interrompida no meio do último registro.
+	data, err := os.ReadFile(st.JournalPath())
+	if err != nil {
+		t.Fatalf("read journal failed: %v", err)
+	}
+	if err := os.WriteFile(st.JournalPath(), data[:len(data)-20], 0o644); err != nil {
+		t.Fatalf("truncate journal failed: %v", err)
+	}
+
+	if _, err := journalStore(path, 100).Load(); !errors.Is(err, ErrCorruptJournal) {
+		t.Fatalf("expected ErrCorruptJournal from strict load, got %v", err)
+	}
+
+	got, msg, err := journalStore(path, 100).LoadWithRecovery()
+	if err != nil {
+		t.Fatalf("recovery failed: %v", err)
+	}
+	if !reflect.DeepEqual(valid, got) {
+		t.Fatalf("expected state up to last valid record\nwant=%+v\ngot=%+v", valid, got)
+	}
+	if !strings.Contains(msg, "2 registros") {
+		t.Fatalf("expected recovery message to report replayed records, got %q", msg)
+	}
+	tails, _ := filepath.Glob(st.JournalPath() + ".corrupt-*")
+	if len(tails) != 1 {
+		t.Fatalf("expected corrupted tail to be preserved, got %v", tails)
+	}
+
+	again, err := journalStore(path, 100).Load()
+	if err != nil {
+		t.Fatalf("load after recovery failed: %v", err)
+	}
+	if !reflect.DeepEqual(valid, again) {
+		t.Fatalf("expected the journal to load cleanly without its corrupted tail")
+	}
+}
+
+func TestJournalRejectsFlippedChecksum(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	state := sampleState("x")
+
+	st := journalStore(path, 100)
+	if err := st.Save(state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	changed := cloneState(state)
+	changed.Tasks[0].Text = "Alterada"
+	if err := st.Autosave(changed); err != nil {
+		t.Fatalf("autosave failed: %v", err)
+	}
+
+	data, err := os.ReadFile(st.JournalPath())
+	if err != nil {
+		t.Fatalf("read journal failed: %v", err)
+	}
+	data = bytes.Replace(data, []byte("Alterada"), []byte("Adulterada"), 1)
+	if err := os.WriteFile(st.JournalPath(), data, 0o644); err != nil {
+		t.Fatalf("write journal failed: %v", err)
+	}
+
+	got, _, err := journalStore(path, 100).LoadWithRecovery()
+	if err != nil {
+		t.Fatalf("recovery failed: %v", err)
+	}
+	if !reflect.DeepEqual(state, got) {
+		t.Fatalf("expected tampered record to be discarded")
+	}
+}
+
+func TestEncryptedJournalHidesRecords(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	opts := Options{Passphrase: "pw", Encrypt: true, Journal: true}
+	state := sampleState("e")
+
+	st := New(path, opts)
+	if err := st.Save(state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	changed := cloneState(state)
+	changed.Tasks[0].Text = "segredo"
+	if err := st.Autosave(changed); err != nil {
+		t.Fatalf("autosave failed: %v", err)
+	}
+
+	raw, err := os.ReadFile(st.JournalPath())
+	if err != nil {
+		t.Fatalf("read journal failed: %v", err)
+	}
+	if bytes.Contains(raw, []byte("segredo")) {
+		t.Fatalf("expected journal records to be encrypted")
+	}
+
+	got, err := New(path, opts).Load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if !reflect.DeepEqual(changed, got) {
+		t.Fatalf("encrypted replay mismatch")
+	}
+
+	wrong := opts
+	wrong.Passphrase = "nope"
+	if _, _, err := New(path, wrong).LoadWithRecovery(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("expected ErrWrongPassphrase, got %v", err)
+	}
+}
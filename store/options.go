@@ -0,0 +1,91 @@
+package store
+
+import "time"
+
+// Options configures how a Store persists state.
+type Options struct {
+	Backup BackupPolicy
+	// Passphrase decrypts encrypted state and backups.
+	Passphrase string
+	// Encrypt seals new writes with a key derived from Passphrase
+	// (PBKDF2-SHA256 + AES-256-GCM). Plain files are still readable.
+	Encrypt bool
+	// Journal appends one checksummed record per save to `<path>.journal`
+	// instead of rewriting the whole file; the snapshot is refreshed every
+	// CompactEvery records.
+	Journal      bool
+	CompactEvery int
+}
+
+// BackupPolicy controls where snapshots go and how many are kept.
+type BackupPolicy struct {
+	// Dir holds the backups; empty means next to the state file.
+	Dir string
+	// Keep is how many of the newest rotating snapshots are always kept.
+	Keep int
+	// MinInterval throttles rotating snapshots: a new one is only taken when
+	// the newest is at least this old. The `.bak` file is refreshed regardless.
+	MinInterval time.Duration
+	// Tiers keep one snapshot per Every window for snapshots younger than For,
+	// in addition to the Keep newest ones.
+	Tiers []RetentionTier
+}
+
+// RetentionTier keeps the newest snapshot of each Every-sized window up to For old.
+type RetentionTier struct {
+	Every time.Duration
+	For   time.Duration
+}
+
+// DefaultOptions keeps the last ten snapshots plus hourly ones for a day
+// and daily ones for a month, taking at most one snapshot per minute.
+func DefaultOptions() Options {
+	return Options{
+		Backup: BackupPolicy{
+			Keep:        maxRotatingBackups,
+			MinInterval: time.Minute,
+			Tiers: []RetentionTier{
+				{Every: time.Hour, For: 24 * time.Hour},
+				{Every: 24 * time.Hour, For: 30 * 24 * time.Hour},
+			},
+		},
+	}
+}
+
+func (o Options) withDefaults() Options {
+	if o.Backup.Keep <= 0 {
+		o.Backup.Keep = maxRotatingBackups
+	}
+	if o.Backup.MinInterval < 0 {
+		o.Backup.MinInterval = 0
+	}
+	return o
+}
+
+// retained marks which snapshots (newest first) survive pruning at now.
+func (p BackupPolicy) retained(snapshots []snapshot, now time.Time) []bool {
+	keep := make([]bool, len(snapshots))
+	for i := range snapshots {
+		if i < p.Keep {
+			keep[i] = true
+		}
+	}
+	for _, tier := range p.Tiers {
+		if tier.Every <= 0 {
+			continue
+		}
+		seen := make(map[int64]bool)
+		for i, snap := range snapshots {
+			if tier.For > 0 && now.Sub(snap.takenAt) > tier.For {
+				continue
+			}
+			window := snap.takenAt.Truncate(tier.Every).Unix()
+			if seen[window] {
+				continue
+			}
+			seen[window] = true
+			keep[i] = true
+		}
+	}
+	return keep
+}
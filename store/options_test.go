@@ -0,0 +1,98 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAutosaveThrottlesRotatingSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	st := New(path, Options{Backup: BackupPolicy{Keep: 10, MinInterval: time.Hour}})
+
+	if err := st.Save(sampleState("seed")); err != nil {
+		t.Fatalf("seed save failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := st.Autosave(sampleState("burst")); err != nil {
+			t.Fatalf("autosave %d failed: %v", i, err)
+		}
+	}
+
+	files, err := filepath.Glob(path + ".bak.*")
+	if err != nil {
+		t.Fatalf("glob failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected a single snapshot for a burst of edits, got %d", len(files))
+	}
+	if _, err := os.Stat(path + ".bak"); err != nil {
+		t.Fatalf("expected latest .bak to be refreshed: %v", err)
+	}
+}
+
+func TestRetentionTiersKeepOneSnapshotPerWindow(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	policy := BackupPolicy{
+		Keep: 2,
+		Tiers: []RetentionTier{
+			{Every: time.Hour, For: 24 * time.Hour},
+			{Every: 24 * time.Hour, For: 7 * 24 * time.Hour},
+		},
+	}
+	at := func(d time.Duration) snapshot {
+		return snapshot{takenAt: now.Add(-d)}
+	}
+	snapshots := []snapshot{
+		at(1 * time.Minute),  // keep (newest)
+		at(2 * time.Minute),  // keep (second newest)
+		at(3 * time.Minute),  // same hour as the two above: pruned
+		at(90 * time.Minute), // newest of its hour
+		at(100 * time.Minute),
+		at(3 * 24 * time.Hour), // newest of its day
+		at(3*24*time.Hour + time.Hour),
+		at(30 * 24 * time.Hour), // older than every tier
+	}
+
+	got := policy.retained(snapshots, now)
+	want := []bool{true, true, false, true, false, true, false, false}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("snapshot %d: expected keep=%v, got %v (all=%v)", i, want[i], got[i], got)
+		}
+	}
+}
+
+func TestBackupDirSeparatesSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	backupDir := filepath.Join(dir, "backups")
+	st := New(path, Options{Backup: BackupPolicy{Dir: backupDir}})
+
+	if err := st.Save(sampleState("v1")); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if err := st.Autosave(sampleState("v2")); err != nil {
+		t.Fatalf("autosave failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(backupDir, "state.json.bak")); err != nil {
+		t.Fatalf("expected .bak inside backup dir: %v", err)
+	}
+	if matches, _ := filepath.Glob(path + ".bak*"); len(matches) != 0 {
+		t.Fatalf("expected no backups next to state file, got %v", matches)
+	}
+
+	if err := os.WriteFile(path, []byte("{broken"), 0o644); err != nil {
+		t.Fatalf("corrupt write failed: %v", err)
+	}
+	recovered, status, err := st.LoadWithRecovery()
+	if err != nil {
+		t.Fatalf("recovery failed: %v", err)
+	}
+	if status == "" || recovered.Lists[0].Name != "Inbox-v1" {
+		t.Fatalf("expected recovery from backup dir, got status=%q lists=%+v", status, recovered.Lists)
+	}
+}
@@ -1,6 +1,8 @@
 package store
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,8 +11,10 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"todo-cli/i18n"
 	"todo-cli/model"
 )
 
@@ -18,23 +22,108 @@ const maxRotatingBackups = 10
 
 var errNoValidBackup = errors.New("no valid backup found")
 
+// ErrChecksumMismatch means the file parsed as JSON but its content does not
+// match the checksum written with it (e.g. a truncated array or a torn write).
+var ErrChecksumMismatch = errors.New("state checksum mismatch")
+
+const checksumPrefix = "sha256:"
+
+// Store persists state to a single file using the given options.
+// The package-level functions use a Store with DefaultOptions.
+type Store struct {
+	path string
+	opts Options
+	keys *keyCache
+
+	mu      sync.Mutex
+	journal journalState
+}
+
+// New returns a Store for the state file at path.
+func New(path string, opts Options) *Store {
+	return &Store{path: path, opts: opts.withDefaults(), keys: newKeyCache()}
+}
+
+// Path returns the state file location.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Options returns the effective options.
+func (s *Store) Options() Options {
+	return s.opts
+}
+
 // Load reads app state from a JSON file.
 // If file does not exist, it returns an initialized empty state.
 func Load(path string) (model.AppState, error) {
-	data, err := os.ReadFile(path)
+	return New(path, DefaultOptions()).Load()
+}
+
+// LoadWithRecovery loads state and tries automatic recovery when the main JSON is corrupted.
+// It returns an optional status message to be shown to the user.
+func LoadWithRecovery(path string) (model.AppState, string, error) {
+	return New(path, DefaultOptions()).LoadWithRecovery()
+}
+
+// Save writes app state to path as JSON.
+func Save(path string, state model.AppState) error {
+	return New(path, DefaultOptions()).Save(state)
+}
+
+// Autosave writes safely using temporary file + atomic rename.
+// It also stores a latest backup (.bak) and a rotating timestamped backup set.
+func Autosave(path string, state model.AppState) error {
+	return New(path, DefaultOptions()).Autosave(state)
+}
+
+// Load reads app state from the store file.
+// If file does not exist, it returns an initialized empty state.
+// In journal mode the journal is replayed on top of the snapshot.
+func (s *Store) Load() (model.AppState, error) {
+	state, err := s.loadSnapshot()
+	if err != nil || !s.opts.Journal {
+		return state, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, _, err = s.loadJournaledLocked(state, false)
+	return state, err
+}
+
+func (s *Store) loadSnapshot() (model.AppState, error) {
+	data, err := os.ReadFile(s.path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return model.NewState(), nil
 		}
 		return model.AppState{}, err
 	}
-	return decodeState(data)
+	return s.decode(data)
 }
 
 // LoadWithRecovery loads state and tries automatic recovery when the main JSON is corrupted.
 // It returns an optional status message to be shown to the user.
-func LoadWithRecovery(path string) (model.AppState, string, error) {
-	state, err := Load(path)
+// A wrong passphrase is reported as ErrWrongPassphrase and never triggers recovery.
+func (s *Store) LoadWithRecovery() (model.AppState, string, error) {
+	state, msg, err := s.loadSnapshotWithRecovery()
+	if err != nil || !s.opts.Journal {
+		return state, msg, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, journalMsg, err := s.loadJournaledLocked(state, true)
+	if err != nil {
+		return model.AppState{}, "", err
+	}
+	if msg != "" && journalMsg != "" {
+		msg += "; "
+	}
+	return state, msg + journalMsg, nil
+}
+
+func (s *Store) loadSnapshotWithRecovery() (model.AppState, string, error) {
+	state, err := s.loadSnapshot()
 	if err == nil {
 		return state, "", nil
 	}
@@ -42,54 +131,110 @@ func LoadWithRecovery(path string) (model.AppState, string, error) {
 		return model.AppState{}, "", err
 	}
 
-	corruptPath, moveErr := moveCorruptFile(path)
+	corruptPath, moveErr := moveCorruptFile(s.path)
 	if moveErr != nil {
-		return model.AppState{}, "", fmt.Errorf("falha ao mover arquivo corrompido: %w", moveErr)
+		return model.AppState{}, "", i18n.Errorf("store.move_corrupt_failed", moveErr)
 	}
 
-	recoveredState, backupPath, backupErr := loadLatestValidBackup(path)
+	recoveredState, backupPath, backupErr := s.loadLatestValidBackup()
 	if backupErr == nil {
-		if err := Save(path, recoveredState); err != nil {
-			return model.AppState{}, "", fmt.Errorf("falha ao restaurar backup: %w", err)
+		if err := s.saveSnapshot(recoveredState); err != nil {
+			return model.AppState{}, "", i18n.Errorf("store.restore_failed", err)
 		}
-		msg := fmt.Sprintf("Estado corrompido recuperado de %s", filepath.Base(backupPath))
+		msg := i18n.T("store.recovered", filepath.Base(backupPath))
 		if corruptPath != "" {
-			msg += fmt.Sprintf(" (arquivo ruim movido para %s)", filepath.Base(corruptPath))
+			msg += i18n.T("store.moved_bad_file", filepath.Base(corruptPath))
 		}
 		return recoveredState, msg, nil
 	}
 	if !errors.Is(backupErr, errNoValidBackup) {
-		return model.AppState{}, "", fmt.Errorf("falha ao inspecionar backups: %w", backupErr)
+		return model.AppState{}, "", i18n.Errorf("store.inspect_failed", backupErr)
 	}
 
 	empty := model.NewState()
-	if err := Save(path, empty); err != nil {
-		return model.AppState{}, "", fmt.Errorf("falha ao inicializar novo estado após corrupção: %w", err)
+	if err := s.saveSnapshot(empty); err != nil {
+		return model.AppState{}, "", i18n.Errorf("store.reset_failed", err)
 	}
-	msg := "Estado corrompido sem backup válido; iniciado com estado vazio"
+	msg := i18n.T("store.reset")
 	if corruptPath != "" {
-		msg += fmt.Sprintf(" (arquivo ruim movido para %s)", filepath.Base(corruptPath))
+		msg += i18n.T("store.moved_bad_file", filepath.Base(corruptPath))
 	}
 	return empty, msg, nil
 }
 
-// Save writes app state to path as JSON.
-func Save(path string, state model.AppState) error {
-	return writeJSON(path, state)
+// Save writes app state to the store file as JSON, encrypted when enabled.
+// In journal mode the snapshot becomes authoritative and the journal is emptied.
+func (s *Store) Save(state model.AppState) error {
+	if !s.opts.Journal {
+		return s.saveSnapshot(state)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.saveSnapshot(state); err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.JournalPath(), nil, s.fileMode()); err != nil {
+		return err
+	}
+	s.rememberLocked(state, 0)
+	return nil
+}
+
+func (s *Store) saveSnapshot(state model.AppState) error {
+	if err := ensureDir(s.path); err != nil {
+		return err
+	}
+	data, err := s.encode(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, s.fileMode())
 }
 
 // Autosave writes safely using temporary file + atomic rename.
 // It also stores a latest backup (.bak) and a rotating timestamped backup set.
-func Autosave(path string, state model.AppState) error {
-	if err := ensureDir(path); err != nil {
-		return err
+// In journal mode it appends one record instead, backing up the state it
+// replaces, and only rewrites the snapshot when the journal is compacted;
+// while a Service is attached (see Attach) its changes are already
+// journaled and state is not used.
+func (s *Store) Autosave(state model.AppState) error {
+	if !s.opts.Journal {
+		return s.autosaveSnapshot(state)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.journal.attached > 0 {
+		return s.retryLocked()
+	}
+	return s.appendJournalLocked("", state)
+}
+
+// replace writes state as a whole, even while a Service is attached.
+func (s *Store) replace(state model.AppState) error {
+	if !s.opts.Journal {
+		return s.autosaveSnapshot(state)
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appendJournalLocked(OpReplace, state)
+}
 
-	if err := backup(path); err != nil {
+func (s *Store) autosaveSnapshot(state model.AppState) error {
+	if err := ensureDir(s.path); err != nil {
 		return err
 	}
+	if err := s.backup(time.Now().UTC(), false); err != nil {
+		return err
+	}
+	return s.writeSnapshot(state)
+}
 
-	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-")
+// writeSnapshot replaces the state file atomically, without a backup.
+func (s *Store) writeSnapshot(state model.AppState) error {
+	if err := ensureDir(s.path); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-")
 	if err != nil {
 		return err
 	}
@@ -98,9 +243,16 @@ func Autosave(path string, state model.AppState) error {
 		_ = os.Remove(tmpName)
 	}()
 
-	enc := json.NewEncoder(tmp)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(state); err != nil {
+	data, err := s.encode(state)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(s.fileMode()); err != nil {
 		_ = tmp.Close()
 		return err
 	}
@@ -113,7 +265,7 @@ func Autosave(path string, state model.AppState) error {
 		return err
 	}
 
-	return os.Rename(tmpName, path)
+	return os.Rename(tmpName, s.path)
 }
 
 func decodeState(data []byte) (model.AppState, error) {
@@ -121,6 +273,9 @@ func decodeState(data []byte) (model.AppState, error) {
 	if err := json.Unmarshal(data, &state); err != nil {
 		return model.AppState{}, err
 	}
+	if err := verifyChecksum(&state); err != nil {
+		return model.AppState{}, err
+	}
 
 	if state.Lists == nil {
 		state.Lists = []model.List{}
@@ -144,16 +299,72 @@ func decodeState(data []byte) (model.AppState, error) {
 	return state, nil
 }
 
-func writeJSON(path string, state model.AppState) error {
-	if err := ensureDir(path); err != nil {
+// stateChecksum hashes the compact JSON of state without its checksum field,
+// so reformatting the file keeps it valid but changing any value does not.
+func stateChecksum(state model.AppState) (string, error) {
+	state.Metadata.Checksum = ""
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return checksumPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// verifyChecksum checks and clears the stored checksum. Files written before
+// checksums existed have none and are accepted as is.
+func verifyChecksum(state *model.AppState) error {
+	stored := state.Metadata.Checksum
+	state.Metadata.Checksum = ""
+	if stored == "" {
+		return nil
+	}
+	sum, err := stateChecksum(*state)
+	if err != nil {
 		return err
 	}
+	if sum != stored {
+		return i18n.Errorf("store.checksum_mismatch", ErrChecksumMismatch, stored, sum)
+	}
+	return nil
+}
+
+// encode renders state as indented JSON, sealed in an envelope when encryption is on.
+func (s *Store) encode(state model.AppState) ([]byte, error) {
+	sum, err := stateChecksum(state)
+	if err != nil {
+		return nil, err
+	}
+	state.Metadata.Checksum = sum
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	data = append(data, '\n')
-	return os.WriteFile(path, data, 0o644)
+	if !s.opts.Encrypt {
+		return data, nil
+	}
+	return s.keys.seal(s.opts.Passphrase, data)
+}
+
+// decode accepts both plain and encrypted files, so enabling or disabling
+// encryption never locks the user out of existing state or backups.
+func (s *Store) decode(data []byte) (model.AppState, error) {
+	if isEncrypted(data) {
+		plain, err := s.keys.open(s.opts.Passphrase, data)
+		if err != nil {
+			return model.AppState{}, err
+		}
+		data = plain
+	}
+	return decodeState(data)
+}
+
+func (s *Store) fileMode() os.FileMode {
+	if s.opts.Encrypt {
+		return 0o600
+	}
+	return 0o644
 }
 
 func ensureDir(path string) error {
@@ -161,8 +372,18 @@ func ensureDir(path string) error {
 	return os.MkdirAll(dir, 0o755)
 }
 
-func backup(path string) error {
-	data, err := os.ReadFile(path)
+// backupBase is the path prefix shared by the `.bak` and `.bak.<timestamp>` files.
+func (s *Store) backupBase() string {
+	if s.opts.Backup.Dir == "" {
+		return s.path
+	}
+	return filepath.Join(s.opts.Backup.Dir, filepath.Base(s.path))
+}
+
+// backup copies the state file to `.bak` and, unless force is false and the
+// newest rotating snapshot is younger than MinInterval, to a new rotating one.
+func (s *Store) backup(now time.Time, force bool) error {
+	data, err := os.ReadFile(s.path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return nil
@@ -170,45 +391,120 @@ func backup(path string) error {
 		return err
 	}
 
-	if err := os.WriteFile(path+".bak", data, 0o644); err != nil {
-		return err
+	if s.opts.Encrypt && !isEncrypted(data) {
+		// Primeiro save após ligar a criptografia: o backup não fica em claro.
+		if data, err = s.keys.seal(s.opts.Passphrase, data); err != nil {
+			return err
+		}
 	}
 
-	timestamp := time.Now().UTC().Format("20060102-150405.000000000")
-	rotatingPath := fmt.Sprintf("%s.bak.%s", path, timestamp)
-	if err := os.WriteFile(rotatingPath, data, 0o644); err != nil {
+	return s.writeBackup(data, now, force)
+}
+
+// backupState backs up state as backup would back up the file holding it.
+func (s *Store) backupState(state model.AppState, now time.Time) error {
+	data, err := s.encode(state)
+	if err != nil {
 		return err
 	}
-
-	return pruneRotatingBackups(path)
+	return s.writeBackup(data, now, false)
 }
 
-func pruneRotatingBackups(path string) error {
-	files, err := filepath.Glob(path + ".bak.*")
+func (s *Store) writeBackup(data []byte, now time.Time, force bool) error {
+	base := s.backupBase()
+	if err := ensureDir(base); err != nil {
+		return err
+	}
+	if err := os.WriteFile(base+".bak", data, s.fileMode()); err != nil {
+		return err
+	}
+
+	snapshots, err := s.rotatingSnapshots()
 	if err != nil {
 		return err
 	}
-	if len(files) <= maxRotatingBackups {
+	if !force && len(snapshots) > 0 && now.Sub(snapshots[0].takenAt) < s.opts.Backup.MinInterval {
+		// Rajadas de edição só atualizam o .bak; a rotação fica estável.
 		return nil
 	}
 
-	sort.Strings(files)
-	toDelete := files[:len(files)-maxRotatingBackups]
-	for _, old := range toDelete {
-		if err := os.Remove(old); err != nil && !errors.Is(err, os.ErrNotExist) {
+	rotatingPath := fmt.Sprintf("%s.bak.%s", base, now.Format(backupTimestampLayout))
+	if err := os.WriteFile(rotatingPath, data, s.fileMode()); err != nil {
+		return err
+	}
+
+	return s.pruneRotatingBackups(now)
+}
+
+// ForceBackup writes a rotating snapshot of the stored state right away,
+// regardless of MinInterval. In journal mode the journal is compacted first,
+// so the snapshot holds every change appended so far.
+func (s *Store) ForceBackup() error {
+	if s.opts.Journal {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.ensureJournalBaseLocked(); err != nil {
+			return err
+		}
+		if err := s.compactLocked(*s.journal.last); err != nil {
+			return err
+		}
+	}
+	return s.backup(time.Now().UTC(), true)
+}
+
+type snapshot struct {
+	path    string
+	takenAt time.Time
+}
+
+// rotatingSnapshots returns the timestamped backups, newest first.
+func (s *Store) rotatingSnapshots() ([]snapshot, error) {
+	base := s.backupBase()
+	files, err := filepath.Glob(base + ".bak.*")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]snapshot, 0, len(files))
+	for _, f := range files {
+		ts, err := time.Parse(backupTimestampLayout, strings.TrimPrefix(f, base+".bak."))
+		if err != nil {
+			// Arquivos com sufixo desconhecido não participam da rotação.
+			continue
+		}
+		out = append(out, snapshot{path: f, takenAt: ts})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].takenAt.After(out[j].takenAt)
+	})
+	return out, nil
+}
+
+func (s *Store) pruneRotatingBackups(now time.Time) error {
+	snapshots, err := s.rotatingSnapshots()
+	if err != nil {
+		return err
+	}
+	keep := s.opts.Backup.retained(snapshots, now)
+	for i, snap := range snapshots {
+		if keep[i] {
+			continue
+		}
+		if err := os.Remove(snap.path); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return err
 		}
 	}
 	return nil
 }
 
-func loadLatestValidBackup(path string) (model.AppState, string, error) {
+func (s *Store) loadLatestValidBackup() (model.AppState, string, error) {
+	base := s.backupBase()
 	candidates := make([]string, 0, 12)
-	latest := path + ".bak"
+	latest := base + ".bak"
 	if _, err := os.Stat(latest); err == nil {
 		candidates = append(candidates, latest)
 	}
-	rotating, err := filepath.Glob(path + ".bak.*")
+	rotating, err := filepath.Glob(base + ".bak.*")
 	if err != nil {
 		return model.AppState{}, "", err
 	}
@@ -231,7 +527,7 @@ func loadLatestValidBackup(path string) (model.AppState, string, error) {
 		if err != nil {
 			continue
 		}
-		state, err := decodeState(data)
+		state, err := s.decode(data)
 		if err != nil {
 			continue
 		}
@@ -269,5 +565,8 @@ func isCorruptStateError(err error) bool {
 	if errors.As(err, &typeErr) {
 		return true
 	}
+	if errors.Is(err, ErrCorruptCiphertext) || errors.Is(err, ErrCorruptJournal) || errors.Is(err, ErrChecksumMismatch) {
+		return true
+	}
 	return errors.Is(err, io.ErrUnexpectedEOF)
 }
@@ -1,10 +1,12 @@
 package store
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -277,3 +279,38 @@ func TestLoadLegacyJSONWithoutNewFields(t *testing.T) {
 		t.Fatalf("expected default position 0 from legacy JSON, got %d", state.Tasks[0].Position)
 	}
 }
+
+func TestLoadDetectsChecksumMismatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	if err := Autosave(path, sampleState("a")); err != nil {
+		t.Fatalf("first autosave failed: %v", err)
+	}
+	if err := Autosave(path, sampleState("b")); err != nil {
+		t.Fatalf("second autosave failed: %v", err)
+	}
+
+	// JSON ainda válido, mas sem a última tarefa: o checksum denuncia.
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	tampered := strings.Replace(string(raw), `"text": "Task-b"`, `"text": "Task-"`, 1)
+	if tampered == string(raw) {
+		t.Fatalf("expected fixture to contain task text")
+	}
+	if err := os.WriteFile(path, []byte(tampered), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	if _, err := Load(path); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+	got, msg, err := LoadWithRecovery(path)
+	if err != nil {
+		t.Fatalf("recovery failed: %v", err)
+	}
+	if msg == "" || !reflect.DeepEqual(sampleState("a"), got) {
+		t.Fatalf("expected recovery from backup, got msg=%q state=%+v", msg, got)
+	}
+}
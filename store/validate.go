@@ -0,0 +1,293 @@
+package store
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// Severity ranks validation issues.
+type Severity int
+
+const (
+	// SeverityWarning is harmless at runtime but worth cleaning up.
+	SeverityWarning Severity = iota
+	// SeverityError breaks invariants the app relies on (unique IDs, valid references).
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return i18n.T("validate.error")
+	}
+	return i18n.T("validate.warning")
+}
+
+// Issue codes reported by Validate.
+const (
+	IssueEmptyListID        = "empty-list-id"
+	IssueDuplicateListID    = "duplicate-list-id"
+	IssueEmptyTaskID        = "empty-task-id"
+	IssueDuplicateTaskID    = "duplicate-task-id"
+	IssueOrphanTask         = "orphan-task"
+	IssueBrokenPositions    = "broken-positions"
+	IssueInvalidPriority    = "invalid-priority"
+	IssueDuplicateArchiveID = "duplicate-archive-id"
+	IssueDanglingSession    = "dangling-session-list"
+	IssueInvalidFilter      = "invalid-filter"
+	IssueInvalidFocus       = "invalid-focus"
+)
+
+// OrphanListName returns the name, in the active locale, of the list Repair
+// moves tasks with an unknown ListID into.
+func OrphanListName() string {
+	return i18n.T("validate.orphan_list")
+}
+
+// isOrphanList reports whether name is the orphan list in any locale, so a
+// repair after switching languages reuses it.
+func isOrphanList(name string) bool {
+	for _, l := range i18n.Locales() {
+		if name == i18n.In(l, "validate.orphan_list") {
+			return true
+		}
+	}
+	return false
+}
+
+// Issue is one problem found in a state.
+type Issue struct {
+	Severity Severity
+	Code     string
+	Message  string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("[%s] %s: %s", i.Severity, i.Code, i.Message)
+}
+
+// Validate reports structural problems that load silently but break the
+// app's invariants. It does not modify state.
+func Validate(state model.AppState) []Issue {
+	var issues []Issue
+	add := func(sev Severity, code, key string, args ...any) {
+		issues = append(issues, Issue{Severity: sev, Code: code, Message: i18n.T(key, args...)})
+	}
+
+	lists := make(map[string]bool, len(state.Lists))
+	for i, l := range state.Lists {
+		switch {
+		case strings.TrimSpace(l.ID) == "":
+			add(SeverityError, IssueEmptyListID, "validate.empty_list_id", i+1, l.Name)
+		case lists[l.ID]:
+			add(SeverityError, IssueDuplicateListID, "validate.dup_list_id", l.ID, l.Name)
+		}
+		lists[l.ID] = true
+	}
+
+	tasks := make(map[string]bool, len(state.Tasks))
+	positions := make(map[string][]int)
+	for i, t := range state.Tasks {
+		switch {
+		case strings.TrimSpace(t.ID) == "":
+			add(SeverityError, IssueEmptyTaskID, "validate.empty_task_id", i+1, t.Text)
+		case tasks[t.ID]:
+			add(SeverityError, IssueDuplicateTaskID, "validate.dup_task_id", t.ID, t.Text)
+		}
+		tasks[t.ID] = true
+		if !lists[t.ListID] {
+			add(SeverityError, IssueOrphanTask, "validate.orphan_task", t.ID, t.Text, t.ListID)
+		}
+		if !validPriority(t.Priority) {
+			add(SeverityWarning, IssueInvalidPriority, "validate.task_priority", t.ID, t.Priority)
+		}
+		positions[t.ListID] = append(positions[t.ListID], t.Position)
+	}
+
+	listIDs := make([]string, 0, len(positions))
+	for id := range positions {
+		listIDs = append(listIDs, id)
+	}
+	sort.Strings(listIDs)
+	for _, id := range listIDs {
+		if !sequentialPositions(positions[id]) {
+			add(SeverityWarning, IssueBrokenPositions, "validate.positions", id, len(positions[id]))
+		}
+	}
+
+	archived := make(map[string]bool, len(state.ArchivedCompleted))
+	for _, a := range state.ArchivedCompleted {
+		if archived[a.ID] {
+			add(SeverityWarning, IssueDuplicateArchiveID, "validate.dup_archive_id", a.ID, a.TaskText)
+		}
+		archived[a.ID] = true
+		if !validPriority(a.Priority) {
+			add(SeverityWarning, IssueInvalidPriority, "validate.archive_priority", a.ID, a.Priority)
+		}
+	}
+
+	if active := state.Metadata.Session.ActiveListID; active != "" && !lists[active] {
+		add(SeverityWarning, IssueDanglingSession, "validate.session_list", active)
+	}
+	switch state.Filter {
+	case model.FilterAll, model.FilterTodo, model.FilterDone:
+	default:
+		add(SeverityWarning, IssueInvalidFilter, "validate.filter", state.Filter)
+	}
+	switch state.Metadata.Session.Focus {
+	case model.SessionFocusLists, model.SessionFocusTasks:
+	default:
+		add(SeverityWarning, IssueInvalidFocus, "validate.focus", state.Metadata.Session.Focus)
+	}
+	return issues
+}
+
+// HasErrors reports whether any issue is an error.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Repair fixes everything Validate reports and returns the repaired state
+// with the issues it addressed. Duplicated IDs get fresh ones (exact
+// duplicate tasks are dropped), orphan tasks go to the OrphanListName list
+// and positions are renumbered keeping the current order.
+func Repair(state model.AppState) (model.AppState, []Issue) {
+	fixed := Validate(state)
+	if len(fixed) == 0 {
+		return state, nil
+	}
+	state = cloneState(state)
+	now := time.Now().UTC()
+
+	seenLists := make(map[string]bool, len(state.Lists))
+	for i := range state.Lists {
+		if strings.TrimSpace(state.Lists[i].ID) == "" || seenLists[state.Lists[i].ID] {
+			state.Lists[i].ID = repairID()
+			state.Lists[i].UpdatedAt = now
+		}
+		seenLists[state.Lists[i].ID] = true
+	}
+
+	seenTasks := make(map[string]model.Task, len(state.Tasks))
+	kept := make([]model.Task, 0, len(state.Tasks))
+	orphanListID := ""
+	for _, t := range state.Tasks {
+		if prev, ok := seenTasks[t.ID]; ok && reflect.DeepEqual(prev, t) {
+			continue
+		}
+		if _, ok := seenTasks[t.ID]; ok || strings.TrimSpace(t.ID) == "" {
+			t.ID = repairID()
+			t.UpdatedAt = now
+		}
+		if !seenLists[t.ListID] {
+			if orphanListID == "" {
+				orphanListID = orphanList(&state, now)
+				seenLists[orphanListID] = true
+			}
+			t.ListID = orphanListID
+			t.Position = 0
+			t.UpdatedAt = now
+		}
+		if !validPriority(t.Priority) {
+			t.Priority = model.PriorityNone
+		}
+		seenTasks[t.ID] = t
+		kept = append(kept, t)
+	}
+	state.Tasks = renumberPositions(kept)
+
+	seenArchived := make(map[string]bool, len(state.ArchivedCompleted))
+	for i := range state.ArchivedCompleted {
+		a := &state.ArchivedCompleted[i]
+		if seenArchived[a.ID] {
+			a.ID = repairID()
+		}
+		seenArchived[a.ID] = true
+		if !validPriority(a.Priority) {
+			a.Priority = model.PriorityNone
+		}
+	}
+
+	if active := state.Metadata.Session.ActiveListID; active != "" && !seenLists[active] {
+		state.Metadata.Session.ActiveListID = ""
+	}
+	switch state.Filter {
+	case model.FilterAll, model.FilterTodo, model.FilterDone:
+	default:
+		state.Filter = model.FilterAll
+	}
+	switch state.Metadata.Session.Focus {
+	case model.SessionFocusLists, model.SessionFocusTasks:
+	default:
+		state.Metadata.Session.Focus = model.SessionFocusLists
+	}
+	return state, fixed
+}
+
+func orphanList(state *model.AppState, now time.Time) string {
+	for _, l := range state.Lists {
+		if isOrphanList(l.Name) {
+			return l.ID
+		}
+	}
+	l := model.List{ID: repairID(), Name: OrphanListName(), Color: "red", CreatedAt: now, UpdatedAt: now}
+	state.Lists = append(state.Lists, l)
+	return l.ID
+}
+
+// renumberPositions gives each list positions 1..n, ordered by the current
+// position (unset ones last) and then by slice order, like the app does.
+func renumberPositions(tasks []model.Task) []model.Task {
+	grouped := make(map[string][]int)
+	for i := range tasks {
+		grouped[tasks[i].ListID] = append(grouped[tasks[i].ListID], i)
+	}
+	for _, indexes := range grouped {
+		sort.SliceStable(indexes, func(i, j int) bool {
+			a, b := tasks[indexes[i]].Position, tasks[indexes[j]].Position
+			if (a > 0) != (b > 0) {
+				return a > 0
+			}
+			return a > 0 && a < b
+		})
+		for order, idx := range indexes {
+			tasks[idx].Position = order + 1
+		}
+	}
+	return tasks
+}
+
+func sequentialPositions(positions []int) bool {
+	sorted := append([]int(nil), positions...)
+	sort.Ints(sorted)
+	for i, p := range sorted {
+		if p != i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func validPriority(p model.Priority) bool {
+	return p >= model.PriorityNone && p <= model.PriorityHigh
+}
+
+func repairID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("repair-%d", time.Now().UTC().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"testing"
+
+	"todo-cli/model"
+)
+
+func issueCodes(issues []Issue) map[string]Severity {
+	codes := make(map[string]Severity, len(issues))
+	for _, issue := range issues {
+		codes[issue.Code] = issue.Severity
+	}
+	return codes
+}
+
+func TestValidateCleanStateHasNoIssues(t *testing.T) {
+	if issues := Validate(sampleState("ok")); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}
+
+func TestValidateReportsAndRepairFixesBrokenState(t *testing.T) {
+	state := sampleState("v")
+	dup := state.Tasks[0]
+	changedDup := dup
+	changedDup.Text = "Outra"
+	orphan := dup
+	orphan.ID = "task-orphan"
+	orphan.ListID = "missing"
+	orphan.Text = "Órfã"
+	orphan.Priority = model.Priority(9)
+	state.Tasks = append(state.Tasks, dup, changedDup, orphan)
+	state.Metadata.Session.ActiveListID = "gone"
+
+	codes := issueCodes(Validate(state))
+	want := map[string]Severity{
+		IssueDuplicateTaskID: SeverityError,
+		IssueOrphanTask:      SeverityError,
+		IssueBrokenPositions: SeverityWarning,
+		IssueInvalidPriority: SeverityWarning,
+		IssueDanglingSession: SeverityWarning,
+	}
+	for code, sev := range want {
+		if got, ok := codes[code]; !ok || got != sev {
+			t.Fatalf("expected %s with severity %s, got %v", code, sev, codes)
+		}
+	}
+
+	repaired, fixed := Repair(state)
+	if len(fixed) == 0 {
+		t.Fatalf("expected repair to report fixed issues")
+	}
+	if issues := Validate(repaired); len(issues) != 0 {
+		t.Fatalf("expected repaired state to validate, got %v", issues)
+	}
+	if len(repaired.Tasks) != 3 {
+		t.Fatalf("expected exact duplicate to be dropped and others kept, got %d tasks", len(repaired.Tasks))
+	}
+	var recovered string
+	for _, l := range repaired.Lists {
+		if l.Name == OrphanListName() {
+			recovered = l.ID
+		}
+	}
+	if recovered == "" {
+		t.Fatalf("expected %q list to be created", OrphanListName())
+	}
+	for _, task := range repaired.Tasks {
+		if task.ID == orphan.ID && task.ListID != recovered {
+			t.Fatalf("expected orphan to move to %q, got list %q", OrphanListName(), task.ListID)
+		}
+	}
+	if repaired.Metadata.Session.ActiveListID != "" {
+		t.Fatalf("expected dangling session list to be cleared")
+	}
+}
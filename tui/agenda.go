@@ -0,0 +1,285 @@
+package tui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// Why a task is on the agenda, most urgent first. agendaNone keeps it off.
+const (
+	agendaOverdue = iota
+	agendaDueToday
+	agendaPinned
+	agendaHighPriority
+	agendaNone
+)
+
+// agendaRank says why an open task belongs on the agenda of day, a date as
+// returned by dateOf.
+func agendaRank(t model.Task, day time.Time) int {
+	if t.Done {
+		return agendaNone
+	}
+	if due, ok := t.Due(); ok {
+		switch {
+		case due.Before(day):
+			return agendaOverdue
+		case due.Equal(day):
+			return agendaDueToday
+		}
+	}
+	switch {
+	case t.Pinned:
+		return agendaPinned
+	case t.Priority == model.PriorityHigh:
+		return agendaHighPriority
+	}
+	return agendaNone
+}
+
+// dateOf drops the clock from a local time, giving the date in the form
+// model.Task.Due returns.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// agendaTasks picks the open tasks of all lists that are overdue, due
+// today, pinned or high priority. They are grouped by list in sidebar order
+// and, within a list, sorted most urgent first, keeping the list order
+// among equals.
+func agendaTasks(lists []model.List, tasks []model.Task, now time.Time) []model.Task {
+	day := dateOf(now)
+	order := make(map[string]int, len(lists))
+	for i, l := range lists {
+		order[l.ID] = i
+	}
+	out := make([]model.Task, 0)
+	for _, t := range tasks {
+		if _, ok := order[t.ListID]; ok && agendaRank(t, day) != agendaNone {
+			out = append(out, t)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b model.Task) int {
+		return cmp.Or(
+			cmp.Compare(order[a.ListID], order[b.ListID]),
+			cmp.Compare(agendaRank(a, day), agendaRank(b, day)),
+		)
+	})
+	return out
+}
+
+// agendaTasks is the agenda as the tasks panel shows it: it honors the
+// search but not the filter, since it only has open tasks.
+func (m *Model) agendaTasks() []model.Task {
+	tasks := agendaTasks(m.svc.Lists(), m.svc.Tasks(""), time.Now())
+	query := strings.ToLower(strings.TrimSpace(m.svc.State().Query))
+	if query == "" {
+		return tasks
+	}
+	return slices.DeleteFunc(tasks, func(t model.Task) bool {
+		return !strings.Contains(strings.ToLower(t.Text), query)
+	})
+}
+
+// agendaRow is a row of the agenda panel: a list header (task is -1) or a
+// task, as an index into the agenda tasks.
+type agendaRow struct {
+	listID string
+	task   int
+}
+
+// agendaRows puts a header above each list's tasks.
+func agendaRows(tasks []model.Task) []agendaRow {
+	rows := make([]agendaRow, 0, len(tasks))
+	for i, t := range tasks {
+		if i == 0 || tasks[i-1].ListID != t.ListID {
+			rows = append(rows, agendaRow{listID: t.ListID, task: -1})
+		}
+		rows = append(rows, agendaRow{listID: t.ListID, task: i})
+	}
+	return rows
+}
+
+func (m *Model) toggleAgenda() {
+	m.showAgenda = !m.showAgenda
+	m.taskCursor = 0
+	if m.showAgenda {
+		m.showHistory = false
+		m.showBoard = false
+		m.focusPane(focusTasks)
+		m.setStatus(i18n.T("status.agenda_opened"), false)
+		return
+	}
+	m.setStatus(i18n.T("status.agenda_closed"), false)
+}
+
+// agendaClick selects the task on a visible row of the agenda, ignoring
+// list headers, and toggles it when the click is on its checkbox.
+func (m *Model) agendaClick(row int, checkbox bool) {
+	rows := agendaRows(m.visibleTasks())
+	r := m.agendaOffset + row
+	if r >= len(rows) || rows[r].task < 0 {
+		return
+	}
+	m.taskCursor = rows[r].task
+	if checkbox {
+		m.toggleTaskDone()
+	}
+}
+
+func (m *Model) togglePin() {
+	if m.focus != focusTasks {
+		m.setStatus(i18n.T("focus.need_tasks.pin"), false)
+		return
+	}
+	if m.showHistory {
+		m.setStatus(i18n.T("status.history_read_only"), false)
+		return
+	}
+	task, ok := m.selectedTask()
+	if !ok {
+		m.setStatus(i18n.T("status.no_task_selected"), true)
+		return
+	}
+	task, err := m.svc.SetTaskPinned(task.ID, !task.Pinned)
+	if err != nil {
+		m.setStatus(i18n.T("error.pin", i18n.Error(err)), true)
+		return
+	}
+	if task.Pinned {
+		m.persist(i18n.T("status.pinned"))
+	} else {
+		m.persist(i18n.T("status.unpinned"))
+	}
+}
+
+func (m *Model) startSetDue() {
+	if m.focus != focusTasks {
+		m.setStatus(i18n.T("focus.need_tasks.due"), false)
+		return
+	}
+	if m.showHistory {
+		m.setStatus(i18n.T("status.history_read_only"), false)
+		return
+	}
+	task, ok := m.selectedTask()
+	if !ok {
+		m.setStatus(i18n.T("status.no_task_selected"), true)
+		return
+	}
+	m.startInput(modeSetDue, task.Extra[model.ExtraDue])
+}
+
+// applyDue saves the date typed in modeSetDue; an empty line clears it.
+func (m *Model) applyDue(text string) {
+	task, ok := m.selectedTask()
+	if !ok {
+		m.mode = modeNormal
+		m.input.Reset()
+		m.setStatus(i18n.T("status.no_task_selected"), true)
+		return
+	}
+	var due time.Time
+	if text != "" {
+		var err error
+		if due, err = time.Parse(model.DateLayout, text); err != nil {
+			m.setStatus(i18n.T("status.due_invalid", text), true)
+			return
+		}
+	}
+	if _, err := m.svc.SetTaskDue(task.ID, due); err != nil {
+		m.setStatus(i18n.T("error.due", i18n.Error(err)), true)
+		return
+	}
+	m.mode = modeNormal
+	m.input.Reset()
+	// Na agenda a tarefa pode sair ou mudar de lugar com o novo prazo.
+	m.taskCursor = m.indexOfTask(task.ID)
+	if due.IsZero() {
+		m.persist(i18n.T("status.due_cleared"))
+		return
+	}
+	m.persist(i18n.T("status.due_set", due.Format(i18n.T("format.date"))))
+}
+
+// taskBadges marks pinned tasks and shows the due date, highlighted when it
+// is today and in the error color when it has passed on an open task.
+func (m *Model) taskBadges(t model.Task, day time.Time) string {
+	var badges []string
+	if t.Pinned {
+		badges = append(badges, m.theme.style(styleMarker).Render("★"))
+	}
+	if due, ok := t.Due(); ok {
+		style := m.theme.style(styleMuted)
+		switch {
+		case t.Done:
+		case due.Before(day):
+			style = m.theme.style(styleStatusError)
+		case due.Equal(day):
+			style = m.theme.style(styleMarker).Bold(true)
+		}
+		badges = append(badges, style.Render(due.Format(i18n.T("format.date"))))
+	}
+	if len(badges) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(badges, " ")
+}
+
+// renderAgendaPanel draws the agenda grouped by list, each group under the
+// list's name and color.
+func (m *Model) renderAgendaPanel(width, height int) string {
+	tasks := m.visibleTasks()
+	rows := agendaRows(tasks)
+	isActive := m.focus == focusTasks
+	day := dateOf(time.Now())
+
+	overdue := 0
+	for _, t := range tasks {
+		if agendaRank(t, day) == agendaOverdue {
+			overdue++
+		}
+	}
+	cursorRow := max(slices.IndexFunc(rows, func(r agendaRow) bool { return r.task == m.taskCursor }), 0)
+	n, scroll := m.scrollRows(height, len(rows), cursorRow, &m.agendaOffset)
+	titleLine := lipgloss.JoinHorizontal(lipgloss.Left,
+		m.theme.panelTitle(i18n.T("panel.agenda"), isActive),
+		"  ",
+		m.theme.style(styleMuted).Render(i18n.T("panel.agenda_meta", len(tasks), overdue)),
+		scroll,
+	)
+
+	lines := make([]string, 0, n+2)
+	lines = append(lines, titleLine)
+	if len(tasks) == 0 {
+		msg := i18n.T("panel.agenda_empty", m.keys.keyFor(actionPin), m.keys.keyFor(actionDue))
+		if strings.TrimSpace(m.svc.State().Query) != "" {
+			msg = i18n.T("panel.no_match_query")
+		}
+		lines = append(lines, m.theme.style(styleMuted).Render(msg))
+		return renderPanel(width, height, lines)
+	}
+
+	names := make(map[string]model.List)
+	for _, l := range m.svc.Lists() {
+		names[l.ID] = l
+	}
+	for r := m.agendaOffset; r < m.agendaOffset+n; r++ {
+		row := rows[r]
+		if row.task < 0 {
+			l := names[row.listID]
+			dot := lipgloss.NewStyle().Foreground(colorForName(l.Color)).Render("●")
+			lines = append(lines, dot+" "+lipgloss.NewStyle().Bold(true).Render(l.Name))
+			continue
+		}
+		lines = append(lines, m.renderTaskRow(tasks[row.task], row.task == m.taskCursor, isActive, day))
+	}
+	return renderPanel(width, height, lines)
+}
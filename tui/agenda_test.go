@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+func TestAgendaTasksPicksAndOrdersAcrossLists(t *testing.T) {
+	now := time.Date(2026, 3, 10, 18, 30, 0, 0, time.Local)
+	lists := []model.List{{ID: "home"}, {ID: "work"}}
+	due := func(date string) map[string]string { return map[string]string{model.ExtraDue: date} }
+	tasks := []model.Task{
+		{ID: "w-high", ListID: "work", Priority: model.PriorityHigh},
+		{ID: "w-pinned", ListID: "work", Pinned: true},
+		{ID: "w-today", ListID: "work", Extra: due("2026-03-10")},
+		{ID: "w-later", ListID: "work", Extra: due("2026-03-11")},
+		{ID: "h-overdue", ListID: "home", Extra: due("2026-03-09")},
+		{ID: "h-done", ListID: "home", Done: true, Pinned: true},
+		{ID: "h-low", ListID: "home", Priority: model.PriorityLow},
+		{ID: "orphan", ListID: "gone", Pinned: true},
+	}
+
+	var got []string
+	for _, task := range agendaTasks(lists, tasks, now) {
+		got = append(got, task.ID)
+	}
+	want := "h-overdue w-today w-pinned w-high"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("expected %q, got %q", want, strings.Join(got, " "))
+	}
+}
+
+func TestAgendaActsOnTasksOfAllLists(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.En)
+
+	svc := app.NewService(model.NewState())
+	svc.MarkOnboardingSeen()
+	home, _ := svc.CreateList("Casa", "")
+	work, _ := svc.CreateList("Trabalho", "")
+	pintar, _ := svc.CreateTask(home.ID, "Pintar")
+	_, _ = svc.CreateTask(home.ID, "Lavar")
+	relatorio, _ := svc.CreateTask(work.ID, "Relatório")
+	if _, err := svc.SetTaskPinned(pintar.ID, true); err != nil {
+		t.Fatalf("pin failed: %v", err)
+	}
+	if _, err := svc.SetTaskDue(relatorio.ID, dateOf(time.Now()).AddDate(0, 0, -1)); err != nil {
+		t.Fatalf("set due failed: %v", err)
+	}
+
+	m := NewModel(svc, "", "")
+	m.width, m.height = 120, 30
+	press := func(keys ...string) {
+		for _, k := range keys {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+
+	press("t")
+	if m.focus != focusTasks {
+		t.Fatal("expected the agenda to focus the tasks panel")
+	}
+	view := m.View()
+	for _, want := range []string{"Today — all lists", "2 tasks • 1 overdue", "Casa", "Pintar  ★", "Trabalho", "Relatório"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in the agenda:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Lavar") {
+		t.Fatalf("expected plain tasks to stay off the agenda:\n%s", view)
+	}
+
+	press("j", "4")
+	if task, _ := svc.GetTask(relatorio.ID); task.Priority != model.PriorityHigh {
+		t.Fatalf("expected the priority to change on the other list, got %+v", task)
+	}
+	press("x")
+	if task, _ := svc.GetTask(relatorio.ID); !task.Done {
+		t.Fatalf("expected x to complete the task, got %+v", task)
+	}
+	if tasks := m.visibleTasks(); len(tasks) != 1 || tasks[0].ID != pintar.ID {
+		t.Fatalf("expected completed tasks to leave the agenda, got %+v", tasks)
+	}
+
+	press("w")
+	m.input.Set("2026-13-01")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != modeSetDue || !m.statusErr {
+		t.Fatal("expected an invalid date to keep the prompt open with an error")
+	}
+	m.input.Set("2031-01-02")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if task, _ := svc.GetTask(pintar.ID); task.Extra[model.ExtraDue] != "2031-01-02" {
+		t.Fatalf("expected the due date to be saved, got %+v", task)
+	}
+
+	press("p")
+	if tasks := m.visibleTasks(); len(tasks) != 0 {
+		t.Fatalf("expected unpinning to drop the task from the agenda, got %+v", tasks)
+	}
+	press("t")
+	if m.showAgenda || len(m.visibleTasks()) != 2 {
+		t.Fatal("expected t to go back to the active list")
+	}
+}
@@ -0,0 +1,260 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/store"
+)
+
+const backupDiffPreviewLines = 8
+
+func (m *Model) openBackups() {
+	if strings.TrimSpace(m.statePath) == "" {
+		m.setStatus(i18n.T("backups.unavailable"), false)
+		return
+	}
+	backups, err := m.store.ListBackups()
+	if err != nil {
+		m.setStatus(i18n.T("backups.list_failed", i18n.Error(err)), true)
+		return
+	}
+	if len(backups) == 0 {
+		m.setStatus(i18n.T("backups.none"), false)
+		return
+	}
+	m.backups = backups
+	m.backupCursor = 0
+	m.mode = modeBackups
+	m.loadBackupDiff()
+	m.setStatus(i18n.T("backups.opened", len(backups)), false)
+}
+
+func (m *Model) closeBackups() {
+	m.mode = modeNormal
+	m.backups = nil
+	m.backupCursor = 0
+	m.backupDiff = app.StateDiff{}
+	m.backupErr = nil
+}
+
+func (m *Model) updateBackupsMode(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "esc", "q", "b":
+		m.closeBackups()
+		m.setStatus(i18n.T("backups.closed"), false)
+	case "j", "down":
+		if m.backupCursor < len(m.backups)-1 {
+			m.backupCursor++
+			m.loadBackupDiff()
+		}
+	case "k", "up":
+		if m.backupCursor > 0 {
+			m.backupCursor--
+			m.loadBackupDiff()
+		}
+	case "r", "enter":
+		b, ok := m.selectedBackup()
+		if !ok {
+			return
+		}
+		if !b.Valid() {
+			m.setStatus(i18n.T("backups.invalid_restore"), true)
+			return
+		}
+		m.mode = modeConfirmRestore
+	}
+}
+
+func (m *Model) updateConfirmRestoreMode(msg tea.KeyMsg) {
+	switch strings.ToLower(msg.String()) {
+	case "y":
+		m.restoreSelectedBackup()
+	case "n", "esc", "enter":
+		m.mode = modeBackups
+		m.setStatus(i18n.T("backups.cancelled"), false)
+	}
+}
+
+func (m *Model) selectedBackup() (store.BackupInfo, bool) {
+	if m.backupCursor < 0 || m.backupCursor >= len(m.backups) {
+		return store.BackupInfo{}, false
+	}
+	return m.backups[m.backupCursor], true
+}
+
+func (m *Model) loadBackupDiff() {
+	m.backupDiff = app.StateDiff{}
+	m.backupErr = nil
+	b, ok := m.selectedBackup()
+	if !ok {
+		return
+	}
+	state, _, err := m.store.LoadBackup(b.ID)
+	if err != nil {
+		m.backupErr = err
+		return
+	}
+	m.backupDiff = app.DiffStates(m.svc.State(), state)
+}
+
+func (m *Model) restoreSelectedBackup() {
+	b, ok := m.selectedBackup()
+	if !ok {
+		m.closeBackups()
+		return
+	}
+	// Lemos o backup antes de salvar o estado atual: o autosave pode
+	// podar justamente o snapshot mais antigo da rotação.
+	state, _, err := m.store.LoadBackup(b.ID)
+	if err != nil {
+		m.mode = modeBackups
+		m.setStatus(i18n.T("backups.read_failed", i18n.Error(err)), true)
+		return
+	}
+	// O estado atual precisa estar em disco (e na rotação) antes de ser trocado.
+	if err := m.syncSession(); err != nil {
+		m.mode = modeBackups
+		return
+	}
+	if err := m.saveIfDirty(); err != nil {
+		m.mode = modeBackups
+		return
+	}
+	// Autosave pode pular a rotação (MinInterval, modo journal): forçamos.
+	if err := m.store.ForceBackup(); err != nil {
+		m.mode = modeBackups
+		m.setStatus(i18n.T("backups.snapshot_failed", i18n.Error(err)), true)
+		return
+	}
+
+	m.svc.ReplaceState(state)
+	m.closeBackups()
+	m.showHistory = false
+	m.listCursor = 0
+	m.taskCursor = 0
+	m.restoreSessionContext()
+	m.ensureSelection()
+	m.persist(i18n.T("backups.restored", b.ID))
+}
+
+func (m *Model) renderBackupsOverlay(width int) string {
+	title := lipgloss.NewStyle().Bold(true).Render(i18n.T("backups.title"))
+	section := m.theme.style(styleSection).Bold(true)
+	muted := m.theme.style(styleMuted)
+
+	rows := []string{title, ""}
+	for i, b := range m.backups {
+		cursor := " "
+		if i == m.backupCursor {
+			cursor = "▸"
+		}
+		detail := i18n.T("backups.task_count", b.TaskCount)
+		if !b.Valid() {
+			detail = i18n.T("backups.invalid")
+		}
+		line := fmt.Sprintf("%s %-26s %s  %8s  %s",
+			cursor,
+			b.ID,
+			b.TakenAt.Local().Format(i18n.T("format.datesecs")),
+			formatSize(b.Size),
+			detail,
+		)
+		if i == m.backupCursor {
+			line = m.theme.style(styleSelected).Bold(true).Render(line)
+		} else if !b.Valid() {
+			line = muted.Render(line)
+		}
+		rows = append(rows, line)
+	}
+
+	rows = append(rows, "", section.Render(i18n.T("backups.on_restore")))
+	rows = append(rows, m.backupDiffLines()...)
+	rows = append(rows, "", muted.Render(i18n.T("backups.footer")))
+
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.color(styleOverlayBorder)).
+		Padding(1, 2)
+	return style.Width(width).Render(strings.Join(rows, "\n"))
+}
+
+func (m *Model) backupDiffLines() []string {
+	line := m.theme.style(styleText)
+	added := m.theme.style(styleDiffAdded)
+	removed := m.theme.style(styleDiffRemoved)
+	changed := m.theme.style(styleDiffChanged)
+
+	if m.backupErr != nil {
+		return []string{removed.Render("  " + i18n.Error(m.backupErr))}
+	}
+	d := m.backupDiff
+	if d.Empty() {
+		return []string{line.Render("  " + i18n.T("backups.no_diff"))}
+	}
+
+	out := []string{
+		line.Render("  " + i18n.T("backups.diff_summary",
+			len(d.AddedLists), len(d.RemovedLists), len(d.RenamedLists),
+			len(d.AddedTasks), len(d.RemovedTasks), len(d.ChangedTasks),
+			d.ArchivedBefore, d.ArchivedAfter)),
+	}
+
+	details := make([]string, 0, backupDiffPreviewLines)
+	for _, l := range d.AddedLists {
+		details = append(details, added.Render("  + "+i18n.T("backups.diff_list", l.Name)))
+	}
+	for _, l := range d.RemovedLists {
+		details = append(details, removed.Render("  − "+i18n.T("backups.diff_list", l.Name)))
+	}
+	for _, l := range d.RenamedLists {
+		details = append(details, changed.Render("  ~ "+i18n.T("backups.diff_list", l.Name)))
+	}
+	for _, t := range d.AddedTasks {
+		details = append(details, added.Render("  + "+t.Text))
+	}
+	for _, t := range d.RemovedTasks {
+		details = append(details, removed.Render("  − "+t.Text))
+	}
+	for _, c := range d.ChangedTasks {
+		details = append(details, changed.Render("  ~ "+describeTaskChange(c)))
+	}
+	if len(details) > backupDiffPreviewLines {
+		rest := len(details) - backupDiffPreviewLines
+		details = append(details[:backupDiffPreviewLines], line.Render("  "+i18n.T("backups.diff_more", rest)))
+	}
+	return append(out, details...)
+}
+
+func describeTaskChange(c app.TaskChange) string {
+	if c.Before.Text != c.After.Text {
+		return fmt.Sprintf("%s → %s", c.Before.Text, c.After.Text)
+	}
+	parts := []string{c.After.Text}
+	if c.Before.Done != c.After.Done {
+		if c.After.Done {
+			parts = append(parts, i18n.T("backups.change_done"))
+		} else {
+			parts = append(parts, i18n.T("backups.change_reopened"))
+		}
+	}
+	if c.Before.Priority != c.After.Priority {
+		parts = append(parts, i18n.T("backups.change_priority", priorityLabel(c.After.Priority)))
+	}
+	if c.Before.ListID != c.After.ListID {
+		parts = append(parts, i18n.T("backups.change_list"))
+	}
+	return strings.Join(parts, " • ")
+}
+
+func formatSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
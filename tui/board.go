@@ -0,0 +1,275 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// boardColumnGap separates the board columns.
+const boardColumnGap = 1
+
+// statusLabel names a board column: the default statuses are translated,
+// custom ones are shown as written.
+func statusLabel(status string) string {
+	switch status {
+	case model.StatusTodo, model.StatusDoing, model.StatusReview, model.StatusDone:
+		return i18n.T("board." + status)
+	}
+	return status
+}
+
+// boardColumns groups the visible tasks by status, as indexes into
+// visibleTasks, one slice per column of the active list.
+func (m *Model) boardColumns() (statuses []string, columns [][]int) {
+	list, ok := m.activeList()
+	if !ok {
+		return nil, nil
+	}
+	statuses = list.BoardStatuses()
+	columns = make([][]int, len(statuses))
+	for i, t := range m.visibleTasks() {
+		for c, st := range statuses {
+			if t.Status == st {
+				columns[c] = append(columns[c], i)
+				break
+			}
+		}
+	}
+	return statuses, columns
+}
+
+// boardCursor locates the selected task on the board; ok is false when no
+// task is selected.
+func (m *Model) boardCursor(columns [][]int) (col, row int, ok bool) {
+	for c, column := range columns {
+		for r, i := range column {
+			if i == m.taskCursor {
+				return c, r, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// moveBoardCursor moves the selection up or down within its column.
+func (m *Model) moveBoardCursor(delta int) {
+	_, columns := m.boardColumns()
+	c, r, ok := m.boardCursor(columns)
+	if !ok {
+		return
+	}
+	m.taskCursor = columns[c][clamp(r+delta, 0, len(columns[c])-1)]
+}
+
+// moveBoardColumn selects a card in the nearest non-empty column to the
+// left (dir < 0) or right, keeping the row when the column is tall enough.
+func (m *Model) moveBoardColumn(dir int) {
+	if !m.showBoard || m.focus != focusTasks {
+		return
+	}
+	_, columns := m.boardColumns()
+	c, r, ok := m.boardCursor(columns)
+	if !ok {
+		return
+	}
+	for next := c + dir; next >= 0 && next < len(columns); next += dir {
+		if len(columns[next]) > 0 {
+			m.taskCursor = columns[next][min(r, len(columns[next])-1)]
+			return
+		}
+	}
+}
+
+func (m *Model) toggleBoard() {
+	m.showBoard = !m.showBoard
+	if m.showBoard {
+		m.showHistory = false
+		m.showAgenda = false
+		m.setStatus(i18n.T("status.board_opened"), false)
+		return
+	}
+	m.setStatus(i18n.T("status.board_closed"), false)
+}
+
+// moveTaskStatus moves the selected task one column left (dir < 0) or
+// right. It works in the list view too, where the status is not shown.
+func (m *Model) moveTaskStatus(dir int) {
+	if m.focus != focusTasks {
+		m.setStatus(i18n.T("focus.need_tasks.status"), false)
+		return
+	}
+	if m.showHistory {
+		m.setStatus(i18n.T("status.history_read_only"), false)
+		return
+	}
+	task, ok := m.selectedTask()
+	if !ok {
+		m.setStatus(i18n.T("status.no_task_selected"), true)
+		return
+	}
+	// Na agenda a tarefa pode ser de outra lista que a ativa.
+	list, err := m.svc.GetList(task.ListID)
+	if err != nil {
+		m.setStatus(i18n.T("error.task_status", i18n.Error(err)), true)
+		return
+	}
+	statuses := list.BoardStatuses()
+	current := 0
+	for i, st := range statuses {
+		if st == task.Status {
+			current = i
+		}
+	}
+	next := clamp(current+dir, 0, len(statuses)-1)
+	if next == current {
+		return
+	}
+	task, err = m.svc.SetTaskStatus(task.ID, statuses[next])
+	if err != nil {
+		m.setStatus(i18n.T("error.task_status", i18n.Error(err)), true)
+		return
+	}
+	m.taskCursor = m.indexOfTask(task.ID)
+	m.persist(i18n.T("status.task_status", statusLabel(task.Status)))
+}
+
+func (m *Model) startEditColumns() {
+	if m.focus != focusLists {
+		m.setStatus(i18n.T("focus.need_lists.columns"), false)
+		return
+	}
+	list, ok := m.activeList()
+	if !ok {
+		m.setStatus(i18n.T("status.no_list_selected"), true)
+		return
+	}
+	m.startInput(modeEditColumns, strings.Join(list.BoardStatuses(), ", "))
+}
+
+// applyColumns saves the comma-separated columns typed in modeEditColumns;
+// an empty line restores the default columns.
+func (m *Model) applyColumns(text string) {
+	list, ok := m.activeList()
+	if !ok {
+		m.mode = modeNormal
+		m.input.Reset()
+		m.setStatus(i18n.T("status.no_list_selected"), true)
+		return
+	}
+	var statuses []string
+	if text != "" {
+		statuses = strings.Split(text, ",")
+	}
+	if _, err := m.svc.SetListStatuses(list.ID, statuses); err != nil {
+		m.setStatus(i18n.T("error.columns", i18n.Error(err)), true)
+		return
+	}
+	m.mode = modeNormal
+	m.input.Reset()
+	m.persist(i18n.T("status.columns"))
+}
+
+// boardCardAt returns the visible task index of the card at column x of
+// the panel and card row (0 is the first card below the column headers).
+func (m *Model) boardCardAt(panelW, x, row int) (int, bool) {
+	_, columns := m.boardColumns()
+	if row < 0 || len(columns) == 0 {
+		return 0, false
+	}
+	// O x conta a partir da borda do painel, antes do padding.
+	c := (x - 1) / (boardColumnWidth(panelW, len(columns)) + boardColumnGap)
+	if x < 1 || c >= len(columns) {
+		return 0, false
+	}
+	offset := 0
+	if selCol, selRow, ok := m.boardCursor(columns); ok && selCol == c {
+		offset = scrollWindow(0, selRow, m.panelRows()-1, len(columns[c]))
+	}
+	if r := offset + row; r < len(columns[c]) {
+		return columns[c][r], true
+	}
+	return 0, false
+}
+
+// boardColumnWidth splits the panel's content width among n columns.
+func boardColumnWidth(panelW, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return max((panelW-2-(n-1)*boardColumnGap)/n, 6)
+}
+
+// renderBoardPanel draws the active list as columns side by side, one per
+// status. Each column scrolls on its own to keep the selected card visible.
+func (m *Model) renderBoardPanel(width, height int) string {
+	list, hasList := m.activeList()
+	isActive := m.focus == focusTasks
+	title := i18n.T("panel.tasks")
+	if hasList {
+		title = i18n.T("panel.board_of", list.Name)
+	}
+	titleLine := m.theme.panelTitle(title, isActive)
+	if hasList {
+		open, done, _ := m.listTaskStats(list.ID)
+		meta := m.theme.style(styleMuted).Render(i18n.T("panel.tasks_meta", open, done))
+		titleLine = lipgloss.JoinHorizontal(lipgloss.Left, titleLine, "  ", meta)
+	}
+	if !hasList {
+		return renderPanel(width, height, []string{titleLine, m.theme.style(styleMuted).Render(i18n.T("panel.no_active_list"))})
+	}
+
+	statuses, columns := m.boardColumns()
+	tasks := m.visibleTasks()
+	selCol, selRow, _ := m.boardCursor(columns)
+	colW := boardColumnWidth(width, len(statuses))
+	rows := max(height-2, 1)
+
+	rendered := make([]string, len(statuses))
+	for c, status := range statuses {
+		header := fmt.Sprintf("%s %d", statusLabel(status), len(columns[c]))
+		lines := []string{m.theme.style(styleSection).Bold(true).Render(header)}
+		offset := 0
+		if c == selCol {
+			offset = scrollWindow(0, selRow, rows, len(columns[c]))
+		}
+		for r := offset; r < min(offset+rows, len(columns[c])); r++ {
+			i := columns[c][r]
+			t := tasks[i]
+			cursor := " "
+			style := lipgloss.NewStyle()
+			if t.Done {
+				style = m.theme.style(styleDone)
+			}
+			if i == m.taskCursor {
+				cursor = "›"
+				style = style.Bold(true)
+				if isActive {
+					style = style.Foreground(m.theme.color(styleSelected))
+				}
+			}
+			card := style.Render(cursor+" ") + m.theme.priorityIndicator(t.Priority) + " " + style.Render(t.Text)
+			lines = append(lines, card)
+		}
+		for i, l := range lines {
+			lines[i] = ansi.Truncate(l, colW, "…")
+		}
+		rendered[c] = lipgloss.NewStyle().Width(colW).Render(strings.Join(lines, "\n"))
+	}
+
+	gap := strings.Repeat(" ", boardColumnGap)
+	parts := make([]string, 0, 2*len(rendered))
+	for c, col := range rendered {
+		if c > 0 {
+			parts = append(parts, gap)
+		}
+		parts = append(parts, col)
+	}
+	board := lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+	return renderPanel(width, height, append([]string{titleLine}, strings.Split(board, "\n")...))
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+func TestBoardMovesCardsBetweenColumns(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.En)
+
+	svc := app.NewService(model.NewState())
+	svc.MarkOnboardingSeen()
+	list, _ := svc.CreateList("Casa", "")
+	for _, text := range []string{"Pintar", "Lavar", "Varrer"} {
+		if _, err := svc.CreateTask(list.ID, text); err != nil {
+			t.Fatalf("create task failed: %v", err)
+		}
+	}
+	m := NewModel(svc, "", "")
+	m.width, m.height = 120, 30
+	m.focus = focusTasks
+	press := func(keys ...string) {
+		for _, k := range keys {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+
+	press("v", ">", ">")
+	task, _ := m.selectedTask()
+	if task.Text != "Pintar" || task.Status != model.StatusReview {
+		t.Fatalf("expected Pintar in review, got %+v", task)
+	}
+	view := m.View()
+	for _, want := range []string{"Board — Casa", "To do 2", "Review 1", "Done 0"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q on the board:\n%s", want, view)
+		}
+	}
+
+	press(">")
+	if task, _ = m.selectedTask(); !task.Done {
+		t.Fatalf("expected the done column to complete the task, got %+v", task)
+	}
+	if err := svc.SetFilter(model.FilterTodo); err != nil {
+		t.Fatalf("set filter failed: %v", err)
+	}
+	m.ensureSelection()
+	if view := m.View(); !strings.Contains(view, "Done 0") || strings.Contains(view, "Pintar") {
+		t.Fatalf("expected the todo filter to hide done cards:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if task, _ = m.selectedTask(); task.Text != "Varrer" {
+		t.Fatalf("expected j to move within the column, got %+v", task)
+	}
+}
@@ -0,0 +1,291 @@
+package tui
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// commandPaletteRows is how many matches the palette shows at once.
+const commandPaletteRows = 12
+
+// choice is an argument offered by the command palette: value goes to the
+// action's apply, label is what the user sees and types against.
+type choice struct {
+	value string
+	label string
+}
+
+// paletteItem is a row of the command palette, ranked against the input.
+type paletteItem struct {
+	value     string
+	label     string
+	keys      string
+	score     int
+	positions []int
+}
+
+// commandLabel names an action in the palette.
+func commandLabel(name string) string {
+	return i18n.T("command." + name)
+}
+
+func (m *Model) openCommandPalette() {
+	m.commandAction = ""
+	m.commandCursor = 0
+	m.startInput(modeCommandPalette, "")
+}
+
+// openCommandArgs opens the palette on the arguments of an action that
+// needs one, such as the target of move-to-list. It takes the action's
+// choices rather than looking them up, since the registry refers to it.
+func (m *Model) openCommandArgs(name string, choices func(*Model) []choice) {
+	if len(choices(m)) == 0 {
+		return
+	}
+	m.commandAction = name
+	m.commandCursor = 0
+	m.startInput(modeCommandPalette, "")
+}
+
+func (m *Model) closeCommandPalette() {
+	m.mode = modeNormal
+	m.input.Reset()
+	m.commandAction = ""
+	m.commandCursor = 0
+}
+
+// paletteItems lists the actions, or the arguments of m.commandAction,
+// that match the input, best first. Actions also match by their keymap
+// name, so "move-to-list" finds the same entry as its label.
+func (m *Model) paletteItems() []paletteItem {
+	var items []paletteItem
+	if m.commandAction == "" {
+		for _, a := range actions {
+			if a.name == actionCommandPalette {
+				continue
+			}
+			items = append(items, paletteItem{value: a.name, label: commandLabel(a.name), keys: m.keys.keysFor(a.name)})
+		}
+	} else if a, ok := findAction(m.commandAction); ok {
+		for _, c := range a.choices(m) {
+			items = append(items, paletteItem{value: c.value, label: c.label})
+		}
+	}
+
+	pat := fuzzyPattern(m.input.String())
+	out := items[:0]
+	for _, it := range items {
+		score, positions, ok := fuzzyMatch(pat, it.label)
+		if !ok && m.commandAction == "" {
+			if score, _, ok = fuzzyMatch(pat, it.value); ok {
+				positions = nil
+			}
+		}
+		if !ok {
+			continue
+		}
+		it.score, it.positions = score, positions
+		out = append(out, it)
+	}
+	slices.SortStableFunc(out, func(a, b paletteItem) int { return b.score - a.score })
+	return out
+}
+
+// updateCommandPalette handles keys in the palette and reports whether the
+// chosen command was quit.
+func (m *Model) updateCommandPalette(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.closeCommandPalette()
+		m.setStatus(i18n.T("status.cancelled"), false)
+	case "up", "ctrl+p":
+		m.commandCursor = max(m.commandCursor-1, 0)
+	case "down", "ctrl+n":
+		m.commandCursor = min(m.commandCursor+1, max(len(m.paletteItems())-1, 0))
+	case "enter":
+		items := m.paletteItems()
+		if len(items) == 0 {
+			return false
+		}
+		return m.runCommand(items[clamp(m.commandCursor, 0, len(items)-1)].value)
+	default:
+		if m.input.Update(msg) {
+			m.commandCursor = 0
+		}
+	}
+	return false
+}
+
+// runCommand runs the action picked in the palette, or applies the picked
+// argument to m.commandAction.
+func (m *Model) runCommand(value string) bool {
+	name := m.commandAction
+	m.closeCommandPalette()
+	if name == "" {
+		name, value = value, ""
+	}
+	if name == actionQuit {
+		return true
+	}
+	a, ok := findAction(name)
+	if !ok {
+		return false
+	}
+	if value != "" {
+		a.apply(m, value)
+	} else if a.run != nil {
+		a.run(m)
+	}
+	m.ensureSelection()
+	return false
+}
+
+// commandTask is the task argument actions work on: the selected one, with
+// the focus on Tasks and outside the history.
+func (m *Model) commandTask(needFocus string) (model.Task, bool) {
+	if m.focus != focusTasks {
+		m.setStatus(i18n.T(needFocus), false)
+		return model.Task{}, false
+	}
+	if m.showHistory {
+		m.setStatus(i18n.T("status.history_read_only"), false)
+		return model.Task{}, false
+	}
+	task, ok := m.selectedTask()
+	if !ok {
+		m.setStatus(i18n.T("status.no_task_selected"), true)
+	}
+	return task, ok
+}
+
+func (m *Model) listChoices() []choice {
+	task, ok := m.commandTask("focus.need_tasks.move")
+	if !ok {
+		return nil
+	}
+	var out []choice
+	for _, l := range m.svc.Lists() {
+		if l.ID != task.ListID {
+			out = append(out, choice{value: l.ID, label: l.Name})
+		}
+	}
+	if len(out) == 0 {
+		m.setStatus(i18n.T("status.no_other_list"), false)
+	}
+	return out
+}
+
+func (m *Model) moveTaskToList(listID string) {
+	task, ok := m.commandTask("focus.need_tasks.move")
+	if !ok {
+		return
+	}
+	list, err := m.svc.GetList(listID)
+	if err != nil {
+		m.setStatus(i18n.T("error.move_task", i18n.Error(err)), true)
+		return
+	}
+	if _, err := m.svc.MoveTaskToList(task.ID, listID); err != nil {
+		m.setStatus(i18n.T("error.move_task", i18n.Error(err)), true)
+		return
+	}
+	m.persist(i18n.T("status.task_moved_to", list.Name))
+}
+
+func (m *Model) priorityChoices() []choice {
+	if _, ok := m.commandTask("focus.need_tasks.prio"); !ok {
+		return nil
+	}
+	var out []choice
+	for _, p := range []model.Priority{model.PriorityNone, model.PriorityLow, model.PriorityMedium, model.PriorityHigh} {
+		out = append(out, choice{value: strconv.Itoa(int(p)), label: priorityLabel(p)})
+	}
+	return out
+}
+
+func (m *Model) applyPriority(value string) {
+	p, err := strconv.Atoi(value)
+	if err != nil {
+		return
+	}
+	m.setSelectedTaskPriority(model.Priority(p))
+}
+
+func (m *Model) statusChoices() []choice {
+	task, ok := m.commandTask("focus.need_tasks.status")
+	if !ok {
+		return nil
+	}
+	list, err := m.svc.GetList(task.ListID)
+	if err != nil {
+		return nil
+	}
+	var out []choice
+	for _, st := range list.BoardStatuses() {
+		out = append(out, choice{value: st, label: statusLabel(st)})
+	}
+	return out
+}
+
+func (m *Model) applyTaskStatus(status string) {
+	task, ok := m.commandTask("focus.need_tasks.status")
+	if !ok {
+		return
+	}
+	task, err := m.svc.SetTaskStatus(task.ID, status)
+	if err != nil {
+		m.setStatus(i18n.T("error.task_status", i18n.Error(err)), true)
+		return
+	}
+	m.taskCursor = m.indexOfTask(task.ID)
+	m.persist(i18n.T("status.task_status", statusLabel(task.Status)))
+}
+
+// renderCommandPalette draws the matches of the palette, with the matched
+// letters highlighted and each action's keys on the right.
+func (m *Model) renderCommandPalette(width int) string {
+	title := i18n.T("commands.title")
+	if m.commandAction != "" {
+		title = commandLabel(m.commandAction)
+	}
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.color(styleOverlayBorder)).
+		Padding(1, 2)
+
+	// Borda (2) + padding (4).
+	lineW := width - 6
+	items := m.paletteItems()
+	rows := []string{lipgloss.NewStyle().Bold(true).Render(title), ""}
+	if len(items) == 0 {
+		rows = append(rows, m.theme.style(styleMuted).Render(i18n.T("commands.none")))
+	}
+	m.commandCursor = clamp(m.commandCursor, 0, max(len(items)-1, 0))
+	offset := scrollWindow(0, m.commandCursor, commandPaletteRows, len(items))
+	hl := m.theme.style(styleMarker).Bold(true)
+	for i := offset; i < min(offset+commandPaletteRows, len(items)); i++ {
+		it := items[i]
+		cursor := "  "
+		base := m.theme.style(styleText)
+		if i == m.commandCursor {
+			cursor = "› "
+			base = lipgloss.NewStyle().Bold(true).Foreground(m.theme.color(styleSelected))
+		}
+		label := base.Render(cursor) + highlightMatches(it.label, it.positions, base, hl)
+		keys := m.theme.style(styleMuted).Render(it.keys)
+		gap := max(lineW-lipgloss.Width(label)-lipgloss.Width(keys), 1)
+		rows = append(rows, label+strings.Repeat(" ", gap)+keys)
+	}
+	if len(items) > commandPaletteRows {
+		indicator := i18n.T("panel.scroll", offset+1, min(offset+commandPaletteRows, len(items)), len(items))
+		rows = append(rows, "", m.theme.style(styleMuted).Render(indicator))
+	}
+	return style.Width(width).Render(strings.Join(rows, "\n"))
+}
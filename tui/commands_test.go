@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+func TestEveryActionHasACommandLabel(t *testing.T) {
+	for _, a := range actions {
+		if a.name == actionCommandPalette {
+			continue
+		}
+		if key := "command." + a.name; i18n.T(key) == key {
+			t.Fatalf("action %s has no palette label", a.name)
+		}
+	}
+}
+
+func TestCommandPaletteRunsActionsWithArguments(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.En)
+
+	svc := app.NewService(model.NewState())
+	svc.MarkOnboardingSeen()
+	home, _ := svc.CreateList("Casa", "")
+	work, _ := svc.CreateList("Trabalho", "")
+	task, _ := svc.CreateTask(home.ID, "Pintar")
+
+	m := NewModel(svc, "", "")
+	m.width, m.height = 120, 30
+	m.focus = focusTasks
+	typeText := func(s string) {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+	enter := func() { m.Update(tea.KeyMsg{Type: tea.KeyEnter}) }
+
+	typeText(":")
+	if m.mode != modeCommandPalette {
+		t.Fatal("expected : to open the palette")
+	}
+	if view := m.View(); !strings.Contains(view, "Commands") || !strings.Contains(view, "Switch focus between lists and tasks") {
+		t.Fatalf("expected the palette to list the actions:\n%s", view)
+	}
+	typeText("mv lst")
+	if items := m.paletteItems(); len(items) == 0 || items[0].value != actionMoveToList {
+		t.Fatalf("expected move-to-list first, got %+v", items)
+	}
+	enter()
+	if m.mode != modeCommandPalette || m.commandAction != actionMoveToList {
+		t.Fatal("expected move-to-list to ask for the target list")
+	}
+	if items := m.paletteItems(); len(items) != 1 || items[0].label != "Trabalho" {
+		t.Fatalf("expected the other list as the only choice, got %+v", items)
+	}
+	enter()
+	if moved, _ := svc.GetTask(task.ID); moved.ListID != work.ID {
+		t.Fatalf("expected the task in Trabalho, got %+v", moved)
+	}
+	if m.mode != modeNormal {
+		t.Fatal("expected the palette to close after running")
+	}
+
+	m.listCursor = 1
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	typeText("set prio")
+	enter()
+	typeText("high")
+	enter()
+	if got, _ := svc.GetTask(task.ID); got.Priority != model.PriorityHigh {
+		t.Fatalf("expected high priority, got %+v", got)
+	}
+
+	typeText(":")
+	typeText("zzzz")
+	enter()
+	if m.mode != modeCommandPalette || !strings.Contains(m.View(), "No command matches") {
+		t.Fatal("expected enter without matches to keep the palette open")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != modeNormal {
+		t.Fatal("expected esc to close the palette")
+	}
+
+	typeText(":")
+	typeText("quit")
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Fatal("expected quit from the palette to quit")
+	}
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// finderRows is how many results the finder shows at once.
+const finderRows = 12
+
+// finderEntry is a list (taskID empty) or task the finder can jump to.
+type finderEntry struct {
+	listID string
+	taskID string
+	text   string
+	done   bool
+}
+
+// finderMatch is an entry that matches the query, as an index into
+// Model.finder.
+type finderMatch struct {
+	entry     int
+	score     int
+	positions []int
+}
+
+// openFinder snapshots every list and task, so typing only matches text
+// and never goes back to the service.
+func (m *Model) openFinder() {
+	lists := m.svc.Lists()
+	tasks := m.svc.Tasks("")
+	m.finder = make([]finderEntry, 0, len(lists)+len(tasks))
+	for _, l := range lists {
+		m.finder = append(m.finder, finderEntry{listID: l.ID, text: l.Name})
+	}
+	for _, t := range tasks {
+		m.finder = append(m.finder, finderEntry{listID: t.ListID, taskID: t.ID, text: t.Text, done: t.Done})
+	}
+	m.finderMatches = nil
+	m.finderCursor = 0
+	m.startInput(modeFinder, "")
+	m.refreshFinder()
+}
+
+func (m *Model) closeFinder() {
+	m.mode = modeNormal
+	m.input.Reset()
+	m.finder = nil
+	m.finderMatches = nil
+	m.finderQuery = ""
+}
+
+// refreshFinder ranks the entries against the input, best first, keeping
+// the sidebar order among equals. Typing more only narrows the results, so
+// when the query grows only the previous matches are searched again.
+func (m *Model) refreshFinder() {
+	query := m.input.String()
+	pat := fuzzyPattern(query)
+	var candidates []int
+	if prev := m.finderMatches; prev != nil && strings.HasPrefix(query, m.finderQuery) {
+		candidates = make([]int, len(prev))
+		for i, fm := range prev {
+			candidates[i] = fm.entry
+		}
+		// Volta à ordem da barra lateral para o desempate.
+		slices.Sort(candidates)
+	} else {
+		candidates = make([]int, len(m.finder))
+		for i := range candidates {
+			candidates[i] = i
+		}
+	}
+
+	matches := make([]finderMatch, 0)
+	for _, i := range candidates {
+		if score, positions, ok := fuzzyMatch(pat, m.finder[i].text); ok {
+			matches = append(matches, finderMatch{entry: i, score: score, positions: positions})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b finderMatch) int { return b.score - a.score })
+	m.finderQuery = query
+	m.finderMatches = matches
+	m.finderCursor = 0
+}
+
+func (m *Model) updateFinder(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.closeFinder()
+		m.setStatus(i18n.T("status.cancelled"), false)
+	case "up", "ctrl+p":
+		m.finderCursor = max(m.finderCursor-1, 0)
+	case "down", "ctrl+n":
+		m.finderCursor = min(m.finderCursor+1, max(len(m.finderMatches)-1, 0))
+	case "enter":
+		if len(m.finderMatches) == 0 {
+			return
+		}
+		e := m.finder[m.finderMatches[m.finderCursor].entry]
+		m.closeFinder()
+		m.jumpTo(e)
+	default:
+		if m.input.Update(msg) {
+			m.refreshFinder()
+		}
+	}
+}
+
+// jumpTo makes the entry's list active and selects it, or its task. A task
+// hidden by the search or the filter clears them, so it can be selected.
+func (m *Model) jumpTo(e finderEntry) {
+	idx := slices.IndexFunc(m.svc.Lists(), func(l model.List) bool { return l.ID == e.listID })
+	if idx < 0 {
+		m.setStatus(i18n.T("status.finder_gone"), true)
+		return
+	}
+	m.listCursor = idx
+	m.showHistory = false
+	m.showAgenda = false
+	if e.taskID == "" {
+		m.focus = focusLists
+		m.taskCursor = 0
+		_ = m.syncSession()
+		m.setStatus(i18n.T("status.active_list", e.text), false)
+		return
+	}
+
+	task, err := m.svc.GetTask(e.taskID)
+	if err != nil {
+		m.setStatus(i18n.T("status.finder_gone"), true)
+		return
+	}
+	m.focus = focusTasks
+	if !slices.ContainsFunc(m.visibleTasks(), func(t model.Task) bool { return t.ID == task.ID }) {
+		m.svc.SetQuery("")
+		_ = m.svc.SetFilter(model.FilterAll)
+	}
+	m.taskCursor = m.indexOfTask(task.ID)
+	_ = m.syncSession()
+	list, _ := m.activeList()
+	m.setStatus(i18n.T("status.jumped_task", list.Name), false)
+}
+
+// renderFinder draws the best matches with the matched letters highlighted:
+// lists with their color dot, tasks with their checkbox and list.
+func (m *Model) renderFinder(width int) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.color(styleOverlayBorder)).
+		Padding(1, 2)
+
+	// Borda (2) + padding (4).
+	lineW := width - 6
+	count := m.theme.style(styleMuted).Render(i18n.T("finder.count", len(m.finderMatches), len(m.finder)))
+	rows := []string{lipgloss.NewStyle().Bold(true).Render(i18n.T("finder.title")) + "  " + count, ""}
+	if len(m.finderMatches) == 0 {
+		rows = append(rows, m.theme.style(styleMuted).Render(i18n.T("finder.none")))
+	}
+
+	lists := make(map[string]model.List)
+	for _, l := range m.svc.Lists() {
+		lists[l.ID] = l
+	}
+	hl := m.theme.style(styleMarker).Bold(true)
+	offset := scrollWindow(0, m.finderCursor, finderRows, len(m.finderMatches))
+	for i := offset; i < min(offset+finderRows, len(m.finderMatches)); i++ {
+		fm := m.finderMatches[i]
+		e := m.finder[fm.entry]
+		cursor := "  "
+		base := m.theme.style(styleText)
+		if e.done {
+			base = m.theme.style(styleDone)
+		}
+		if i == m.finderCursor {
+			cursor = "› "
+			base = lipgloss.NewStyle().Bold(true).Foreground(m.theme.color(styleSelected))
+		}
+		l := lists[e.listID]
+		icon := lipgloss.NewStyle().Foreground(colorForName(l.Color)).Render("●")
+		where := ""
+		if e.taskID != "" {
+			icon = "[ ]"
+			if e.done {
+				icon = "[x]"
+			}
+			where = m.theme.style(styleMuted).Render(l.Name)
+		}
+		line := base.Render(cursor) + icon + " " + highlightMatches(e.text, fm.positions, base, hl)
+		line = ansi.Truncate(line, max(lineW-lipgloss.Width(where)-1, 8), "…")
+		gap := max(lineW-lipgloss.Width(line)-lipgloss.Width(where), 1)
+		rows = append(rows, line+strings.Repeat(" ", gap)+where)
+	}
+	return style.Width(width).Render(strings.Join(rows, "\n"))
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+func TestFinderJumpsToListsAndHiddenTasks(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.En)
+
+	svc := app.NewService(model.NewState())
+	svc.MarkOnboardingSeen()
+	_, _ = svc.CreateList("Casa", "")
+	work, _ := svc.CreateList("Trabalho", "")
+	_, _ = svc.CreateTask(work.ID, "Revisar contrato")
+	report, _ := svc.CreateTask(work.ID, "Relatório trimestral")
+	if _, err := svc.ToggleDone(report.ID); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if err := svc.SetFilter(model.FilterTodo); err != nil {
+		t.Fatalf("set filter failed: %v", err)
+	}
+
+	m := NewModel(svc, "", "")
+	m.width, m.height = 120, 30
+	typeText := func(s string) {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if m.mode != modeFinder || len(m.finderMatches) != 4 {
+		t.Fatalf("expected the finder to start with everything, got %d matches", len(m.finderMatches))
+	}
+	typeText("rel tri")
+	view := m.View()
+	if !strings.Contains(view, "1 of 4") || !strings.Contains(view, "Trabalho") {
+		t.Fatalf("expected one task match with its list:\n%s", view)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	task, ok := m.selectedTask()
+	if !ok || task.ID != report.ID || m.focus != focusTasks || m.listCursor != 1 {
+		t.Fatalf("expected the done task selected in Trabalho, got %+v", task)
+	}
+	if st := svc.State(); st.Filter != model.FilterAll {
+		t.Fatalf("expected the filter hiding the task to be cleared, got %q", st.Filter)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	typeText("casa")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.focus != focusLists || m.listCursor != 0 {
+		t.Fatal("expected a list match to select the list")
+	}
+}
+
+func TestFinderNarrowsLargeStates(t *testing.T) {
+	state := model.NewState()
+	state.Lists = []model.List{{ID: "l1", Name: "Inbox"}}
+	for i := range 10000 {
+		state.Tasks = append(state.Tasks, model.Task{
+			ID: fmt.Sprintf("t%d", i), ListID: "l1", Position: i + 1,
+			Text: fmt.Sprintf("Task number %d about something", i),
+		})
+	}
+	svc := app.NewService(state)
+	m := NewModel(svc, "", "")
+	m.width, m.height = 120, 30
+
+	m.openFinder()
+	for _, r := range "9999" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(m.finderMatches) == 0 || m.finder[m.finderMatches[0].entry].taskID != "t9999" {
+		t.Fatalf("expected t9999 to rank first, got %+v", m.finderMatches[:min(3, len(m.finderMatches))])
+	}
+	narrowed := len(m.finderMatches)
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if len(m.finderMatches) <= narrowed {
+		t.Fatalf("expected deleting a letter to widen the results again: %d <= %d", len(m.finderMatches), narrowed)
+	}
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Scores of fuzzyMatch, per matched letter.
+const (
+	fuzzyMatchScore  = 16
+	fuzzyBoundary    = 8
+	fuzzyConsecutive = 6
+	fuzzyMaxGap      = 8
+)
+
+// fuzzyPattern lowercases a pattern and drops its spaces, so "mv lst"
+// finds "Move to list".
+func fuzzyPattern(pattern string) []rune {
+	out := make([]rune, 0, len(pattern))
+	for _, r := range pattern {
+		if !unicode.IsSpace(r) {
+			out = append(out, unicode.ToLower(r))
+		}
+	}
+	return out
+}
+
+// fuzzyMatch reports whether the letters of pat (from fuzzyPattern) appear
+// in text in order, ignoring case, like fzf. The score favors letters at
+// word starts and runs of consecutive letters, and penalizes gaps; positions
+// are the rune indexes of text that matched, for highlighting. An empty
+// pattern matches everything with score 0.
+func fuzzyMatch(pat []rune, text string) (score int, positions []int, ok bool) {
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+	runes := []rune(text)
+	// Primeiro acha onde termina a ocorrência mais cedo; depois volta dali
+	// até o início mais tardio, para ficar com a janela mais curta.
+	end := -1
+	for i, p := 0, 0; i < len(runes); i++ {
+		if unicode.ToLower(runes[i]) == pat[p] {
+			if p++; p == len(pat) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+	start := end
+	for i, p := end, len(pat)-1; i >= 0; i-- {
+		if unicode.ToLower(runes[i]) == pat[p] {
+			if p--; p < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	positions = make([]int, 0, len(pat))
+	for i, p := start, 0; p < len(pat); i++ {
+		if unicode.ToLower(runes[i]) != pat[p] {
+			continue
+		}
+		score += fuzzyMatchScore
+		if i == 0 || isWordBoundary(runes[i-1], runes[i]) {
+			score += fuzzyBoundary
+		}
+		if p > 0 {
+			if gap := i - positions[p-1] - 1; gap == 0 {
+				score += fuzzyConsecutive
+			} else {
+				score -= min(gap, fuzzyMaxGap)
+			}
+		}
+		positions = append(positions, i)
+		p++
+	}
+	return score - min(start, fuzzyMaxGap), positions, true
+}
+
+// isWordBoundary reports whether cur starts a word after prev.
+func isWordBoundary(prev, cur rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// highlightMatches renders text with the runes at positions (ascending, as
+// fuzzyMatch returns them) in hl and the rest in base.
+func highlightMatches(text string, positions []int, base, hl lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(text)
+	}
+	var b strings.Builder
+	runes := []rune(text)
+	next := 0
+	for i := 0; i < len(runes); {
+		matched := next < len(positions) && positions[next] == i
+		j := i
+		for j < len(runes) && (next < len(positions) && positions[next] == j) == matched {
+			if matched {
+				next++
+			}
+			j++
+		}
+		style := base
+		if matched {
+			style = hl
+		}
+		b.WriteString(style.Render(string(runes[i:j])))
+		i = j
+	}
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFuzzyMatchRanksWordStartsAndRuns(t *testing.T) {
+	pat := fuzzyPattern("mv LST")
+	_, positions, ok := fuzzyMatch(pat, "Move task to list…")
+	if !ok || !slices.Equal(positions, []int{0, 2, 13, 15, 16}) {
+		t.Fatalf("unexpected match %v (%v)", positions, ok)
+	}
+	if _, _, ok := fuzzyMatch(pat, "Move down"); ok {
+		t.Fatal("expected letters out of order not to match")
+	}
+
+	// Início de palavra e letras seguidas valem mais que letras soltas.
+	best, _, _ := fuzzyMatch(fuzzyPattern("arch"), "Archive completed")
+	worse, _, _ := fuzzyMatch(fuzzyPattern("arch"), "Search tasks")
+	if best <= worse {
+		t.Fatalf("expected a prefix run to outrank a scattered match: %d <= %d", best, worse)
+	}
+	if score, positions, ok := fuzzyMatch(nil, "anything"); !ok || score != 0 || positions != nil {
+		t.Fatal("expected an empty pattern to match everything")
+	}
+}
+
+func TestHighlightMatchesKeepsText(t *testing.T) {
+	plain := lipgloss.NewStyle()
+	if got := highlightMatches("Relatório", []int{0, 7}, plain, plain); got != "Relatório" {
+		t.Fatalf("expected the text back unchanged, got %q", got)
+	}
+}
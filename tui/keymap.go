@@ -0,0 +1,514 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// ErrInvalidKeymap is wrapped by keymap files that cannot be used.
+var ErrInvalidKeymap = errors.New("invalid keymap")
+
+// quitKey always quits from normal mode, so a broken keymap cannot trap the
+// user; it cannot be bound to an action.
+const quitKey = "ctrl+c"
+
+// Action names, as written in the keymap file.
+const (
+	actionQuit           = "quit"
+	actionFocus          = "focus"
+	actionDown           = "down"
+	actionUp             = "up"
+	actionPageDown       = "page-down"
+	actionPageUp         = "page-up"
+	actionTop            = "top"
+	actionBottom         = "bottom"
+	actionOpen           = "open"
+	actionAdd            = "add"
+	actionRename         = "rename"
+	actionEdit           = "edit"
+	actionToggle         = "toggle"
+	actionDelete         = "delete"
+	actionUndo           = "undo"
+	actionFilter         = "filter"
+	actionMoveDown       = "move-down"
+	actionMoveUp         = "move-up"
+	actionPriorityNone   = "priority-none"
+	actionPriorityLow    = "priority-low"
+	actionPriorityMedium = "priority-medium"
+	actionPriorityHigh   = "priority-high"
+	actionColor          = "color"
+	actionArchiveDone    = "archive-done"
+	actionArchiveAll     = "archive-all"
+	actionDeleteAll      = "delete-all"
+	actionCopy           = "copy"
+	actionHistory        = "history"
+	actionBackups        = "backups"
+	actionExportMarkdown = "export-markdown"
+	actionImportMarkdown = "import-markdown"
+	actionSearch         = "search"
+	actionHelp           = "help"
+	actionBoard          = "board"
+	actionColumnLeft     = "column-left"
+	actionColumnRight    = "column-right"
+	actionStatusLeft     = "status-left"
+	actionStatusRight    = "status-right"
+	actionColumns        = "columns"
+	actionAgenda         = "agenda"
+	actionPin            = "pin"
+	actionDue            = "due"
+	actionMoveToList     = "move-to-list"
+	actionSetPriority    = "set-priority"
+	actionSetStatus      = "set-status"
+	actionCommandPalette = "command-palette"
+	actionFind           = "find"
+	actionBack           = "back"
+)
+
+// action is one normal-mode command. run is nil for quit, which Update
+// handles itself.
+type action struct {
+	name string
+	keys []string
+	run  func(*Model)
+	// choices lists the arguments of an action that needs one; its run opens
+	// the command palette to pick one, then apply runs with the value.
+	choices func(*Model) []choice
+	apply   func(*Model, string)
+}
+
+// actions is the registry of normal-mode commands with their default keys.
+var actions = []action{
+	{name: actionQuit, keys: []string{"q"}},
+	{name: actionFocus, keys: []string{"tab"}, run: (*Model).toggleFocus},
+	{name: actionDown, keys: []string{"j", "down"}, run: func(m *Model) { m.moveCursor(1) }},
+	{name: actionUp, keys: []string{"k", "up"}, run: func(m *Model) { m.moveCursor(-1) }},
+	{name: actionPageDown, keys: []string{"pgdown"}, run: func(m *Model) { m.moveCursor(m.panelRows()) }},
+	{name: actionPageUp, keys: []string{"pgup"}, run: func(m *Model) { m.moveCursor(-m.panelRows()) }},
+	{name: actionTop, keys: []string{"g", "home"}, run: func(m *Model) { m.moveCursor(-jumpRows) }},
+	{name: actionBottom, keys: []string{"G", "end"}, run: func(m *Model) { m.moveCursor(jumpRows) }},
+	{name: actionOpen, keys: []string{"enter"}, run: (*Model).handleEnter},
+	{name: actionAdd, keys: []string{"a"}, run: (*Model).startAdd},
+	{name: actionRename, keys: []string{"r"}, run: (*Model).startRenameList},
+	{name: actionEdit, keys: []string{"e"}, run: (*Model).startEditTask},
+	{name: actionToggle, keys: []string{"x"}, run: (*Model).toggleTaskDone},
+	{name: actionDelete, keys: []string{"d"}, run: (*Model).startDeleteConfirm},
+	{name: actionUndo, keys: []string{"u"}, run: (*Model).undo},
+	{name: actionFilter, keys: []string{"f"}, run: (*Model).cycleFilter},
+	{name: actionMoveDown, keys: []string{"J"}, run: func(m *Model) { m.moveSelected(1) }},
+	{name: actionMoveUp, keys: []string{"K"}, run: func(m *Model) { m.moveSelected(-1) }},
+	{name: actionPriorityNone, keys: []string{"1"}, run: func(m *Model) { m.setSelectedTaskPriority(model.PriorityNone) }},
+	{name: actionPriorityLow, keys: []string{"2"}, run: func(m *Model) { m.setSelectedTaskPriority(model.PriorityLow) }},
+	{name: actionPriorityMedium, keys: []string{"3"}, run: func(m *Model) { m.setSelectedTaskPriority(model.PriorityMedium) }},
+	{name: actionPriorityHigh, keys: []string{"4"}, run: func(m *Model) { m.setSelectedTaskPriority(model.PriorityHigh) }},
+	{name: actionColor, keys: []string{"c"}, run: (*Model).cycleListColor},
+	{name: actionArchiveDone, keys: []string{"C"}, run: (*Model).startArchiveConfirm},
+	{name: actionArchiveAll, keys: []string{"A"}, run: (*Model).startArchiveAllConfirm},
+	{name: actionDeleteAll, keys: []string{"D"}, run: (*Model).startDeleteAllConfirm},
+	{name: actionCopy, keys: []string{"y"}, run: (*Model).copyActiveTodos},
+	{name: actionHistory, keys: []string{"h"}, run: (*Model).toggleHistory},
+	{name: actionBackups, keys: []string{"b"}, run: (*Model).openBackups},
+	{name: actionExportMarkdown, keys: []string{"M"}, run: (*Model).startMarkdownExport},
+	{name: actionImportMarkdown, keys: []string{"I"}, run: (*Model).startMarkdownImport},
+	{name: actionSearch, keys: []string{"/"}, run: (*Model).startSearch},
+	{name: actionHelp, keys: []string{"?"}, run: (*Model).toggleHelp},
+	{name: actionBoard, keys: []string{"v"}, run: (*Model).toggleBoard},
+	{name: actionColumnLeft, keys: []string{"left"}, run: func(m *Model) { m.moveBoardColumn(-1) }},
+	{name: actionColumnRight, keys: []string{"right"}, run: func(m *Model) { m.moveBoardColumn(1) }},
+	{name: actionStatusLeft, keys: []string{"<"}, run: func(m *Model) { m.moveTaskStatus(-1) }},
+	{name: actionStatusRight, keys: []string{">"}, run: func(m *Model) { m.moveTaskStatus(1) }},
+	{name: actionColumns, keys: []string{"S"}, run: (*Model).startEditColumns},
+	{name: actionAgenda, keys: []string{"t"}, run: (*Model).toggleAgenda},
+	{name: actionPin, keys: []string{"p"}, run: (*Model).togglePin},
+	{name: actionDue, keys: []string{"w"}, run: (*Model).startSetDue},
+	{name: actionMoveToList, run: func(m *Model) { m.openCommandArgs(actionMoveToList, (*Model).listChoices) }, choices: (*Model).listChoices, apply: (*Model).moveTaskToList},
+	{name: actionSetPriority, run: func(m *Model) { m.openCommandArgs(actionSetPriority, (*Model).priorityChoices) }, choices: (*Model).priorityChoices, apply: (*Model).applyPriority},
+	{name: actionSetStatus, run: func(m *Model) { m.openCommandArgs(actionSetStatus, (*Model).statusChoices) }, choices: (*Model).statusChoices, apply: (*Model).applyTaskStatus},
+	{name: actionCommandPalette, keys: []string{":", "ctrl+p"}, run: (*Model).openCommandPalette},
+	{name: actionFind, keys: []string{"ctrl+f"}, run: (*Model).openFinder},
+	{name: actionBack, keys: []string{"esc"}, run: (*Model).back},
+}
+
+// jumpRows is past the end of any panel; moveCursor clamps it to the first or
+// last item.
+const jumpRows = 1 << 30
+
+func findAction(name string) (action, bool) {
+	for _, a := range actions {
+		if a.name == name {
+			return a, true
+		}
+	}
+	return action{}, false
+}
+
+// Keymap binds action names to key sequences. A sequence is one or more keys
+// separated by spaces, e.g. "d" or "g g"; keys use Bubble Tea names such as
+// "ctrl+d", "tab" or "space".
+type Keymap struct {
+	bindings map[string][][]string // ação → sequências
+	index    map[string]string     // sequência → ação
+	prefixes map[string]bool       // prefixos próprios de sequências
+}
+
+// DefaultKeymap returns the built-in bindings.
+func DefaultKeymap() Keymap {
+	k, err := NewKeymap(nil)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// NewKeymap layers overrides on top of the default bindings. Each entry
+// replaces all keys of its action; an empty list unbinds it. The result is
+// rejected when a sequence is bound twice or is a prefix of another one.
+func NewKeymap(overrides map[string][]string) (Keymap, error) {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := findAction(name); !ok {
+			return Keymap{}, i18n.Errorf("keymap.unknown_action", ErrInvalidKeymap, name)
+		}
+	}
+
+	k := Keymap{
+		bindings: make(map[string][][]string, len(actions)),
+		index:    make(map[string]string),
+		prefixes: make(map[string]bool),
+	}
+	for _, a := range actions {
+		keys, ok := overrides[a.name]
+		if !ok {
+			keys = a.keys
+		}
+		for _, raw := range keys {
+			seq := parseSequence(raw)
+			if len(seq) == 0 {
+				return Keymap{}, i18n.Errorf("keymap.empty_sequence", ErrInvalidKeymap, a.name)
+			}
+			if slices.Contains(seq, quitKey) {
+				return Keymap{}, i18n.Errorf("keymap.reserved", ErrInvalidKeymap, a.name, quitKey)
+			}
+			id := sequenceID(seq)
+			if other, taken := k.index[id]; taken {
+				if other == a.name {
+					continue
+				}
+				return Keymap{}, i18n.Errorf("keymap.bound_twice", ErrInvalidKeymap, formatSequence(seq), other, a.name)
+			}
+			k.index[id] = a.name
+			k.bindings[a.name] = append(k.bindings[a.name], seq)
+		}
+	}
+
+	// Com prefixos ambíguos não dá para saber se "g" encerra a sequência ou
+	// se ainda vem outra tecla, então eles são rejeitados em vez de esperar.
+	for _, a := range actions {
+		for _, seq := range k.bindings[a.name] {
+			for n := 1; n < len(seq); n++ {
+				prefix := seq[:n]
+				if other, ok := k.index[sequenceID(prefix)]; ok {
+					return Keymap{}, i18n.Errorf("keymap.shadows", ErrInvalidKeymap, formatSequence(prefix), other, formatSequence(seq), a.name)
+				}
+				k.prefixes[sequenceID(prefix)] = true
+			}
+		}
+	}
+	return k, nil
+}
+
+// LoadKeymap reads a JSON object mapping action names to key sequences,
+// e.g. {"delete": ["d d"], "delete-all": []}. A missing file yields the
+// default keymap.
+func LoadKeymap(path string) (Keymap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return DefaultKeymap(), nil
+		}
+		return Keymap{}, err
+	}
+	var overrides map[string][]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return Keymap{}, fmt.Errorf("%s: %w: %v", filepath.Base(path), ErrInvalidKeymap, err)
+	}
+	k, err := NewKeymap(overrides)
+	if err != nil {
+		return Keymap{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return k, nil
+}
+
+// resolve feeds key after the pending keys. It returns the matched action,
+// or the keys still pending when they start a longer sequence. A key that
+// breaks a pending sequence is tried again on its own.
+func (k Keymap) resolve(pending []string, key string) (name string, next []string) {
+	seq := append(slices.Clone(pending), key)
+	id := sequenceID(seq)
+	if name, ok := k.index[id]; ok {
+		return name, nil
+	}
+	if k.prefixes[id] {
+		return "", seq
+	}
+	if len(pending) > 0 {
+		return k.resolve(nil, key)
+	}
+	return "", nil
+}
+
+// keysFor renders the bindings of an action for help texts, e.g. "j/↓".
+func (k Keymap) keysFor(name string) string {
+	seqs := k.bindings[name]
+	labels := make([]string, 0, len(seqs))
+	for _, seq := range seqs {
+		labels = append(labels, formatSequence(seq))
+	}
+	if name == actionQuit {
+		labels = append(labels, quitKey)
+	}
+	return strings.Join(labels, "/")
+}
+
+// keyFor renders the first binding of an action, or "—" when it is unbound.
+func (k Keymap) keyFor(name string) string {
+	seqs := k.bindings[name]
+	if len(seqs) == 0 {
+		return "—"
+	}
+	return formatSequence(seqs[0])
+}
+
+func parseSequence(raw string) []string {
+	fields := strings.Fields(raw)
+	seq := make([]string, 0, len(fields))
+	for _, f := range fields {
+		seq = append(seq, normalizeKey(f))
+	}
+	return seq
+}
+
+// normalizeKey matches the names produced by tea.KeyMsg.String: named keys
+// and modifiers are lower case, while single characters keep their case
+// ("J" and "alt+J" differ from "j" and "alt+j").
+func normalizeKey(key string) string {
+	if strings.EqualFold(key, "space") {
+		return " "
+	}
+	i := strings.LastIndex(key, "+")
+	if i < 0 || i == len(key)-1 {
+		if len([]rune(key)) == 1 {
+			return key
+		}
+		return strings.ToLower(key)
+	}
+	mods, base := strings.ToLower(key[:i+1]), key[i+1:]
+	if len([]rune(base)) > 1 {
+		base = strings.ToLower(base)
+	}
+	return mods + base
+}
+
+func sequenceID(seq []string) string {
+	return strings.Join(seq, "\x00")
+}
+
+func formatSequence(seq []string) string {
+	labels := make([]string, len(seq))
+	for i, key := range seq {
+		labels[i] = keyLabel(key)
+	}
+	return strings.Join(labels, " ")
+}
+
+func keyLabel(key string) string {
+	switch key {
+	case " ":
+		return i18n.T("keys.space")
+	case "tab":
+		return "Tab"
+	case "enter":
+		return "Enter"
+	case "esc":
+		return "Esc"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	case "home":
+		return "Home"
+	case "end":
+		return "End"
+	}
+	return key
+}
+
+// helpEntry documents one or more related actions under a single label,
+// e.g. move-down and move-up as "J/K reorder".
+type helpEntry struct {
+	actions []string
+	label   string
+}
+
+func entry(label string, names ...string) helpEntry {
+	return helpEntry{actions: names, label: label}
+}
+
+var (
+	navigateEntry = entry("action.navigate", actionDown, actionUp)
+	pageEntry     = entry("action.page", actionPageDown, actionPageUp)
+	jumpEntry     = entry("action.jump", actionTop, actionBottom)
+	reorderEntry  = entry("action.reorder", actionMoveDown, actionMoveUp)
+	columnEntry   = entry("action.column", actionColumnLeft, actionColumnRight)
+	statusEntry   = entry("action.status", actionStatusLeft, actionStatusRight)
+	priorityEntry = entry("action.priority", actionPriorityNone, actionPriorityLow, actionPriorityMedium, actionPriorityHigh)
+)
+
+// helpSections lays out the ? overlay; each title is a catalog key.
+var helpSections = []struct {
+	title   string
+	entries []helpEntry
+}{
+	{"help.global", []helpEntry{
+		entry("action.focus", actionFocus),
+		navigateEntry,
+		pageEntry,
+		jumpEntry,
+		entry("action.quit", actionQuit),
+		entry("action.search", actionSearch),
+		entry("action.command_palette", actionCommandPalette),
+		entry("action.find", actionFind),
+		entry("action.undo", actionUndo),
+		entry("action.help", actionHelp),
+		entry("action.back", actionBack),
+		entry("action.backups", actionBackups),
+		entry("action.export_markdown", actionExportMarkdown),
+		entry("action.import_markdown", actionImportMarkdown),
+	}},
+	{"help.lists", []helpEntry{
+		entry("action.add", actionAdd),
+		entry("action.rename", actionRename),
+		entry("action.color", actionColor),
+		reorderEntry,
+		entry("action.columns", actionColumns),
+		entry("action.delete", actionDelete),
+		entry("action.open", actionOpen),
+	}},
+	{"help.tasks", []helpEntry{
+		entry("action.add", actionAdd),
+		entry("action.edit", actionEdit),
+		entry("action.toggle", actionToggle),
+		priorityEntry,
+		reorderEntry,
+		entry("action.filter", actionFilter),
+		entry("action.copy", actionCopy),
+		entry("action.delete", actionDelete),
+		entry("action.archive_done", actionArchiveDone),
+		entry("action.archive_all", actionArchiveAll),
+		entry("action.delete_all", actionDeleteAll),
+		entry("action.history", actionHistory),
+		entry("action.board", actionBoard),
+		columnEntry,
+		statusEntry,
+		entry("action.agenda", actionAgenda),
+		entry("action.pin", actionPin),
+		entry("action.due", actionDue),
+	}},
+}
+
+// Hints shown in the footer for each pane in normal mode.
+var (
+	listsHint = []helpEntry{
+		entry("action.add", actionAdd),
+		entry("action.rename", actionRename),
+		entry("action.color", actionColor),
+		reorderEntry,
+		entry("action.delete", actionDelete),
+		entry("action.open", actionOpen),
+		entry("action.focus", actionFocus),
+		entry("action.quit", actionQuit),
+	}
+	tasksHint = []helpEntry{
+		entry("action.add", actionAdd),
+		entry("action.edit", actionEdit),
+		entry("action.toggle", actionToggle),
+		priorityEntry,
+		reorderEntry,
+		entry("action.filter", actionFilter),
+		entry("action.search", actionSearch),
+		entry("action.archive_done", actionArchiveDone),
+		entry("action.history", actionHistory),
+		entry("action.undo", actionUndo),
+	}
+	boardHint = []helpEntry{
+		navigateEntry,
+		columnEntry,
+		statusEntry,
+		entry("action.add", actionAdd),
+		entry("action.toggle", actionToggle),
+		entry("action.board", actionBoard),
+		entry("action.undo", actionUndo),
+	}
+	agendaHint = []helpEntry{
+		navigateEntry,
+		entry("action.toggle", actionToggle),
+		priorityEntry,
+		entry("action.edit", actionEdit),
+		entry("action.pin", actionPin),
+		entry("action.due", actionDue),
+		entry("action.close_agenda", actionAgenda),
+		entry("action.undo", actionUndo),
+	}
+	historyHint = []helpEntry{
+		navigateEntry,
+		entry("action.close_history", actionHistory),
+		entry("action.focus", actionFocus),
+		entry("action.undo", actionUndo),
+		entry("action.quit", actionQuit),
+	}
+)
+
+// describe renders entries as "key label" items, skipping unbound ones. A
+// single action lists all of its keys; a group shows the first key of each.
+func (k Keymap) describe(entries []helpEntry) []string {
+	items := make([]string, 0, len(entries))
+	for _, e := range entries {
+		var keys string
+		if len(e.actions) == 1 {
+			keys = k.keysFor(e.actions[0])
+		} else {
+			var first []string
+			for _, name := range e.actions {
+				if len(k.bindings[name]) > 0 {
+					first = append(first, k.keyFor(name))
+				}
+			}
+			keys = strings.Join(first, "/")
+		}
+		if keys == "" {
+			continue
+		}
+		items = append(items, keys+" "+i18n.T(e.label))
+	}
+	return items
+}
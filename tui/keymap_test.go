@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/app"
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+func TestNewKeymapRejectsConflicts(t *testing.T) {
+	cases := map[string]map[string][]string{
+		"same key":       {"delete-all": {"d"}},
+		"prefix":         {"delete": {"g g"}, "history": {"g"}},
+		"unknown action": {"explode": {"z"}},
+		"reserved":       {"quit": {"ctrl+c"}},
+		"empty":          {"undo": {" "}},
+	}
+	for name, overrides := range cases {
+		if _, err := NewKeymap(overrides); !errors.Is(err, ErrInvalidKeymap) {
+			t.Fatalf("%s: expected ErrInvalidKeymap, got %v", name, err)
+		}
+	}
+
+	// Trocar duas teclas de lugar não é conflito.
+	if _, err := NewKeymap(map[string][]string{"delete": {"D"}, "delete-all": {"d"}}); err != nil {
+		t.Fatalf("swapping keys failed: %v", err)
+	}
+}
+
+func TestKeySequencesDispatchActions(t *testing.T) {
+	svc := app.NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "")
+	task, _ := svc.CreateTask(list.ID, "Pintar")
+
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	if err := os.WriteFile(path, []byte(`{"delete": ["d d"], "toggle": ["space"]}`), 0o644); err != nil {
+		t.Fatalf("write keymap failed: %v", err)
+	}
+	keys, err := LoadKeymap(path)
+	if err != nil {
+		t.Fatalf("load keymap failed: %v", err)
+	}
+	m := NewModel(svc, "", "")
+	m.SetKeymap(keys)
+	m.focus = focusTasks
+
+	press := func(k tea.KeyMsg) { m.Update(k) }
+	press(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if tasks := svc.Tasks(list.ID); !tasks[0].Done {
+		t.Fatal("expected space to toggle the task")
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.mode != modeNormal || len(m.pendingKeys) != 1 {
+		t.Fatalf("expected a pending sequence after one d, got mode=%v pending=%v", m.mode, m.pendingKeys)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.mode != modeConfirmDelete || m.confirmID != task.ID {
+		t.Fatalf("expected d d to ask for delete confirmation, got mode=%v", m.mode)
+	}
+}
+
+func TestHelpFollowsKeymap(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.En)
+
+	keys, err := NewKeymap(map[string][]string{"delete-all": {"ctrl+x D"}, "copy": {}})
+	if err != nil {
+		t.Fatalf("keymap failed: %v", err)
+	}
+	m := NewModel(app.NewService(model.NewState()), "", "")
+	m.SetKeymap(keys)
+	m.focus = focusTasks
+
+	help := m.renderHelpOverlay(96)
+	if !strings.Contains(help, "ctrl+x D delete all") {
+		t.Fatalf("expected remapped key in help:\n%s", help)
+	}
+	if strings.Contains(help, "Markdown checklist") {
+		t.Fatalf("unbound action must not be listed:\n%s", help)
+	}
+	if hint := m.contextualHelp(); !strings.HasPrefix(hint, "Tasks • a create • e edit") {
+		t.Fatalf("unexpected contextual help %q", hint)
+	}
+}
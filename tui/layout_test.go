@@ -1,9 +1,14 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+
 	"todo-cli/app"
+	"todo-cli/i18n"
 	"todo-cli/model"
 )
 
@@ -36,3 +41,79 @@ func TestPaneWidthsSmallTerminalStillValid(t *testing.T) {
 		t.Fatalf("expected panes not to exceed viewport width=%d, got left=%d right=%d", viewW, left, right)
 	}
 }
+
+func TestViewFollowsLocale(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	svc := app.NewService(model.NewState())
+	m := NewModel(svc, "", "")
+	m.width, m.height = 120, 30
+
+	i18n.SetLocale(i18n.En)
+	m.undo()
+	view := m.View()
+	for _, want := range []string{"Lists", "Tasks", "Nothing to undo", "? shortcuts"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected English view to contain %q:\n%s", want, view)
+		}
+	}
+
+	i18n.SetLocale(i18n.PtBR)
+	if _, err := svc.MoveListUp("nope"); err != nil {
+		m.setStatus(i18n.T("error.move_list", i18n.Error(err)), true)
+	}
+	if view := m.View(); !strings.Contains(view, "lista não encontrada") {
+		t.Fatalf("expected translated app error in status bar:\n%s", view)
+	}
+}
+
+func TestScrollWindowKeepsCursorVisible(t *testing.T) {
+	cases := []struct {
+		offset, cursor, rows, total, want int
+	}{
+		{0, 3, 10, 5, 0},
+		{0, 12, 10, 30, 3},
+		{15, 4, 10, 30, 4},
+		{25, 29, 10, 30, 20},
+		{5, 7, 10, 30, 5},
+	}
+	for _, c := range cases {
+		if got := scrollWindow(c.offset, c.cursor, c.rows, c.total); got != c.want {
+			t.Fatalf("scrollWindow(%d, %d, %d, %d) = %d, want %d", c.offset, c.cursor, c.rows, c.total, got, c.want)
+		}
+	}
+}
+
+func TestTasksPanelScrollsToCursor(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.En)
+
+	svc := app.NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "")
+	for i := 1; i <= 60; i++ {
+		if _, err := svc.CreateTask(list.ID, fmt.Sprintf("tarefa-%02d", i)); err != nil {
+			t.Fatalf("create task failed: %v", err)
+		}
+	}
+	m := NewModel(svc, "", "")
+	m.width, m.height = 120, 30
+	m.focus = focusTasks
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	view := m.View()
+	if !strings.Contains(view, "tarefa-60") || strings.Contains(view, "tarefa-01") {
+		t.Fatalf("expected the last task in view after jumping to the bottom:\n%s", view)
+	}
+	if !strings.Contains(view, "of 60") {
+		t.Fatalf("expected a position indicator:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if m.taskCursor != 59-m.panelRows() {
+		t.Fatalf("expected page up to move one panel up, got cursor %d", m.taskCursor)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyHome})
+	if view := m.View(); !strings.Contains(view, "tarefa-01") || m.taskOffset != 0 {
+		t.Fatalf("expected the first task in view after jumping to the top:\n%s", view)
+	}
+}
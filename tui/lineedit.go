@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// inputHistoryLimit caps the entries kept per input mode.
+const inputHistoryLimit = 50
+
+// lineEditor is the single-line editor behind the input modes, with
+// readline-style keys:
+//
+//	←/→, ctrl+b/ctrl+f        move one character
+//	alt+←/alt+→, alt+b/alt+f  move one word
+//	home/end, ctrl+a/ctrl+e   start/end of line
+//	backspace, delete/ctrl+d  delete before/under the cursor
+//	ctrl+w, alt+backspace     delete the word before the cursor
+//	ctrl+u, ctrl+k            delete to the start/end of line
+//
+// Pasted text is inserted at the cursor with line breaks turned into spaces.
+type lineEditor struct {
+	runes  []rune
+	cursor int
+}
+
+// Set replaces the text and moves the cursor to the end.
+func (e *lineEditor) Set(s string) {
+	e.runes = []rune(s)
+	e.cursor = len(e.runes)
+}
+
+func (e *lineEditor) Reset() {
+	e.Set("")
+}
+
+func (e *lineEditor) String() string {
+	return string(e.runes)
+}
+
+// Update applies an editing key and reports whether the text changed.
+func (e *lineEditor) Update(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes:
+		if msg.Alt && !msg.Paste {
+			switch string(msg.Runes) {
+			case "b":
+				e.cursor = e.wordStart()
+			case "f":
+				e.cursor = e.wordEnd()
+			}
+			return false
+		}
+		e.insert(msg.Runes, msg.Paste)
+		return true
+	case tea.KeySpace:
+		e.insert([]rune{' '}, false)
+		return true
+	case tea.KeyLeft, tea.KeyCtrlB:
+		if msg.Alt {
+			e.cursor = e.wordStart()
+		} else if e.cursor > 0 {
+			e.cursor--
+		}
+	case tea.KeyRight, tea.KeyCtrlF:
+		if msg.Alt {
+			e.cursor = e.wordEnd()
+		} else if e.cursor < len(e.runes) {
+			e.cursor++
+		}
+	case tea.KeyCtrlLeft:
+		e.cursor = e.wordStart()
+	case tea.KeyCtrlRight:
+		e.cursor = e.wordEnd()
+	case tea.KeyHome, tea.KeyCtrlA:
+		e.cursor = 0
+	case tea.KeyEnd, tea.KeyCtrlE:
+		e.cursor = len(e.runes)
+	case tea.KeyBackspace, tea.KeyCtrlH:
+		if msg.Alt {
+			return e.deleteTo(e.wordStart())
+		}
+		if e.cursor == 0 {
+			return false
+		}
+		return e.deleteTo(e.cursor - 1)
+	case tea.KeyDelete, tea.KeyCtrlD:
+		if e.cursor == len(e.runes) {
+			return false
+		}
+		return e.deleteTo(e.cursor + 1)
+	case tea.KeyCtrlW:
+		return e.deleteTo(e.wordStart())
+	case tea.KeyCtrlU:
+		return e.deleteTo(0)
+	case tea.KeyCtrlK:
+		return e.deleteTo(len(e.runes))
+	}
+	return false
+}
+
+func (e *lineEditor) insert(rs []rune, paste bool) {
+	if paste {
+		rs = []rune(sanitizePaste(string(rs)))
+	}
+	out := make([]rune, 0, len(e.runes)+len(rs))
+	out = append(out, e.runes[:e.cursor]...)
+	out = append(out, rs...)
+	out = append(out, e.runes[e.cursor:]...)
+	e.runes = out
+	e.cursor += len(rs)
+}
+
+// deleteTo removes the text between the cursor and pos, in either direction.
+func (e *lineEditor) deleteTo(pos int) bool {
+	from, to := min(pos, e.cursor), max(pos, e.cursor)
+	if from == to {
+		return false
+	}
+	e.runes = append(e.runes[:from:from], e.runes[to:]...)
+	e.cursor = from
+	return true
+}
+
+// wordStart is where the word before the cursor begins, skipping the spaces
+// right before the cursor first.
+func (e *lineEditor) wordStart() int {
+	i := e.cursor
+	for i > 0 && unicode.IsSpace(e.runes[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(e.runes[i-1]) {
+		i--
+	}
+	return i
+}
+
+func (e *lineEditor) wordEnd() int {
+	i := e.cursor
+	for i < len(e.runes) && unicode.IsSpace(e.runes[i]) {
+		i++
+	}
+	for i < len(e.runes) && !unicode.IsSpace(e.runes[i]) {
+		i++
+	}
+	return i
+}
+
+// View renders the text with the cursor: a block at the end of the line, or
+// the character under it in reverse video.
+func (e *lineEditor) View() string {
+	if e.cursor >= len(e.runes) {
+		return string(e.runes) + "▌"
+	}
+	under := lipgloss.NewStyle().Reverse(true).Render(string(e.runes[e.cursor]))
+	return string(e.runes[:e.cursor]) + under + string(e.runes[e.cursor+1:])
+}
+
+// sanitizePaste keeps pasted text on one line: tabs and line breaks become
+// spaces and other control characters are dropped.
+func sanitizePaste(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// inputHistory recalls earlier entries of one input mode with up/down, like
+// a shell. pos == len(entries) means "not browsing"; draft keeps what was
+// typed before browsing started.
+type inputHistory struct {
+	entries []string
+	pos     int
+	draft   string
+}
+
+// add records an entry, skipping blanks and repeats of the last one, and
+// stops browsing.
+func (h *inputHistory) add(s string) {
+	s = strings.TrimSpace(s)
+	if s != "" && (len(h.entries) == 0 || h.entries[len(h.entries)-1] != s) {
+		h.entries = append(h.entries, s)
+		if len(h.entries) > inputHistoryLimit {
+			h.entries = h.entries[len(h.entries)-inputHistoryLimit:]
+		}
+	}
+	h.reset()
+}
+
+func (h *inputHistory) reset() {
+	h.pos = len(h.entries)
+	h.draft = ""
+}
+
+// prev returns the entry before the current one; ok is false at the oldest.
+func (h *inputHistory) prev(current string) (string, bool) {
+	if h.pos == 0 {
+		return "", false
+	}
+	if h.pos == len(h.entries) {
+		h.draft = current
+	}
+	h.pos--
+	return h.entries[h.pos], true
+}
+
+// next returns the following entry, or the draft after the newest one.
+func (h *inputHistory) next() (string, bool) {
+	if h.pos >= len(h.entries) {
+		return "", false
+	}
+	h.pos++
+	if h.pos == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.pos], true
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func typeRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestLineEditorEditing(t *testing.T) {
+	var e lineEditor
+	e.Set("comprar pão")
+	steps := []struct {
+		key  tea.KeyMsg
+		want string
+		cur  int
+	}{
+		{tea.KeyMsg{Type: tea.KeyCtrlA}, "comprar pão", 0},
+		{typeRunes("ir "), "ir comprar pão", 3},
+		{tea.KeyMsg{Type: tea.KeyCtrlE}, "ir comprar pão", 14},
+		{tea.KeyMsg{Type: tea.KeyCtrlW}, "ir comprar ", 11},
+		{tea.KeyMsg{Type: tea.KeyLeft, Alt: true}, "ir comprar ", 3},
+		{tea.KeyMsg{Type: tea.KeyDelete}, "ir omprar ", 3},
+		{tea.KeyMsg{Type: tea.KeyBackspace}, "iromprar ", 2},
+		{tea.KeyMsg{Type: tea.KeyCtrlK}, "ir", 2},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" à\r\nfeira\t"), Paste: true}, "ir à feira ", 11},
+		{tea.KeyMsg{Type: tea.KeyLeft}, "ir à feira ", 10},
+		{tea.KeyMsg{Type: tea.KeyCtrlU}, " ", 0},
+	}
+	for i, s := range steps {
+		e.Update(s.key)
+		if e.String() != s.want || e.cursor != s.cur {
+			t.Fatalf("step %d (%s): got %q cursor %d, want %q cursor %d", i, s.key, e.String(), e.cursor, s.want, s.cur)
+		}
+	}
+}
+
+func TestInputHistoryRecall(t *testing.T) {
+	svc := app.NewService(model.NewState())
+	list, _ := svc.CreateList("Casa", "")
+	m := NewModel(svc, "", "")
+	m.focus = focusTasks
+
+	for _, text := range []string{"lavar louça", "regar plantas"} {
+		m.startAdd()
+		m.Update(typeRunes(text))
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	if got := len(svc.Tasks(list.ID)); got != 2 {
+		t.Fatalf("expected 2 tasks, got %d", got)
+	}
+
+	m.startAdd()
+	m.Update(typeRunes("rascunho"))
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if got := m.input.String(); got != "lavar louça" {
+		t.Fatalf("expected oldest entry, got %q", got)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if got := m.input.String(); got != "lavar louça" {
+		t.Fatalf("expected to stay on the oldest entry, got %q", got)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := m.input.String(); got != "rascunho" {
+		t.Fatalf("expected the draft back, got %q", got)
+	}
+
+	// Histórico é por modo: a busca não vê as tarefas digitadas.
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m.startSearch()
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if got := m.input.String(); got != "" {
+		t.Fatalf("expected empty search history, got %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/exchange"
+	"todo-cli/i18n"
+)
+
+const markdownDefaultFile = "todo.md"
+
+func (m *Model) markdownDefaultPath() string {
+	if strings.TrimSpace(m.statePath) == "" {
+		return markdownDefaultFile
+	}
+	return filepath.Join(filepath.Dir(m.statePath), markdownDefaultFile)
+}
+
+func (m *Model) startMarkdownExport() {
+	m.startInput(modeExportMarkdown, m.markdownDefaultPath())
+	m.setStatus(i18n.T("markdown.export_prompt"), false)
+}
+
+func (m *Model) startMarkdownImport() {
+	m.startInput(modeImportMarkdown, m.markdownDefaultPath())
+	m.setStatus(i18n.T("markdown.import_prompt"), false)
+}
+
+// exportMarkdown writes the export to path. An existing file is only
+// replaced once overwrite is confirmed (see updateConfirmOverwriteMode).
+func (m *Model) exportMarkdown(path string, overwrite bool) {
+	if path == "" {
+		m.setStatus(i18n.T("markdown.need_dest"), true)
+		return
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		m.overwritePath = path
+		m.mode = modeConfirmOverwrite
+		return
+	}
+	if err != nil {
+		m.setStatus(i18n.T("markdown.export_failed", i18n.Error(err)), true)
+		return
+	}
+	err = exchange.ExportMarkdown(f, m.svc.State(), exchange.ExportOptions{IncludeArchive: true})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		m.setStatus(i18n.T("markdown.export_failed", i18n.Error(err)), true)
+		return
+	}
+	m.mode = modeNormal
+	m.input.Reset()
+	m.setStatus(i18n.T("markdown.exported", path), false)
+}
+
+func (m *Model) updateConfirmOverwriteMode(msg tea.KeyMsg) {
+	switch strings.ToLower(msg.String()) {
+	case "y":
+		m.mode = modeExportMarkdown
+		m.exportMarkdown(m.overwritePath, true)
+		m.overwritePath = ""
+	case "n", "esc", "enter":
+		// Volta ao caminho digitado, para escolher outro arquivo.
+		m.mode = modeExportMarkdown
+		m.overwritePath = ""
+		m.setStatus(i18n.T("markdown.overwrite_cancelled"), false)
+	}
+}
+
+func (m *Model) importMarkdown(path string) {
+	if path == "" {
+		m.setStatus(i18n.T("markdown.need_source"), true)
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		m.setStatus(i18n.T("markdown.import_failed", i18n.Error(err)), true)
+		return
+	}
+	batch, skipped, err := exchange.ImportMarkdown(f)
+	_ = f.Close()
+	if err != nil {
+		m.setStatus(i18n.T("markdown.import_failed", i18n.Error(err)), true)
+		return
+	}
+	result, err := m.svc.Import(batch)
+	if err != nil {
+		m.setStatus(i18n.T("markdown.import_failed", i18n.Error(err)), true)
+		return
+	}
+	m.mode = modeNormal
+	m.input.Reset()
+	m.ensureSelection()
+	msg := i18n.T("markdown.imported", result.ListsCreated, result.TasksCreated, result.TasksUpdated)
+	if len(skipped) > 0 {
+		msg += i18n.T("markdown.skipped", len(skipped))
+	}
+	m.persist(msg + i18n.T("status.undo_hint"))
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func TestMarkdownExportAsksBeforeOverwriting(t *testing.T) {
+	svc := app.NewService(model.NewState())
+	svc.MarkOnboardingSeen()
+	list, _ := svc.CreateList("Casa", "")
+	if _, err := svc.CreateTask(list.ID, "Pintar"); err != nil {
+		t.Fatalf("create task failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "todo.md")
+	if err := os.WriteFile(path, []byte("meu arquivo\n"), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	m := NewModel(svc, "", "")
+	export := func() {
+		m.startInput(modeExportMarkdown, path)
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	contents := func() string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		return string(data)
+	}
+
+	export()
+	if m.mode != modeConfirmOverwrite || contents() != "meu arquivo\n" {
+		t.Fatalf("expected a confirmation before touching the file, got mode %v", m.mode)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.mode != modeExportMarkdown || m.input.String() != path || contents() != "meu arquivo\n" {
+		t.Fatalf("expected to return to the path prompt with the file kept, got mode %v", m.mode)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if m.mode != modeNormal || !strings.Contains(contents(), "Pintar") {
+		t.Fatalf("expected the confirmed export to replace the file, got mode %v: %q", m.mode, contents())
+	}
+}
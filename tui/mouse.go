@@ -0,0 +1,194 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"todo-cli/i18n"
+)
+
+// wheelRows is how far one wheel notch moves the selection.
+const wheelRows = 3
+
+// paneLayout is where View draws the panels, in screen cells, so mouse
+// events can be mapped back to items.
+type paneLayout struct {
+	// itemsTop is the screen row of the first item, right below the titles.
+	itemsTop int
+	rows     int
+
+	listsX, listsW int
+	tasksX, tasksW int
+}
+
+// layout mirrors the geometry of View: header, optional onboarding, the
+// frame's top border and the panel titles sit above the items; the frame's
+// left border and the divider separate the panels.
+func (m *Model) layout() paneLayout {
+	viewW := m.viewportWidth()
+	leftW, rightW := m.paneWidths(frameContentWidth(viewW), paneGap)
+	top := 1
+	if m.shouldShowOnboarding() {
+		top += lipgloss.Height(m.renderOnboarding(viewW))
+	}
+	return paneLayout{
+		itemsTop: top + 2,
+		rows:     m.panelRows(),
+		listsX:   1,
+		listsW:   leftW,
+		tasksX:   1 + leftW + paneGap,
+		tasksW:   rightW,
+	}
+}
+
+// hit returns the panel under the cell (x, y) and the visible item row
+// there, or row -1 on the panel's title. ok is false outside the panels.
+func (l paneLayout) hit(x, y int) (pane focusPane, row int, ok bool) {
+	switch {
+	case x >= l.listsX && x < l.listsX+l.listsW:
+		pane = focusLists
+	case x >= l.tasksX && x < l.tasksX+l.tasksW:
+		pane = focusTasks
+	default:
+		return 0, 0, false
+	}
+	row = y - l.itemsTop
+	if row < -1 || row >= l.rows {
+		return 0, 0, false
+	}
+	return pane, row, true
+}
+
+// onCheckbox reports whether column x falls on the "[ ]" of a task row,
+// after the panel padding and the cursor column.
+func (l paneLayout) onCheckbox(x int) bool {
+	col := x - l.tasksX
+	return col >= 3 && col < 6
+}
+
+// updateMouse handles clicks, the wheel and dragging tasks to reorder them.
+// Only normal mode reacts; overlays and prompts ignore the mouse.
+func (m *Model) updateMouse(msg tea.MouseMsg) {
+	l := m.layout()
+	switch msg.Action {
+	case tea.MouseActionMotion:
+		if m.dragTaskID != "" {
+			m.dragOver(msg.Y - l.itemsTop)
+		}
+		return
+	case tea.MouseActionRelease:
+		if m.dragTaskID != "" {
+			m.drop()
+		}
+		return
+	}
+
+	pane, row, ok := l.hit(msg.X, msg.Y)
+	if !ok {
+		return
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.focusPane(pane)
+		m.moveCursor(-wheelRows)
+	case tea.MouseButtonWheelDown:
+		m.focusPane(pane)
+		m.moveCursor(wheelRows)
+	case tea.MouseButtonLeft:
+		m.focusPane(pane)
+		switch {
+		case pane == focusTasks && m.showBoard:
+			// No quadro a primeira linha abaixo do título é o cabeçalho das colunas.
+			if i, ok := m.boardCardAt(l.tasksW, msg.X-l.tasksX, row-1); ok {
+				m.taskCursor = i
+			}
+		case pane == focusTasks && m.showAgenda:
+			if row >= 0 {
+				m.agendaClick(row, l.onCheckbox(msg.X))
+			}
+		case row >= 0:
+			m.click(row, l.onCheckbox(msg.X))
+		}
+	}
+	m.ensureSelection()
+}
+
+func (m *Model) focusPane(pane focusPane) {
+	if m.focus == pane {
+		return
+	}
+	m.focus = pane
+	_ = m.syncSession()
+}
+
+// click selects the item on a visible row of the focused panel. On a task
+// it either toggles the checkbox or starts a drag.
+func (m *Model) click(row int, checkbox bool) {
+	if m.focus == focusLists {
+		if i := m.listOffset + row; i < len(m.svc.Lists()) && i != m.listCursor {
+			m.listCursor = i
+			m.taskCursor = 0
+			_ = m.syncSession()
+		}
+		return
+	}
+	if m.showHistory {
+		if i := m.historyOffset + row; i < len(m.archivedForDisplay()) {
+			m.historyCursor = i
+		}
+		return
+	}
+	tasks := m.visibleTasks()
+	i := m.taskOffset + row
+	if i >= len(tasks) {
+		return
+	}
+	m.taskCursor = i
+	if checkbox {
+		m.toggleTaskDone()
+		return
+	}
+	m.dragTaskID = tasks[i].ID
+	m.dragFrom = i
+}
+
+// dragOver moves the cursor to the drop position under the pointer. Rows
+// above or below the panel scroll it.
+func (m *Model) dragOver(row int) {
+	tasks := m.visibleTasks()
+	if len(tasks) == 0 {
+		return
+	}
+	m.taskCursor = clamp(m.taskOffset+row, 0, len(tasks)-1)
+	if m.taskCursor != m.dragFrom {
+		m.setStatus(i18n.T("status.dragging", tasks[m.dragFrom].Text), false)
+	}
+}
+
+// drop moves the dragged task to where the cursor is, in a single step so
+// one undo reverts the whole drag.
+func (m *Model) drop() {
+	id, from := m.dragTaskID, m.dragFrom
+	m.dragTaskID = ""
+	tasks := m.visibleTasks()
+	list, ok := m.activeList()
+	if !ok || m.taskCursor == from || m.taskCursor >= len(tasks) || from >= len(tasks) || tasks[from].ID != id {
+		return
+	}
+	// Com filtro ou busca ativos, a posição visível difere da posição na
+	// lista; o destino é o lugar da tarefa sob o cursor na lista inteira.
+	target := tasks[m.taskCursor].ID
+	position := 0
+	for i, t := range m.svc.Tasks(list.ID) {
+		if t.ID == target {
+			position = i
+			break
+		}
+	}
+	if _, err := m.svc.MoveTaskTo(id, position); err != nil {
+		m.taskCursor = from
+		m.setStatus(i18n.T("error.move_task", i18n.Error(err)), true)
+		return
+	}
+	m.persist(i18n.T("status.tasks_reordered"))
+}
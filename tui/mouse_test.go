@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"todo-cli/app"
+	"todo-cli/model"
+)
+
+func newMouseModel(t *testing.T) (*Model, *app.Service, model.List) {
+	t.Helper()
+	svc := app.NewService(model.NewState())
+	svc.MarkOnboardingSeen()
+	if _, err := svc.CreateList("Casa", ""); err != nil {
+		t.Fatalf("create list failed: %v", err)
+	}
+	work, _ := svc.CreateList("Trabalho", "")
+	for _, text := range []string{"A", "B", "C", "D"} {
+		if _, err := svc.CreateTask(work.ID, text); err != nil {
+			t.Fatalf("create task failed: %v", err)
+		}
+	}
+	m := NewModel(svc, "", "")
+	m.width, m.height = 100, 30
+	m.listCursor = 1
+	return m, svc, work
+}
+
+func taskTexts(svc *app.Service, listID string) string {
+	var b strings.Builder
+	for _, t := range svc.Tasks(listID) {
+		b.WriteString(t.Text)
+	}
+	return b.String()
+}
+
+func TestLayoutMatchesRenderedRows(t *testing.T) {
+	m, _, _ := newMouseModel(t)
+	lines := strings.Split(m.View(), "\n")
+	l := m.layout()
+
+	if row := lines[l.itemsTop]; !strings.Contains(row, "Casa") || !strings.Contains(row, "[ ] • A") {
+		t.Fatalf("expected the first list and task on row %d, got %q", l.itemsTop, row)
+	}
+	if pane, row, ok := l.hit(l.tasksX+4, l.itemsTop+2); !ok || pane != focusTasks || row != 2 {
+		t.Fatalf("unexpected hit: pane=%v row=%d ok=%v", pane, row, ok)
+	}
+	if _, _, ok := l.hit(l.tasksX-1, l.itemsTop); ok {
+		t.Fatal("expected the divider not to hit a panel")
+	}
+}
+
+func TestMouseClickSelectsAndToggles(t *testing.T) {
+	m, svc, work := newMouseModel(t)
+	l := m.layout()
+	click := func(x, y int) {
+		m.Update(tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+		m.Update(tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+	}
+
+	click(l.listsX+4, l.itemsTop)
+	if m.focus != focusLists || m.listCursor != 0 {
+		t.Fatalf("expected click to select the first list, got focus=%v cursor=%d", m.focus, m.listCursor)
+	}
+
+	click(l.listsX+4, l.itemsTop+1)
+	click(l.tasksX+10, l.itemsTop+1)
+	if m.focus != focusTasks || m.taskCursor != 1 {
+		t.Fatalf("expected click to select task B, got focus=%v cursor=%d", m.focus, m.taskCursor)
+	}
+
+	click(l.tasksX+4, l.itemsTop)
+	if tasks := svc.Tasks(work.ID); tasks[len(tasks)-1].Text != "A" || !tasks[len(tasks)-1].Done {
+		t.Fatalf("expected the checkbox click to complete A, got %+v", tasks)
+	}
+
+	m.Update(tea.MouseMsg{X: l.listsX + 4, Y: l.itemsTop, Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress})
+	if m.focus != focusLists || m.listCursor != 0 {
+		t.Fatalf("expected the wheel to move the lists cursor, got focus=%v cursor=%d", m.focus, m.listCursor)
+	}
+}
+
+func TestMouseDragReordersTasks(t *testing.T) {
+	m, svc, work := newMouseModel(t)
+	l := m.layout()
+	x := l.tasksX + 10
+
+	m.Update(tea.MouseMsg{X: x, Y: l.itemsTop, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	m.Update(tea.MouseMsg{X: x, Y: l.itemsTop + 1, Button: tea.MouseButtonLeft, Action: tea.MouseActionMotion})
+	m.Update(tea.MouseMsg{X: x, Y: l.itemsTop + 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionMotion})
+	if got := taskTexts(svc, work.ID); got != "ABCD" {
+		t.Fatalf("expected no change before the drop, got %s", got)
+	}
+	m.Update(tea.MouseMsg{X: x, Y: l.itemsTop + 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+	if got := taskTexts(svc, work.ID); got != "BCAD" || m.taskCursor != 2 {
+		t.Fatalf("expected A dropped on the third row, got %s (cursor %d)", got, m.taskCursor)
+	}
+
+	m.undo()
+	if got := taskTexts(svc, work.ID); got != "ABCD" {
+		t.Fatalf("expected one undo to revert the drag, got %s", got)
+	}
+}
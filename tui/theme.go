@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"todo-cli/i18n"
+	"todo-cli/model"
+)
+
+// ErrInvalidTheme is wrapped by theme files that cannot be used.
+var ErrInvalidTheme = errors.New("invalid theme")
+
+// Semantic style names, as written in theme files.
+const (
+	styleSummary           = "summary"
+	styleMuted             = "muted"
+	styleHint              = "hint"
+	stylePanelBorder       = "panel.border"
+	stylePanelBorderActive = "panel.border.active"
+	styleDividerLists      = "divider.lists"
+	styleDividerTasks      = "divider.tasks"
+	styleSelected          = "selected"
+	styleMarker            = "marker"
+	stylePriorityNone      = "priority.none"
+	stylePriorityLow       = "priority.low"
+	stylePriorityMedium    = "priority.medium"
+	stylePriorityHigh      = "priority.high"
+	styleDone              = "done"
+	styleStatus            = "status"
+	styleStatusError       = "status.error"
+	stylePrompt            = "prompt"
+	styleOverlayBorder     = "overlay.border"
+	styleSection           = "section"
+	styleText              = "text"
+	styleOnboardingBorder  = "onboarding.border"
+	styleDiffAdded         = "diff.added"
+	styleDiffRemoved       = "diff.removed"
+	styleDiffChanged       = "diff.changed"
+)
+
+// faint is a style value meaning "dim the default color" instead of a color.
+const faint = "faint"
+
+// Built-in theme names.
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+)
+
+// builtinThemes maps each style to an ANSI 256 color, a hex color or faint.
+var builtinThemes = map[string]map[string]string{
+	ThemeDark: {
+		styleSummary:           "241",
+		styleMuted:             "244",
+		styleHint:              "245",
+		stylePanelBorder:       "240",
+		stylePanelBorderActive: "39",
+		styleDividerLists:      "33",
+		styleDividerTasks:      "70",
+		styleSelected:          "229",
+		styleMarker:            "10",
+		stylePriorityNone:      "240",
+		stylePriorityLow:       "114",
+		stylePriorityMedium:    "220",
+		stylePriorityHigh:      "203",
+		styleDone:              faint,
+		styleStatus:            "70",
+		styleStatusError:       "9",
+		stylePrompt:            "220",
+		styleOverlayBorder:     "244",
+		styleSection:           "111",
+		styleText:              "252",
+		styleOnboardingBorder:  "63",
+		styleDiffAdded:         "114",
+		styleDiffRemoved:       "203",
+		styleDiffChanged:       "220",
+	},
+	ThemeLight: {
+		styleSummary:           "244",
+		styleMuted:             "243",
+		styleHint:              "242",
+		stylePanelBorder:       "250",
+		stylePanelBorderActive: "25",
+		styleDividerLists:      "25",
+		styleDividerTasks:      "28",
+		styleSelected:          "25",
+		styleMarker:            "28",
+		stylePriorityNone:      "249",
+		stylePriorityLow:       "28",
+		stylePriorityMedium:    "130",
+		stylePriorityHigh:      "160",
+		styleDone:              faint,
+		styleStatus:            "28",
+		styleStatusError:       "160",
+		stylePrompt:            "130",
+		styleOverlayBorder:     "245",
+		styleSection:           "25",
+		styleText:              "236",
+		styleOnboardingBorder:  "61",
+		styleDiffAdded:         "28",
+		styleDiffRemoved:       "160",
+		styleDiffChanged:       "130",
+	},
+	// Só as 16 cores básicas, que o terminal ajusta ao próprio fundo, e nada
+	// esmaecido: concluídas ficam em cinza em vez de faint.
+	ThemeHighContrast: {
+		styleSummary:           "15",
+		styleMuted:             "7",
+		styleHint:              "15",
+		stylePanelBorder:       "7",
+		stylePanelBorderActive: "15",
+		styleDividerLists:      "14",
+		styleDividerTasks:      "10",
+		styleSelected:          "11",
+		styleMarker:            "10",
+		stylePriorityNone:      "7",
+		stylePriorityLow:       "10",
+		stylePriorityMedium:    "11",
+		stylePriorityHigh:      "9",
+		styleDone:              "7",
+		styleStatus:            "10",
+		styleStatusError:       "9",
+		stylePrompt:            "11",
+		styleOverlayBorder:     "15",
+		styleSection:           "14",
+		styleText:              "15",
+		styleOnboardingBorder:  "14",
+		styleDiffAdded:         "10",
+		styleDiffRemoved:       "9",
+		styleDiffChanged:       "11",
+	},
+}
+
+// Theme holds the semantic styles of the TUI.
+type Theme struct {
+	name   string
+	styles map[string]string
+}
+
+// DefaultTheme returns the dark theme, the original look of the TUI.
+func DefaultTheme() Theme {
+	t, _ := BuiltinTheme(ThemeDark)
+	return t
+}
+
+// BuiltinTheme returns one of ThemeDark, ThemeLight or ThemeHighContrast.
+func BuiltinTheme(name string) (Theme, bool) {
+	styles, ok := builtinThemes[name]
+	if !ok {
+		return Theme{}, false
+	}
+	return Theme{name: name, styles: maps.Clone(styles)}, true
+}
+
+// ThemeNames lists the built-in themes.
+func ThemeNames() []string {
+	return slices.Sorted(maps.Keys(builtinThemes))
+}
+
+// Name returns the built-in name, or the base name of the theme file.
+func (t Theme) Name() string {
+	return t.name
+}
+
+// themeFile is the JSON layout of a theme file: a built-in base (dark by
+// default) and the styles it overrides.
+type themeFile struct {
+	Base   string            `json:"base"`
+	Styles map[string]string `json:"styles"`
+}
+
+// LoadTheme returns the built-in theme called source or, otherwise, reads
+// source as a theme file. An empty source yields DefaultTheme.
+func LoadTheme(source string) (Theme, error) {
+	if source == "" {
+		return DefaultTheme(), nil
+	}
+	if t, ok := BuiltinTheme(source); ok {
+		return t, nil
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !strings.ContainsAny(source, `/\.`) {
+			return Theme{}, i18n.Errorf("theme.unknown", ErrInvalidTheme, source, strings.Join(ThemeNames(), ", "))
+		}
+		return Theme{}, err
+	}
+	var f themeFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Theme{}, fmt.Errorf("%s: %w: %v", filepath.Base(source), ErrInvalidTheme, err)
+	}
+	t, err := newTheme(f)
+	if err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", filepath.Base(source), err)
+	}
+	t.name = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	return t, nil
+}
+
+func newTheme(f themeFile) (Theme, error) {
+	base := f.Base
+	if base == "" {
+		base = ThemeDark
+	}
+	t, ok := BuiltinTheme(base)
+	if !ok {
+		return Theme{}, i18n.Errorf("theme.unknown_base", ErrInvalidTheme, base)
+	}
+	for _, name := range slices.Sorted(maps.Keys(f.Styles)) {
+		value := strings.TrimSpace(f.Styles[name])
+		if _, ok := t.styles[name]; !ok {
+			return Theme{}, i18n.Errorf("theme.unknown_style", ErrInvalidTheme, name)
+		}
+		if value != faint && !validColor(value) {
+			return Theme{}, i18n.Errorf("theme.invalid_color", ErrInvalidTheme, name, value)
+		}
+		t.styles[name] = value
+	}
+	return t, nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColor accepts ANSI 256 colors ("0".."255") and hex colors ("#rgb",
+// "#rrggbb").
+func validColor(v string) bool {
+	if hexColor.MatchString(v) {
+		return true
+	}
+	n, err := strconv.Atoi(v)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// style returns a foreground style for the semantic name.
+func (t Theme) style(name string) lipgloss.Style {
+	s := lipgloss.NewStyle()
+	v := t.styles[name]
+	if v == faint {
+		return s.Faint(true)
+	}
+	if v == "" {
+		return s
+	}
+	return s.Foreground(lipgloss.Color(v))
+}
+
+// color returns the color of a semantic name, for borders.
+func (t Theme) color(name string) lipgloss.TerminalColor {
+	v := t.styles[name]
+	if v == "" || v == faint {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(v)
+}
+
+func (t Theme) priorityIndicator(p model.Priority) string {
+	switch p {
+	case model.PriorityLow:
+		return t.style(stylePriorityLow).Render("●")
+	case model.PriorityMedium:
+		return t.style(stylePriorityMedium).Render("●")
+	case model.PriorityHigh:
+		return t.style(stylePriorityHigh).Render("●")
+	}
+	return t.style(stylePriorityNone).Render("•")
+}
+
+func (t Theme) panelTitle(title string, active bool) string {
+	base := lipgloss.NewStyle().Bold(true)
+	if !active {
+		return base.Render(title)
+	}
+	text := t.style(styleSelected).Bold(true).Render(title)
+	marker := t.style(styleMarker).Bold(true).Render("*")
+	return lipgloss.JoinHorizontal(lipgloss.Left, text, " ", marker)
+}
+
+// colorForName maps a list color to a terminal color: one of the named
+// colors, or any hex color such as "#ff8800". Unknown names use the
+// terminal's default foreground.
+func colorForName(name string) lipgloss.TerminalColor {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if hexColor.MatchString(name) {
+		return lipgloss.Color(name)
+	}
+	switch name {
+	case "blue":
+		return lipgloss.Color("12")
+	case "green":
+		return lipgloss.Color("10")
+	case "yellow":
+		return lipgloss.Color("11")
+	case "magenta", "purple":
+		return lipgloss.Color("13")
+	case "cyan":
+		return lipgloss.Color("14")
+	case "red":
+		return lipgloss.Color("9")
+	default:
+		return lipgloss.NoColor{}
+	}
+}
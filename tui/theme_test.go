@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLoadTheme(t *testing.T) {
+	for _, name := range ThemeNames() {
+		theme, err := LoadTheme(name)
+		if err != nil || theme.Name() != name {
+			t.Fatalf("built-in theme %q failed: %v", name, err)
+		}
+		for style := range builtinThemes[ThemeDark] {
+			if theme.styles[style] == "" {
+				t.Fatalf("theme %q misses style %q", name, style)
+			}
+		}
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solar.json")
+	data := `{"base": "light", "styles": {"selected": "#268bd2", "priority.high": "196", "done": "faint"}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write theme failed: %v", err)
+	}
+	theme, err := LoadTheme(path)
+	if err != nil {
+		t.Fatalf("load theme failed: %v", err)
+	}
+	if theme.Name() != "solar" || theme.color(styleSelected) != lipgloss.Color("#268bd2") {
+		t.Fatalf("expected overridden selected color, got %+v", theme)
+	}
+	if theme.styles[styleText] != builtinThemes[ThemeLight][styleText] {
+		t.Fatal("styles left out must come from the base theme")
+	}
+
+	for _, bad := range []string{
+		`{"styles": {"selected": "#12345"}}`,
+		`{"styles": {"selectd": "229"}}`,
+		`{"base": "sepia"}`,
+	} {
+		if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+			t.Fatalf("write theme failed: %v", err)
+		}
+		if _, err := LoadTheme(path); !errors.Is(err, ErrInvalidTheme) {
+			t.Fatalf("expected ErrInvalidTheme for %s, got %v", bad, err)
+		}
+	}
+	if _, err := LoadTheme("sepia"); !errors.Is(err, ErrInvalidTheme) {
+		t.Fatalf("expected ErrInvalidTheme for unknown name, got %v", err)
+	}
+}
+
+func TestColorForNameAcceptsHex(t *testing.T) {
+	if got := colorForName(" #FF8800 "); got != lipgloss.Color("#ff8800") {
+		t.Fatalf("expected hex color, got %v", got)
+	}
+	if got := colorForName("blue"); got != lipgloss.Color("12") {
+		t.Fatalf("expected named color, got %v", got)
+	}
+	if got := colorForName("#ff88"); got != (lipgloss.NoColor{}) {
+		t.Fatalf("expected no color for malformed hex, got %v", got)
+	}
+}
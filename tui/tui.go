@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"todo-cli/app"
+	"todo-cli/exchange"
+	"todo-cli/i18n"
 	"todo-cli/model"
 	"todo-cli/store"
 )
@@ -25,9 +29,9 @@ const (
 
 func (f focusPane) String() string {
 	if f == focusTasks {
-		return "tarefas"
+		return i18n.T("focus.tasks")
 	}
-	return "listas"
+	return i18n.T("focus.lists")
 }
 
 type uiMode int
@@ -41,6 +45,15 @@ const (
 	modeSearch
 	modeConfirmDelete
 	modeConfirmArchive
+	modeBackups
+	modeConfirmRestore
+	modeExportMarkdown
+	modeConfirmOverwrite
+	modeImportMarkdown
+	modeEditColumns
+	modeSetDue
+	modeCommandPalette
+	modeFinder
 )
 
 type deleteKind int
@@ -55,18 +68,49 @@ const (
 type Model struct {
 	svc       *app.Service
 	statePath string
+	store     *store.Store
 
 	focus         focusPane
 	mode          uiMode
 	listCursor    int
 	taskCursor    int
 	historyCursor int
-	input         string
+	input         lineEditor
+
+	// Primeira linha visível de cada painel; ajustada ao renderizar para
+	// manter o cursor à vista.
+	listOffset    int
+	taskOffset    int
+	historyOffset int
+	agendaOffset  int
+
+	// dragTaskID is the task being dragged with the mouse, picked up at
+	// visible row dragFrom.
+	dragTaskID string
+	dragFrom   int
+
+	// commandAction is the action whose argument the command palette is
+	// asking for, or empty while it lists the actions.
+	commandAction string
+	commandCursor int
+
+	// finder is the snapshot of lists and tasks the fuzzy finder searches,
+	// and finderMatches the results for finderQuery.
+	finder        []finderEntry
+	finderQuery   string
+	finderMatches []finderMatch
+	finderCursor  int
+
+	// inputHistories keeps what was entered in each input mode this session.
+	inputHistories map[uiMode]*inputHistory
 
 	confirmKind deleteKind
 	confirmID   string
 	confirmName string
 
+	// overwritePath is the existing file a Markdown export would replace.
+	overwritePath string
+
 	archiveListID   string
 	archiveListName string
 	archiveCount    int
@@ -74,10 +118,27 @@ type Model struct {
 
 	showHistory bool
 	showHelp    bool
+	showBoard   bool
+	showAgenda  bool
+
+	keys        Keymap
+	pendingKeys []string
+	theme       Theme
+
+	backups      []store.BackupInfo
+	backupCursor int
+	backupDiff   app.StateDiff
+	backupErr    error
 
 	status    string
 	statusErr bool
 
+	hookFailures <-chan error
+
+	// dirty is set by service events (possibly from other goroutines) and
+	// cleared by saveIfDirty, which writes once per handled message.
+	dirty atomic.Bool
+
 	width  int
 	height int
 
@@ -87,45 +148,108 @@ type Model struct {
 func NewModel(svc *app.Service, statePath, startupStatus string) *Model {
 	status := strings.TrimSpace(startupStatus)
 	if status == "" {
-		status = "Pronto"
+		status = i18n.T("status.ready")
 	}
 
 	m := &Model{
 		svc:       svc,
 		statePath: statePath,
+		store:     store.New(statePath, store.DefaultOptions()),
 		focus:     focusLists,
 		mode:      modeNormal,
 		status:    status,
+		keys:      DefaultKeymap(),
+		theme:     DefaultTheme(),
 		palette:   []string{"blue", "green", "yellow", "magenta", "cyan", "red"},
 	}
+	svc.Subscribe(m.onEvent)
 	m.restoreSessionContext()
 	m.ensureSelection()
 
 	if startupStatus == "" && m.shouldShowOnboarding() {
-		m.setStatus("Bem-vindo. Pressione 'a' em Listas para criar sua primeira lista.", false)
+		m.setStatus(i18n.T("status.welcome"), false)
 	}
 
 	return m
 }
 
+// SetStore replaces the default store, e.g. with one built from the user config.
+func (m *Model) SetStore(st *store.Store) {
+	m.store = st
+	m.statePath = st.Path()
+}
+
+// SetKeymap replaces the default key bindings, e.g. with LoadKeymap's result.
+func (m *Model) SetKeymap(k Keymap) {
+	m.keys = k
+	m.pendingKeys = nil
+}
+
+// SetTheme replaces the default theme, e.g. with LoadTheme's result.
+func (m *Model) SetTheme(t Theme) {
+	m.theme = t
+}
+
+// WatchHooks shows post-hook failures from ch in the status bar.
+func (m *Model) WatchHooks(ch <-chan error) {
+	m.hookFailures = ch
+}
+
+type hookFailedMsg struct{ err error }
+
+func (m *Model) waitHookFailure() tea.Cmd {
+	ch := m.hookFailures
+	if ch == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		err, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return hookFailedMsg{err: err}
+	}
+}
+
 func (m *Model) Init() tea.Cmd {
-	return nil
+	return m.waitHookFailure()
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer func() { _ = m.saveIfDirty() }()
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+	case hookFailedMsg:
+		m.setStatus(i18n.T("status.hook_failed", i18n.Error(msg.err)), true)
+		return m, m.waitHookFailure()
+	case tea.MouseMsg:
+		if m.mode == modeNormal && !m.showHelp {
+			m.updateMouse(msg)
+		}
 	case tea.KeyMsg:
 		switch m.mode {
-		case modeAddList, modeAddTask, modeRenameList, modeEditTask, modeSearch:
+		case modeAddList, modeAddTask, modeRenameList, modeEditTask, modeSearch, modeExportMarkdown, modeImportMarkdown, modeEditColumns, modeSetDue:
 			m.updateInputMode(msg)
 		case modeConfirmDelete, modeConfirmArchive:
 			m.updateConfirmMode(msg)
+		case modeCommandPalette:
+			if quit := m.updateCommandPalette(msg); quit {
+				_ = m.syncSession()
+				return m, tea.Quit
+			}
+		case modeFinder:
+			m.updateFinder(msg)
+		case modeBackups:
+			m.updateBackupsMode(msg)
+		case modeConfirmRestore:
+			m.updateConfirmRestoreMode(msg)
+		case modeConfirmOverwrite:
+			m.updateConfirmOverwriteMode(msg)
 		default:
 			if quit := m.updateNormalMode(msg); quit {
-				_ = m.persistContextSilently()
+				_ = m.syncSession()
 				return m, tea.Quit
 			}
 		}
@@ -134,133 +258,147 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) updateNormalMode(msg tea.KeyMsg) bool {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	key := msg.String()
+	if key == quitKey {
+		return true
+	}
+	name, pending := m.keys.resolve(m.pendingKeys, key)
+	m.pendingKeys = pending
+	if len(pending) > 0 {
+		m.setStatus(i18n.T("status.pending_keys", formatSequence(pending)), false)
+		return false
+	}
+	if name == actionQuit {
 		return true
-	case "tab":
-		if m.focus == focusLists {
-			m.focus = focusTasks
-		} else {
-			m.focus = focusLists
-		}
-		_ = m.persistContextSilently()
-		m.setStatus(fmt.Sprintf("Foco em %s", m.focus.String()), false)
-	case "j", "down":
-		m.moveCursor(1)
-	case "k", "up":
-		m.moveCursor(-1)
-	case "enter":
-		m.handleEnter()
-	case "a":
-		m.startAdd()
-	case "r":
-		m.startRenameList()
-	case "e":
-		m.startEditTask()
-	case "x":
-		m.toggleTaskDone()
-	case "d":
-		m.startDeleteConfirm()
-	case "u":
-		m.undo()
-	case "f":
-		m.cycleFilter()
-	case "J":
-		m.moveSelected(1)
-	case "K":
-		m.moveSelected(-1)
-	case "1":
-		m.setSelectedTaskPriority(model.PriorityNone)
-	case "2":
-		m.setSelectedTaskPriority(model.PriorityLow)
-	case "3":
-		m.setSelectedTaskPriority(model.PriorityMedium)
-	case "4":
-		m.setSelectedTaskPriority(model.PriorityHigh)
-	case "c":
-		m.cycleListColor()
-	case "C":
-		m.startArchiveConfirm()
-	case "A":
-		m.startArchiveAllConfirm()
-	case "D":
-		m.startDeleteAllConfirm()
-	case "y":
-		m.copyActiveTodos()
-	case "h":
-		m.toggleHistory()
-	case "/":
-		m.mode = modeSearch
-		m.input = m.svc.State().Query
-		m.setStatus("Busca incremental ativa: digite para filtrar em tempo real", false)
-	case "?":
-		m.showHelp = !m.showHelp
-		if m.showHelp {
-			m.setStatus("Atalhos abertos (pressione ? ou Esc para fechar)", false)
-		} else {
-			m.setStatus("Atalhos ocultos", false)
-		}
-	case "esc":
-		if m.showHelp {
-			m.showHelp = false
-			m.setStatus("Atalhos ocultos", false)
-			break
-		}
-		if strings.TrimSpace(m.svc.State().Query) != "" {
-			m.svc.SetQuery("")
-			m.taskCursor = 0
-			m.persist("Busca limpa")
-		}
+	}
+	if a, ok := findAction(name); ok {
+		a.run(m)
 	}
 
 	m.ensureSelection()
 	return false
 }
 
+func (m *Model) toggleFocus() {
+	if m.focus == focusLists {
+		m.focus = focusTasks
+	} else {
+		m.focus = focusLists
+	}
+	_ = m.syncSession()
+	m.setStatus(i18n.T("status.focus", m.focus.String()), false)
+}
+
+func (m *Model) startSearch() {
+	m.startInput(modeSearch, m.svc.State().Query)
+	m.setStatus(i18n.T("status.search_active"), false)
+}
+
+func (m *Model) toggleHelp() {
+	m.showHelp = !m.showHelp
+	if m.showHelp {
+		m.setStatus(i18n.T("status.help_opened", m.helpCloseKeys()), false)
+	} else {
+		m.setStatus(i18n.T("status.help_closed"), false)
+	}
+}
+
+// back closes the help overlay or, without it, clears the search.
+func (m *Model) back() {
+	if m.showHelp {
+		m.showHelp = false
+		m.setStatus(i18n.T("status.help_closed"), false)
+		return
+	}
+	if strings.TrimSpace(m.svc.State().Query) != "" {
+		m.svc.SetQuery("")
+		m.taskCursor = 0
+		m.persist(i18n.T("status.search_cleared"))
+	}
+}
+
+// helpCloseKeys lists the keys that close the help overlay, e.g. "Esc/?".
+func (m *Model) helpCloseKeys() string {
+	var keys []string
+	for _, name := range []string{actionBack, actionHelp} {
+		if k := m.keys.keysFor(name); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return strings.Join(keys, "/")
+}
+
 func (m *Model) updateInputMode(msg tea.KeyMsg) {
 	switch msg.String() {
 	case "ctrl+c":
 		if m.mode == modeSearch {
 			m.svc.SetQuery("")
 			m.taskCursor = 0
-			m.persist("Busca limpa")
+			m.persist(i18n.T("status.search_cleared"))
 		}
 		m.mode = modeNormal
-		m.input = ""
-		m.setStatus("Cancelado", false)
+		m.input.Reset()
+		m.setStatus(i18n.T("status.cancelled"), false)
 		return
 	case "esc":
 		if m.mode == modeSearch {
 			m.svc.SetQuery("")
 			m.taskCursor = 0
-			m.persist("Busca limpa")
+			m.persist(i18n.T("status.search_cleared"))
 		} else {
-			m.setStatus("Cancelado", false)
+			m.setStatus(i18n.T("status.cancelled"), false)
 		}
 		m.mode = modeNormal
-		m.input = ""
+		m.input.Reset()
 		return
 	case "enter":
+		m.historyFor(m.mode).add(m.input.String())
 		m.applyInput()
 		return
-	}
-
-	switch msg.Type {
-	case tea.KeyBackspace, tea.KeyCtrlH:
-		m.input = trimLastRune(m.input)
-	case tea.KeySpace:
-		m.input += " "
-	case tea.KeyRunes:
-		m.input += string(msg.Runes)
+	case "up":
+		text, ok := m.historyFor(m.mode).prev(m.input.String())
+		if !ok {
+			return
+		}
+		m.input.Set(text)
+	case "down":
+		text, ok := m.historyFor(m.mode).next()
+		if !ok {
+			return
+		}
+		m.input.Set(text)
+	default:
+		if !m.input.Update(msg) {
+			return
+		}
 	}
 
 	if m.mode == modeSearch {
-		m.svc.SetQuery(strings.TrimSpace(m.input))
+		m.svc.SetQuery(strings.TrimSpace(m.input.String()))
 		m.taskCursor = 0
 		m.ensureSelection()
 	}
 }
 
+// startInput enters an input mode with text ready for editing.
+func (m *Model) startInput(mode uiMode, text string) {
+	m.mode = mode
+	m.input.Set(text)
+	m.historyFor(mode).reset()
+}
+
+func (m *Model) historyFor(mode uiMode) *inputHistory {
+	h, ok := m.inputHistories[mode]
+	if !ok {
+		if m.inputHistories == nil {
+			m.inputHistories = make(map[uiMode]*inputHistory)
+		}
+		h = &inputHistory{}
+		m.inputHistories[mode] = h
+	}
+	return h
+}
+
 func (m *Model) updateConfirmMode(msg tea.KeyMsg) {
 	switch strings.ToLower(msg.String()) {
 	case "y":
@@ -282,96 +420,104 @@ func (m *Model) updateConfirmMode(msg tea.KeyMsg) {
 			m.confirmName = ""
 		}
 		m.mode = modeNormal
-		m.setStatus("Ação cancelada", false)
+		m.setStatus(i18n.T("status.action_cancelled"), false)
 	}
 }
 
 func (m *Model) applyInput() {
-	text := strings.TrimSpace(m.input)
+	text := strings.TrimSpace(m.input.String())
 	switch m.mode {
 	case modeAddList:
 		if text == "" {
-			m.setStatus("Nome da lista não pode ser vazio", true)
+			m.setStatus(i18n.T("status.list_name_empty"), true)
 			return
 		}
 		color := m.palette[len(m.svc.Lists())%len(m.palette)]
 		if _, err := m.svc.CreateList(text, color); err != nil {
-			m.setStatus("Erro ao criar lista: "+err.Error(), true)
+			m.setStatus(i18n.T("error.create_list", i18n.Error(err)), true)
 			return
 		}
 		m.listCursor = len(m.svc.Lists()) - 1
 		m.mode = modeNormal
-		m.input = ""
-		m.persist("Lista criada")
+		m.input.Reset()
+		m.persist(i18n.T("status.list_created"))
 	case modeAddTask:
 		if text == "" {
-			m.setStatus("Texto da tarefa não pode ser vazio", true)
+			m.setStatus(i18n.T("status.task_text_empty"), true)
 			return
 		}
 		list, ok := m.activeList()
 		if !ok {
-			m.setStatus("Crie uma lista antes de adicionar tarefas", true)
+			m.setStatus(i18n.T("status.create_list_first"), true)
 			m.mode = modeNormal
-			m.input = ""
+			m.input.Reset()
 			return
 		}
 		task, err := m.svc.CreateTask(list.ID, text)
 		if err != nil {
-			m.setStatus("Erro ao criar tarefa: "+err.Error(), true)
+			m.setStatus(i18n.T("error.create_task", i18n.Error(err)), true)
 			return
 		}
 		m.mode = modeNormal
-		m.input = ""
+		m.input.Reset()
 		m.taskCursor = m.indexOfTask(task.ID)
-		m.persist("Tarefa criada")
+		m.persist(i18n.T("status.task_created"))
 	case modeRenameList:
 		if text == "" {
-			m.setStatus("Nome da lista não pode ser vazio", true)
+			m.setStatus(i18n.T("status.list_name_empty"), true)
 			return
 		}
 		list, ok := m.activeList()
 		if !ok {
 			m.mode = modeNormal
-			m.input = ""
-			m.setStatus("Nenhuma lista selecionada", true)
+			m.input.Reset()
+			m.setStatus(i18n.T("status.no_list_selected"), true)
 			return
 		}
 		if _, err := m.svc.UpdateList(list.ID, text, list.Color); err != nil {
-			m.setStatus("Erro ao renomear lista: "+err.Error(), true)
+			m.setStatus(i18n.T("error.rename_list", i18n.Error(err)), true)
 			return
 		}
 		m.mode = modeNormal
-		m.input = ""
-		m.persist("Lista renomeada")
+		m.input.Reset()
+		m.persist(i18n.T("status.list_renamed"))
 	case modeEditTask:
 		if text == "" {
-			m.setStatus("Texto da tarefa não pode ser vazio", true)
+			m.setStatus(i18n.T("status.task_text_empty"), true)
 			return
 		}
 		task, ok := m.selectedTask()
 		if !ok {
 			m.mode = modeNormal
-			m.input = ""
-			m.setStatus("Nenhuma tarefa selecionada", true)
+			m.input.Reset()
+			m.setStatus(i18n.T("status.no_task_selected"), true)
 			return
 		}
 		if _, err := m.svc.UpdateTask(task.ID, text); err != nil {
-			m.setStatus("Erro ao editar tarefa: "+err.Error(), true)
+			m.setStatus(i18n.T("error.edit_task", i18n.Error(err)), true)
 			return
 		}
 		m.mode = modeNormal
-		m.input = ""
-		m.persist("Tarefa atualizada")
+		m.input.Reset()
+		m.persist(i18n.T("status.task_updated"))
 	case modeSearch:
 		m.svc.SetQuery(text)
 		m.mode = modeNormal
-		m.input = ""
+		m.input.Reset()
 		m.taskCursor = 0
 		if text == "" {
-			m.persist("Busca limpa")
+			m.persist(i18n.T("status.search_cleared"))
 			return
 		}
-		m.persist("Busca aplicada")
+		m.persist(i18n.T("status.search_applied"))
+	case modeExportMarkdown:
+		m.exportMarkdown(text, false)
+	case modeImportMarkdown:
+		m.importMarkdown(text)
+	case modeEditColumns:
+		m.applyColumns(text)
+	case modeSetDue:
+		m.applyDue(text)
 	}
 }
 
@@ -385,7 +531,7 @@ func (m *Model) moveCursor(delta int) {
 		m.listCursor = clamp(m.listCursor+delta, 0, len(lists)-1)
 		m.taskCursor = 0
 		if m.listCursor != old {
-			_ = m.persistContextSilently()
+			_ = m.syncSession()
 		}
 		return
 	}
@@ -398,6 +544,10 @@ func (m *Model) moveCursor(delta int) {
 		m.historyCursor = clamp(m.historyCursor+delta, 0, len(entries)-1)
 		return
 	}
+	if m.showBoard {
+		m.moveBoardCursor(delta)
+		return
+	}
 
 	tasks := m.visibleTasks()
 	if len(tasks) == 0 {
@@ -412,89 +562,89 @@ func (m *Model) handleEnter() {
 	}
 	list, ok := m.activeList()
 	if !ok {
-		m.setStatus("Sem listas. Pressione 'a' para criar a primeira", false)
+		m.setStatus(i18n.T("status.no_lists"), false)
 		return
 	}
 	m.taskCursor = 0
-	_ = m.persistContextSilently()
-	m.setStatus(fmt.Sprintf("Lista ativa: %s", list.Name), false)
+	_ = m.syncSession()
+	m.setStatus(i18n.T("status.active_list", list.Name), false)
 }
 
 func (m *Model) startAdd() {
 	if m.focus == focusLists {
-		m.mode = modeAddList
-		m.input = ""
+		m.startInput(modeAddList, "")
 		return
 	}
 
 	if m.showHistory {
-		m.setStatus("Feche o histórico ('h') para adicionar tarefas", false)
+		m.setStatus(i18n.T("status.history_close_to_add"), false)
+		return
+	}
+	if m.showAgenda {
+		m.setStatus(i18n.T("status.agenda_close_to_add"), false)
 		return
 	}
 
 	if _, ok := m.activeList(); !ok {
-		m.setStatus("Crie uma lista antes de adicionar tarefas", true)
+		m.setStatus(i18n.T("status.create_list_first"), true)
 		return
 	}
-	m.mode = modeAddTask
-	m.input = ""
+	m.startInput(modeAddTask, "")
 }
 
 func (m *Model) startRenameList() {
 	if m.focus != focusLists {
-		m.setStatus("Renomear lista: mude o foco para Listas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_lists.rename"), false)
 		return
 	}
 	list, ok := m.activeList()
 	if !ok {
-		m.setStatus("Nenhuma lista selecionada", true)
+		m.setStatus(i18n.T("status.no_list_selected"), true)
 		return
 	}
-	m.mode = modeRenameList
-	m.input = list.Name
+	m.startInput(modeRenameList, list.Name)
 }
 
 func (m *Model) startEditTask() {
 	if m.focus != focusTasks {
-		m.setStatus("Editar tarefa: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.edit"), false)
 		return
 	}
 	if m.showHistory {
-		m.setStatus("Histórico é somente leitura. Pressione 'h' para voltar.", false)
+		m.setStatus(i18n.T("status.history_read_only"), false)
 		return
 	}
 	task, ok := m.selectedTask()
 	if !ok {
-		m.setStatus("Nenhuma tarefa selecionada", true)
+		m.setStatus(i18n.T("status.no_task_selected"), true)
 		return
 	}
-	m.mode = modeEditTask
-	m.input = task.Text
+	m.startInput(modeEditTask, task.Text)
 }
 
 func (m *Model) toggleTaskDone() {
 	if m.focus != focusTasks {
-		m.setStatus("Marcar tarefa: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.toggle"), false)
 		return
 	}
 	if m.showHistory {
-		m.setStatus("Histórico é somente leitura. Pressione 'h' para voltar.", false)
+		m.setStatus(i18n.T("status.history_read_only"), false)
 		return
 	}
 	task, ok := m.selectedTask()
 	if !ok {
-		m.setStatus("Nenhuma tarefa selecionada", true)
+		m.setStatus(i18n.T("status.no_task_selected"), true)
 		return
 	}
 	updated, err := m.svc.ToggleDone(task.ID)
 	if err != nil {
-		m.setStatus("Erro ao alternar tarefa: "+err.Error(), true)
+		m.setStatus(i18n.T("error.toggle_task", i18n.Error(err)), true)
 		return
 	}
 	if updated.Done {
-		m.persist("Tarefa concluída")
+		m.persist(i18n.T("status.task_done"))
 	} else {
-		m.persist("Tarefa reaberta")
+		m.persist(i18n.T("status.task_reopened"))
 	}
 }
 
@@ -513,7 +663,7 @@ func (m *Model) moveSelected(delta int) {
 func (m *Model) moveSelectedList(delta int) {
 	list, ok := m.activeList()
 	if !ok {
-		m.setStatus("Nenhuma lista selecionada", true)
+		m.setStatus(i18n.T("status.no_list_selected"), true)
 		return
 	}
 
@@ -526,11 +676,11 @@ func (m *Model) moveSelectedList(delta int) {
 	if err != nil {
 		switch err {
 		case app.ErrListAlreadyAtTop:
-			m.setStatus("A lista já está no topo", false)
+			m.setStatus(i18n.T("status.list_at_top"), false)
 		case app.ErrListAlreadyAtBottom:
-			m.setStatus("A lista já está no fim", false)
+			m.setStatus(i18n.T("status.list_at_bottom"), false)
 		default:
-			m.setStatus("Erro ao mover lista: "+err.Error(), true)
+			m.setStatus(i18n.T("error.move_list", i18n.Error(err)), true)
 		}
 		return
 	}
@@ -541,17 +691,21 @@ func (m *Model) moveSelectedList(delta int) {
 		m.listCursor++
 	}
 	m.ensureSelection()
-	m.persist("Ordem das listas atualizada")
+	m.persist(i18n.T("status.lists_reordered"))
 }
 
 func (m *Model) moveSelectedTask(delta int) {
 	if m.focus != focusTasks {
-		m.setStatus("Ordenar tarefa: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.move"), false)
+		return
+	}
+	if m.showAgenda {
+		m.setStatus(i18n.T("status.agenda_no_reorder"), false)
 		return
 	}
 	task, ok := m.selectedTask()
 	if !ok {
-		m.setStatus("Nenhuma tarefa selecionada", true)
+		m.setStatus(i18n.T("status.no_task_selected"), true)
 		return
 	}
 
@@ -564,11 +718,11 @@ func (m *Model) moveSelectedTask(delta int) {
 	if err != nil {
 		switch err {
 		case app.ErrTaskAlreadyAtTop:
-			m.setStatus("A tarefa já está no topo", false)
+			m.setStatus(i18n.T("status.task_at_top"), false)
 		case app.ErrTaskAlreadyAtBottom:
-			m.setStatus("A tarefa já está no fim", false)
+			m.setStatus(i18n.T("status.task_at_bottom"), false)
 		default:
-			m.setStatus("Erro ao mover tarefa: "+err.Error(), true)
+			m.setStatus(i18n.T("error.move_task", i18n.Error(err)), true)
 		}
 		return
 	}
@@ -579,55 +733,55 @@ func (m *Model) moveSelectedTask(delta int) {
 		m.taskCursor++
 	}
 	m.ensureSelection()
-	m.persist("Ordem das tarefas atualizada")
+	m.persist(i18n.T("status.tasks_reordered"))
 }
 
 func (m *Model) setSelectedTaskPriority(priority model.Priority) {
 	if m.focus != focusTasks {
-		m.setStatus("Prioridade: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.prio"), false)
 		return
 	}
 	if m.showHistory {
-		m.setStatus("Histórico é somente leitura. Pressione 'h' para voltar.", false)
+		m.setStatus(i18n.T("status.history_read_only"), false)
 		return
 	}
 	task, ok := m.selectedTask()
 	if !ok {
-		m.setStatus("Nenhuma tarefa selecionada", true)
+		m.setStatus(i18n.T("status.no_task_selected"), true)
 		return
 	}
 	updated, err := m.svc.SetTaskPriority(task.ID, priority)
 	if err != nil {
-		m.setStatus("Erro ao ajustar prioridade: "+err.Error(), true)
+		m.setStatus(i18n.T("error.priority", i18n.Error(err)), true)
 		return
 	}
-	m.persist(fmt.Sprintf("Prioridade: %s", priorityLabel(updated.Priority)))
+	m.persist(i18n.T("status.priority", priorityLabel(updated.Priority)))
 }
 
 func (m *Model) undo() {
 	if err := m.svc.Undo(); err != nil {
 		if err == app.ErrNothingToUndo {
-			m.setStatus("Nada para desfazer", false)
+			m.setStatus(i18n.T("status.nothing_to_undo"), false)
 			return
 		}
-		m.setStatus("Erro ao desfazer: "+err.Error(), true)
+		m.setStatus(i18n.T("error.undo", i18n.Error(err)), true)
 		return
 	}
 	m.showHistory = false
-	m.persist("Undo aplicado")
+	m.persist(i18n.T("status.undone"))
 }
 
 func (m *Model) cycleFilter() {
 	if m.focus != focusTasks {
-		m.setStatus("Filtro de tarefas: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.filter"), false)
 		return
 	}
 	if m.showHistory {
-		m.setStatus("Filtro vale para tarefas ativas. Pressione 'h' para voltar.", false)
+		m.setStatus(i18n.T("status.filter_in_history"), false)
 		return
 	}
 	if _, ok := m.activeList(); !ok {
-		m.setStatus("Crie uma lista para usar filtros", false)
+		m.setStatus(i18n.T("status.filter_needs_list"), false)
 		return
 	}
 	st := m.svc.State()
@@ -641,21 +795,21 @@ func (m *Model) cycleFilter() {
 		next = model.FilterAll
 	}
 	if err := m.svc.SetFilter(next); err != nil {
-		m.setStatus("Erro ao alterar filtro: "+err.Error(), true)
+		m.setStatus(i18n.T("error.filter", i18n.Error(err)), true)
 		return
 	}
 	m.taskCursor = 0
-	m.persist("Filtro: " + filterLabel(next))
+	m.persist(i18n.T("status.filter", filterLabel(next)))
 }
 
 func (m *Model) cycleListColor() {
 	if m.focus != focusLists {
-		m.setStatus("Cor da lista: mude o foco para Listas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_lists.color"), false)
 		return
 	}
 	list, ok := m.activeList()
 	if !ok {
-		m.setStatus("Nenhuma lista selecionada", true)
+		m.setStatus(i18n.T("status.no_list_selected"), true)
 		return
 	}
 	current := 0
@@ -667,24 +821,24 @@ func (m *Model) cycleListColor() {
 	}
 	nextColor := m.palette[(current+1)%len(m.palette)]
 	if _, err := m.svc.UpdateList(list.ID, list.Name, nextColor); err != nil {
-		m.setStatus("Erro ao trocar cor da lista: "+err.Error(), true)
+		m.setStatus(i18n.T("error.list_color", i18n.Error(err)), true)
 		return
 	}
-	m.persist("Cor da lista alterada")
+	m.persist(i18n.T("status.list_color"))
 }
 
 func (m *Model) startArchiveConfirm() {
 	if m.focus != focusTasks {
-		m.setStatus("Arquivar concluídas: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.arch"), false)
 		return
 	}
 	if m.showHistory {
-		m.setStatus("Você já está no histórico. Pressione 'h' para voltar.", false)
+		m.setStatus(i18n.T("status.history_already_open"), false)
 		return
 	}
 	list, ok := m.activeList()
 	if !ok {
-		m.setStatus("Nenhuma lista ativa", true)
+		m.setStatus(i18n.T("status.no_active_list"), true)
 		return
 	}
 
@@ -695,7 +849,7 @@ func (m *Model) startArchiveConfirm() {
 		}
 	}
 	if count == 0 {
-		m.setStatus("Não há tarefas concluídas para arquivar nesta lista", false)
+		m.setStatus(i18n.T("status.nothing_done_to_archive"), false)
 		return
 	}
 
@@ -708,21 +862,21 @@ func (m *Model) startArchiveConfirm() {
 
 func (m *Model) startArchiveAllConfirm() {
 	if m.focus != focusTasks {
-		m.setStatus("Arquivar todos: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.archall"), false)
 		return
 	}
 	if m.showHistory {
-		m.setStatus("Feche o histórico ('h') para arquivar todos os to-dos", false)
+		m.setStatus(i18n.T("status.history_close_to_arch"), false)
 		return
 	}
 	list, ok := m.activeList()
 	if !ok {
-		m.setStatus("Nenhuma lista ativa", true)
+		m.setStatus(i18n.T("status.no_active_list"), true)
 		return
 	}
 	count := len(m.svc.Tasks(list.ID))
 	if count == 0 {
-		m.setStatus("Não há to-dos para arquivar nesta lista", false)
+		m.setStatus(i18n.T("status.nothing_to_archive"), false)
 		return
 	}
 
@@ -749,7 +903,7 @@ func (m *Model) confirmArchive() {
 		m.archiveListName = ""
 		m.archiveCount = 0
 		m.archiveAll = false
-		m.setStatus("Erro ao arquivar: "+err.Error(), true)
+		m.setStatus(i18n.T("error.archive", i18n.Error(err)), true)
 		return
 	}
 	m.mode = modeNormal
@@ -759,109 +913,104 @@ func (m *Model) confirmArchive() {
 	m.archiveAll = false
 	m.taskCursor = 0
 	if m.showHistory {
-		m.persist(fmt.Sprintf("%d itens arquivados • u desfaz", count))
+		m.persist(i18n.T("status.archived_items", count))
 		return
 	}
-	m.persist(fmt.Sprintf("%d to-dos arquivados • u desfaz", count))
+	m.persist(i18n.T("status.archived_todos", count))
 }
 
 func (m *Model) toggleHistory() {
 	if m.focus != focusTasks {
-		m.setStatus("Histórico: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.history"), false)
 		return
 	}
 	if !m.showHistory && len(m.svc.ArchivedCompleted()) == 0 {
-		m.setStatus("Histórico vazio. Arquive concluídas com 'C'.", false)
+		m.setStatus(i18n.T("status.history_empty"), false)
 		return
 	}
 	m.showHistory = !m.showHistory
 	m.historyCursor = 0
 	if m.showHistory {
-		m.setStatus("Histórico de concluídas aberto", false)
+		m.showBoard = false
+		m.showAgenda = false
+		m.setStatus(i18n.T("status.history_opened"), false)
 	} else {
-		m.setStatus("Voltando para tarefas ativas", false)
+		m.setStatus(i18n.T("status.history_closed"), false)
 	}
 }
 
 func (m *Model) startDeleteAllConfirm() {
 	if m.focus != focusTasks {
-		m.setStatus("Deletar todos: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.delall"), false)
 		return
 	}
 	if m.showHistory {
-		m.setStatus("Histórico é somente leitura. Pressione 'h' para voltar.", false)
+		m.setStatus(i18n.T("status.history_read_only"), false)
 		return
 	}
 	list, ok := m.activeList()
 	if !ok {
-		m.setStatus("Nenhuma lista ativa", true)
+		m.setStatus(i18n.T("status.no_active_list"), true)
 		return
 	}
 	count := len(m.svc.Tasks(list.ID))
 	if count == 0 {
-		m.setStatus("Não há to-dos para deletar nesta lista", false)
+		m.setStatus(i18n.T("status.nothing_to_delete"), false)
 		return
 	}
 
 	m.mode = modeConfirmDelete
 	m.confirmKind = deleteAllTasks
 	m.confirmID = list.ID
-	m.confirmName = fmt.Sprintf("%s (%d to-dos)", list.Name, count)
+	m.confirmName = i18n.T("confirm.todo_count", list.Name, count)
 }
 
 func (m *Model) copyActiveTodos() {
 	if m.focus != focusTasks {
-		m.setStatus("Copiar to-dos: mude o foco para Tarefas (Tab)", false)
+		m.setStatus(i18n.T("focus.need_tasks.copy"), false)
 		return
 	}
 	if m.showHistory {
-		m.setStatus("Histórico é somente leitura. Pressione 'h' para voltar.", false)
+		m.setStatus(i18n.T("status.history_read_only"), false)
 		return
 	}
 	list, ok := m.activeList()
 	if !ok {
-		m.setStatus("Nenhuma lista ativa", true)
+		m.setStatus(i18n.T("status.no_active_list"), true)
 		return
 	}
 
 	tasks := m.svc.Tasks(list.ID)
 	if len(tasks) == 0 {
-		m.setStatus("Sem to-dos ativos para copiar", false)
+		m.setStatus(i18n.T("status.nothing_to_copy"), false)
 		return
 	}
 
-	parts := make([]string, 0, len(tasks))
-	for _, t := range tasks {
-		text := strings.TrimSpace(strings.ReplaceAll(t.Text, "\n", " "))
-		if text == "" {
-			continue
-		}
-		parts = append(parts, "- "+text)
-	}
-	if len(parts) == 0 {
-		m.setStatus("Sem to-dos válidos para copiar", false)
+	payload := exchange.MarkdownChecklist(tasks)
+	if payload == "" {
+		m.setStatus(i18n.T("status.nothing_valid_to_copy"), false)
 		return
 	}
+	parts := strings.Split(payload, "\n")
 
-	payload := strings.Join(parts, "\n")
 	if err := copyToClipboard(payload); err != nil {
-		m.setStatus("Falha ao copiar: "+err.Error(), true)
+		m.setStatus(i18n.T("error.copy", i18n.Error(err)), true)
 		return
 	}
-	m.setStatus(fmt.Sprintf("%d to-dos copiados para a área de transferência", len(parts)), false)
+	m.setStatus(i18n.T("status.copied", len(parts)), false)
 }
 
 func (m *Model) startDeleteConfirm() {
 	if m.focus == focusLists {
 		list, ok := m.activeList()
 		if !ok {
-			m.setStatus("Nenhuma lista selecionada", true)
+			m.setStatus(i18n.T("status.no_list_selected"), true)
 			return
 		}
 		count := len(m.svc.Tasks(list.ID))
 		name := list.Name
 		if count > 0 {
-			name = fmt.Sprintf("%s (%d tarefas)", list.Name, count)
+			name = i18n.T("confirm.list_count", list.Name, count)
 		}
 		m.mode = modeConfirmDelete
 		m.confirmKind = deleteList
@@ -871,13 +1020,13 @@ func (m *Model) startDeleteConfirm() {
 	}
 
 	if m.showHistory {
-		m.setStatus("Histórico é somente leitura. Pressione 'h' para voltar.", false)
+		m.setStatus(i18n.T("status.history_read_only"), false)
 		return
 	}
 
 	task, ok := m.selectedTask()
 	if !ok {
-		m.setStatus("Nenhuma tarefa selecionada", true)
+		m.setStatus(i18n.T("status.no_task_selected"), true)
 		return
 	}
 	m.mode = modeConfirmDelete
@@ -890,24 +1039,24 @@ func (m *Model) confirmDelete() {
 	switch m.confirmKind {
 	case deleteList:
 		if err := m.svc.DeleteList(m.confirmID); err != nil {
-			m.setStatus("Erro ao excluir lista: "+err.Error(), true)
+			m.setStatus(i18n.T("error.delete_list", i18n.Error(err)), true)
 			break
 		}
-		m.persist("Lista excluída • u desfaz")
+		m.persist(i18n.T("status.list_deleted"))
 	case deleteTask:
 		if err := m.svc.DeleteTask(m.confirmID); err != nil {
-			m.setStatus("Erro ao excluir tarefa: "+err.Error(), true)
+			m.setStatus(i18n.T("error.delete_task", i18n.Error(err)), true)
 			break
 		}
-		m.persist("Tarefa excluída • u desfaz")
+		m.persist(i18n.T("status.task_deleted"))
 	case deleteAllTasks:
 		count, err := m.svc.DeleteAllTasks(m.confirmID)
 		if err != nil {
-			m.setStatus("Erro ao deletar to-dos: "+err.Error(), true)
+			m.setStatus(i18n.T("error.delete_all", i18n.Error(err)), true)
 			break
 		}
 		m.taskCursor = 0
-		m.persist(fmt.Sprintf("%d to-dos deletados • u desfaz", count))
+		m.persist(i18n.T("status.deleted_todos", count))
 	}
 	m.mode = modeNormal
 	m.confirmKind = deleteNone
@@ -916,27 +1065,38 @@ func (m *Model) confirmDelete() {
 	m.ensureSelection()
 }
 
+// persist records the session context after a user action and reports
+// success. Saving happens in saveIfDirty, driven by the service events.
 func (m *Model) persist(success string) {
 	m.svc.MarkOnboardingSeen()
-	if err := m.svc.SetSessionContext(m.currentActiveListID(), m.sessionFocusValue()); err != nil {
-		m.setStatus("Falha ao atualizar contexto da sessão: "+err.Error(), true)
-		return
-	}
-	if err := store.Autosave(m.statePath, m.svc.State()); err != nil {
-		m.setStatus("Alteração aplicada, mas falhou ao salvar em disco: "+err.Error(), true)
+	if err := m.syncSession(); err != nil {
 		return
 	}
 	m.ensureSelection()
 	m.setStatus(success, false)
 }
 
-func (m *Model) persistContextSilently() error {
+func (m *Model) syncSession() error {
 	if err := m.svc.SetSessionContext(m.currentActiveListID(), m.sessionFocusValue()); err != nil {
-		m.setStatus("Falha ao atualizar contexto da sessão: "+err.Error(), true)
+		m.setStatus(i18n.T("error.session", i18n.Error(err)), true)
 		return err
 	}
-	if err := store.Autosave(m.statePath, m.svc.State()); err != nil {
-		m.setStatus("Falha ao salvar contexto da sessão: "+err.Error(), true)
+	return nil
+}
+
+func (m *Model) onEvent(app.Event) {
+	m.dirty.Store(true)
+}
+
+// saveIfDirty writes the state once if any service event arrived since the
+// last save; a failure replaces the status so it is not mistaken for success.
+func (m *Model) saveIfDirty() error {
+	if !m.dirty.Swap(false) {
+		return nil
+	}
+	if err := m.store.Autosave(m.svc.State()); err != nil {
+		m.dirty.Store(true)
+		m.setStatus(i18n.T("error.save", i18n.Error(err)), true)
 		return err
 	}
 	return nil
@@ -1028,6 +1188,9 @@ func (m *Model) sessionFocusValue() string {
 }
 
 func (m *Model) visibleTasks() []model.Task {
+	if m.showAgenda {
+		return m.agendaTasks()
+	}
 	list, ok := m.activeList()
 	if !ok {
 		return []model.Task{}
@@ -1100,54 +1263,37 @@ func (m *Model) indexOfTask(taskID string) int {
 
 func (m *Model) View() string {
 	if m.width == 0 || m.height == 0 {
-		return "carregando..."
+		return i18n.T("view.loading")
 	}
 
 	st := m.svc.State()
 	title := lipgloss.NewStyle().Bold(true).Render("todo-cli")
-	summary := fmt.Sprintf("foco: %s • filtro: %s", m.focus.String(), filterLabel(st.Filter))
+	summary := i18n.T("view.summary", m.focus.String(), filterLabel(st.Filter))
 	if st.Query != "" {
-		summary += " • busca: \"" + st.Query + "\""
+		summary += i18n.T("view.summary_query", st.Query)
 	}
 	if m.showHistory {
-		summary += " • histórico: ligado"
+		summary += i18n.T("view.summary_history")
 	}
 	header := lipgloss.JoinHorizontal(lipgloss.Left,
 		title,
-		lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("  "+summary),
+		m.theme.style(styleSummary).Render("  "+summary),
 	)
 
 	viewW := m.viewportWidth()
-	const paneGap = 1
-	const rightInset = 6
-	frameW := viewW - rightInset
-	if frameW < 40 {
-		frameW = viewW
-	}
-	frameContentW := frameW - 2
-	if frameContentW < 20 {
-		frameContentW = frameW
-	}
-
-	panelH := m.height - 6
-	if panelH < 8 {
-		panelH = 8
-	}
-	innerPaneH := panelH - 2
-	if innerPaneH < 6 {
-		innerPaneH = 6
-	}
+	frameContentW := frameContentWidth(viewW)
+	panelH, innerPaneH := m.panelHeights()
 
 	leftW, rightW := m.paneWidths(frameContentW, paneGap)
-	dividerColor := lipgloss.Color("240")
+	dividerStyle := m.theme.style(stylePanelBorder)
 	if m.focus == focusLists {
-		dividerColor = lipgloss.Color("33")
+		dividerStyle = m.theme.style(styleDividerLists)
 	} else if m.focus == focusTasks {
-		dividerColor = lipgloss.Color("70")
+		dividerStyle = m.theme.style(styleDividerTasks)
 	}
 	divider := strings.Repeat("│\n", innerPaneH)
 	divider = strings.TrimSuffix(divider, "\n")
-	divider = lipgloss.NewStyle().Foreground(dividerColor).Bold(true).Render(divider)
+	divider = dividerStyle.Bold(true).Render(divider)
 	split := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		m.renderListsPanel(leftW, innerPaneH),
@@ -1155,9 +1301,9 @@ func (m *Model) View() string {
 		m.renderTasksPanel(rightW, innerPaneH),
 	)
 
-	frameColor := lipgloss.Color("240")
+	frameColor := m.theme.color(stylePanelBorder)
 	if m.mode == modeNormal {
-		frameColor = lipgloss.Color("39")
+		frameColor = m.theme.color(stylePanelBorderActive)
 	}
 	panes := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -1173,16 +1319,16 @@ func (m *Model) View() string {
 
 	statusText := m.status
 	if statusText == "" {
-		statusText = "Pronto"
+		statusText = i18n.T("status.ready")
 	}
-	statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("70"))
+	statusStyle := m.theme.style(styleStatus)
 	if m.statusErr {
-		statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+		statusStyle = m.theme.style(styleStatusError)
 	}
 
-	rightHint := "? atalhos"
+	rightHint := i18n.T("view.help_hint", m.keys.keyFor(actionHelp))
 	if m.showHelp {
-		rightHint = "Esc/? fechar atalhos"
+		rightHint = i18n.T("view.help_close_hint", m.helpCloseKeys())
 	}
 	footerCore := m.renderFooter(statusText, statusStyle, rightHint, frameContentW)
 	footerLine := " " + footerCore + " "
@@ -1193,34 +1339,52 @@ func (m *Model) View() string {
 	promptLine := ""
 	switch m.mode {
 	case modeAddList:
-		promptLine = "Nova lista: " + m.input + "▌"
+		promptLine = i18n.T("prompt.add_list") + m.input.View()
 	case modeAddTask:
-		promptLine = "Nova tarefa: " + m.input + "▌"
+		promptLine = i18n.T("prompt.add_task") + m.input.View()
 	case modeRenameList:
-		promptLine = "Renomear lista: " + m.input + "▌"
+		promptLine = i18n.T("prompt.rename_list") + m.input.View()
 	case modeEditTask:
-		promptLine = "Editar tarefa: " + m.input + "▌"
+		promptLine = i18n.T("prompt.edit_task") + m.input.View()
 	case modeSearch:
-		promptLine = "Busca (/): " + m.input + "▌  (incremental; Enter confirma, Esc limpa)"
+		promptLine = i18n.T("prompt.search") + m.input.View() + i18n.T("prompt.search_hint")
+	case modeExportMarkdown:
+		promptLine = i18n.T("prompt.export_md") + m.input.View()
+	case modeImportMarkdown:
+		promptLine = i18n.T("prompt.import_md") + m.input.View()
+	case modeEditColumns:
+		promptLine = i18n.T("prompt.columns") + m.input.View()
+	case modeSetDue:
+		promptLine = i18n.T("prompt.due") + m.input.View()
+	case modeCommandPalette:
+		promptLine = i18n.T("prompt.command") + m.input.View()
+	case modeFinder:
+		promptLine = i18n.T("prompt.find") + m.input.View()
 	case modeConfirmDelete:
-		target := "item"
+		target := i18n.T("confirm.item")
 		if m.confirmKind == deleteList {
-			target = "lista"
+			target = i18n.T("confirm.list")
 		} else if m.confirmKind == deleteTask {
-			target = "tarefa"
+			target = i18n.T("confirm.task")
 		} else if m.confirmKind == deleteAllTasks {
-			target = "todos os to-dos"
+			target = i18n.T("confirm.all_todos")
 		}
-		promptLine = fmt.Sprintf("Excluir %s \"%s\"? [y/N]", target, m.confirmName)
+		promptLine = i18n.T("confirm.delete", target, m.confirmName)
+	case modeConfirmRestore:
+		if b, ok := m.selectedBackup(); ok {
+			promptLine = i18n.T("confirm.restore", b.ID)
+		}
+	case modeConfirmOverwrite:
+		promptLine = i18n.T("confirm.overwrite", m.overwritePath)
 	case modeConfirmArchive:
 		if m.archiveAll {
-			promptLine = fmt.Sprintf("Arquivar TODOS os %d to-dos da lista \"%s\"? [y/N]", m.archiveCount, m.archiveListName)
+			promptLine = i18n.T("confirm.archive_all", m.archiveCount, m.archiveListName)
 		} else {
-			promptLine = fmt.Sprintf("Arquivar %d concluídas da lista \"%s\"? [y/N]", m.archiveCount, m.archiveListName)
+			promptLine = i18n.T("confirm.archive_done", m.archiveCount, m.archiveListName)
 		}
 	}
 	if promptLine != "" {
-		promptLine = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Width(viewW).Render(promptLine)
+		promptLine = m.theme.style(stylePrompt).Width(viewW).Render(promptLine)
 	}
 
 	parts := []string{header}
@@ -1229,17 +1393,16 @@ func (m *Model) View() string {
 	}
 
 	if m.showHelp {
-		popupW := viewW - 8
-		if popupW > 96 {
-			popupW = 96
-		}
-		if popupW < 56 {
-			popupW = viewW - 2
-		}
-		if popupW < 40 {
-			popupW = 40
-		}
-		popup := m.renderHelpOverlay(popupW)
+		popup := m.renderHelpOverlay(popupWidth(viewW))
+		panes = lipgloss.Place(viewW, panelH, lipgloss.Center, lipgloss.Center, popup)
+	} else if m.mode == modeBackups || m.mode == modeConfirmRestore {
+		popup := m.renderBackupsOverlay(popupWidth(viewW))
+		panes = lipgloss.Place(viewW, panelH, lipgloss.Center, lipgloss.Center, popup)
+	} else if m.mode == modeCommandPalette {
+		popup := m.renderCommandPalette(popupWidth(viewW))
+		panes = lipgloss.Place(viewW, panelH, lipgloss.Center, lipgloss.Center, popup)
+	} else if m.mode == modeFinder {
+		popup := m.renderFinder(popupWidth(viewW))
 		panes = lipgloss.Place(viewW, panelH, lipgloss.Center, lipgloss.Center, popup)
 	}
 
@@ -1250,6 +1413,56 @@ func (m *Model) View() string {
 	return strings.Join(parts, "\n")
 }
 
+// paneGap is the column between the panels, where the divider goes.
+const paneGap = 1
+
+// frameContentWidth is the width inside the panels' frame.
+func frameContentWidth(viewW int) int {
+	const rightInset = 6
+	frameW := viewW - rightInset
+	if frameW < 40 {
+		frameW = viewW
+	}
+	frameContentW := frameW - 2
+	if frameContentW < 20 {
+		frameContentW = frameW
+	}
+	return frameContentW
+}
+
+// panelHeights returns the frame height and the height of the panels in it.
+func (m *Model) panelHeights() (panelH, innerPaneH int) {
+	panelH = m.height - 6
+	if panelH < 8 {
+		panelH = 8
+	}
+	innerPaneH = panelH - 2
+	if innerPaneH < 6 {
+		innerPaneH = 6
+	}
+	return panelH, innerPaneH
+}
+
+// panelRows is how many items fit in a panel below its title.
+func (m *Model) panelRows() int {
+	_, innerPaneH := m.panelHeights()
+	return innerPaneH - 1
+}
+
+func popupWidth(viewW int) int {
+	popupW := viewW - 8
+	if popupW > 96 {
+		popupW = 96
+	}
+	if popupW < 56 {
+		popupW = viewW - 2
+	}
+	if popupW < 40 {
+		popupW = 40
+	}
+	return popupW
+}
+
 func (m *Model) viewportWidth() int {
 	if m.width <= 0 {
 		return 1
@@ -1313,10 +1526,10 @@ func (m *Model) renderFooter(statusText string, statusStyle lipgloss.Style, righ
 	left := strings.TrimSpace(statusText)
 	right := strings.TrimSpace(rightHint)
 	if left == "" {
-		left = "Pronto"
+		left = i18n.T("status.ready")
 	}
 	if right == "" {
-		right = "? atalhos"
+		right = i18n.T("view.help_hint", m.keys.keyFor(actionHelp))
 	}
 
 	leftW := utf8.RuneCountInString(left)
@@ -1339,99 +1552,107 @@ func (m *Model) renderFooter(statusText string, statusStyle lipgloss.Style, righ
 		padding = 1
 	}
 
-	rightStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	rightStyle := m.theme.style(styleHint)
 	line := statusStyle.Render(left) + strings.Repeat(" ", padding) + rightStyle.Render(right)
 	return lipgloss.NewStyle().Width(width).Render(line)
 }
 
 func (m *Model) renderHelpOverlay(width int) string {
-	title := lipgloss.NewStyle().Bold(true).Render("Atalhos")
-	section := lipgloss.NewStyle().Foreground(lipgloss.Color("111")).Bold(true)
-	line := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-
-	rows := []string{
-		title,
-		"",
-		section.Render("Globais"),
-		line.Render("  Tab alterna foco • j/k navega • q sai"),
-		line.Render("  / busca • u desfaz • ? abre/fecha atalhos • Esc fecha"),
-		"",
-		section.Render("Listas (com foco em Listas)"),
-		line.Render("  a cria • r renomeia • c cor • J/K reordena • d exclui"),
-		line.Render("  Enter define lista ativa"),
-		"",
-		section.Render("Tarefas (com foco em Tarefas)"),
-		line.Render("  a cria • e edita • x conclui/reabre • 1..4 prioridade"),
-		line.Render("  J/K reordena • f filtro • y copia to-dos ativos"),
-		line.Render("  C arquiva concluídas • A arquiva todos • D deleta todos"),
-		line.Render("  h histórico"),
-	}
+	title := lipgloss.NewStyle().Bold(true).Render(i18n.T("help.title"))
+	section := m.theme.style(styleSection).Bold(true)
+	line := m.theme.style(styleText)
 
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("244")).
+		BorderForeground(m.theme.color(styleOverlayBorder)).
 		Padding(1, 2)
 
+	// Borda (2) + padding (4) + recuo das linhas (2).
+	lineW := width - 8
+	rows := []string{title}
+	for _, s := range helpSections {
+		rows = append(rows, "", section.Render(i18n.T(s.title)))
+		for _, l := range wrapItems(m.keys.describe(s.entries), " • ", lineW) {
+			rows = append(rows, line.Render("  "+l))
+		}
+	}
+
 	return style.Width(width).Render(strings.Join(rows, "\n"))
 }
 
 func (m *Model) renderOnboarding(width int) string {
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("63")).
+		BorderForeground(m.theme.color(styleOnboardingBorder)).
 		Padding(0, 1)
 	if width <= 0 {
 		width = m.viewportWidth()
 	}
-	text := "Primeiro uso:\n1) Em Listas: 'a' para criar lista\n2) Tab para Tarefas e 'a' para adicionar\n3) 'x' conclui, 'C' arquiva concluídas, 'h' abre histórico"
+	k := m.keys
+	text := i18n.T("help.onboarding",
+		k.keyFor(actionAdd), k.keyFor(actionFocus), k.keyFor(actionAdd),
+		k.keyFor(actionToggle), k.keyFor(actionArchiveDone), k.keyFor(actionHistory))
 	return style.Width(width).Render(text)
 }
 
 func (m *Model) contextualHelp() string {
 	switch m.mode {
-	case modeAddList, modeAddTask, modeRenameList, modeEditTask:
-		return "Digite texto • Enter confirmar • Esc cancelar"
+	case modeAddList, modeAddTask, modeRenameList, modeEditTask, modeEditColumns, modeSetDue:
+		return i18n.T("hint.input")
+	case modeExportMarkdown, modeImportMarkdown:
+		return i18n.T("hint.path")
 	case modeSearch:
-		return "Busca incremental • Digite para filtrar • Enter confirma • Esc limpa"
-	case modeConfirmDelete, modeConfirmArchive:
-		return "Confirmar ação • y confirma • n/Esc cancela"
-	}
-
+		return i18n.T("hint.search")
+	case modeConfirmDelete, modeConfirmArchive, modeConfirmRestore, modeConfirmOverwrite:
+		return i18n.T("hint.confirm")
+	case modeBackups:
+		return i18n.T("hint.backups")
+	case modeCommandPalette:
+		return i18n.T("hint.commands")
+	case modeFinder:
+		return i18n.T("hint.finder")
+	}
+
+	title, entries := i18n.T("hint.tasks"), tasksHint
 	if m.showHistory {
-		return "Histórico • j/k navegar • h voltar • Tab foco • u undo • q sair"
+		title, entries = i18n.T("hint.history"), historyHint
+	} else if m.showBoard && m.focus == focusTasks {
+		title, entries = i18n.T("hint.board"), boardHint
+	} else if m.showAgenda && m.focus == focusTasks {
+		title, entries = i18n.T("hint.agenda"), agendaHint
+	} else if m.focus == focusLists {
+		title, entries = i18n.T("hint.lists"), listsHint
 	}
-
-	if m.focus == focusLists {
-		return "Listas • a criar • r renomear • c cor • J/K reordenar • d excluir • Enter ativar • Tab tarefas • q sair"
-	}
-	return "Tarefas • a criar • e editar • x done • 1..4 prioridade • J/K reordenar • f filtro • / busca • C arquivar concluídas • h histórico • u undo"
+	return strings.Join(append([]string{title}, m.keys.describe(entries)...), " • ")
 }
 
 func (m *Model) renderListsPanel(width, height int) string {
 	lists := m.svc.Lists()
 	isActive := m.focus == focusLists
 
-	title := panelTitleStyled("Listas", isActive)
+	title := m.theme.panelTitle(i18n.T("panel.lists"), isActive)
 	totalOpen := 0
 	for _, l := range lists {
 		open, _, _ := m.listTaskStats(l.ID)
 		totalOpen += open
 	}
-	meta := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(fmt.Sprintf("%d listas • %d abertas", len(lists), totalOpen))
+	meta := m.theme.style(styleMuted).Render(i18n.T("panel.lists_meta", len(lists), totalOpen))
 
-	lines := make([]string, 0, len(lists)+2)
-	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Left, title, "  ", meta))
+	rows, scroll := m.scrollRows(height, len(lists), m.listCursor, &m.listOffset)
+	lines := make([]string, 0, rows+2)
+	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Left, title, "  ", meta, scroll))
 	if len(lists) == 0 {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render("Sem listas. Pressione 'a' para criar a primeira."))
+		lines = append(lines, m.theme.style(styleMuted).Render(i18n.T("panel.no_lists")))
 	} else {
-		for i, l := range lists {
+		for i := m.listOffset; i < m.listOffset+rows; i++ {
+			l := lists[i]
 			cursor := " "
 			if i == m.listCursor {
 				cursor = "▸"
 			}
 			dot := lipgloss.NewStyle().Foreground(colorForName(l.Color)).Render("●")
 			open, done, _ := m.listTaskStats(l.ID)
-			stats := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(fmt.Sprintf("%d•%d", open, done))
+			stats := m.theme.style(styleMuted).Render(fmt.Sprintf("%d•%d", open, done))
 			line := lipgloss.JoinHorizontal(lipgloss.Left,
 				cursor+" ",
 				dot+" ",
@@ -1442,7 +1663,7 @@ func (m *Model) renderListsPanel(width, height int) string {
 			if i == m.listCursor {
 				style := lipgloss.NewStyle().Bold(true)
 				if isActive {
-					style = style.Foreground(lipgloss.Color("229"))
+					style = style.Foreground(m.theme.color(styleSelected))
 				}
 				line = style.Render(line)
 			}
@@ -1450,17 +1671,19 @@ func (m *Model) renderListsPanel(width, height int) string {
 		}
 	}
 
-	panelStyle := lipgloss.NewStyle().
-		Width(width).
-		Height(height).
-		Padding(0, 1)
-	return panelStyle.Render(strings.Join(lines, "\n"))
+	return renderPanel(width, height, lines)
 }
 
 func (m *Model) renderTasksPanel(width, height int) string {
 	if m.showHistory {
 		return m.renderHistoryPanel(width, height)
 	}
+	if m.showBoard {
+		return m.renderBoardPanel(width, height)
+	}
+	if m.showAgenda {
+		return m.renderAgendaPanel(width, height)
+	}
 
 	list, hasList := m.activeList()
 	allTasksInList := []model.Task{}
@@ -1470,137 +1693,171 @@ func (m *Model) renderTasksPanel(width, height int) string {
 	tasks := m.visibleTasks()
 
 	isActive := m.focus == focusTasks
-	title := "Tarefas"
+	title := i18n.T("panel.tasks")
 	if hasList {
-		title = fmt.Sprintf("Tarefas — %s", list.Name)
+		title = i18n.T("panel.tasks_of", list.Name)
 	}
 
-	titleLine := panelTitleStyled(title, isActive)
+	titleLine := m.theme.panelTitle(title, isActive)
 	if hasList {
 		open, done, _ := m.listTaskStats(list.ID)
-		meta := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(fmt.Sprintf("%d abertas • %d concluídas", open, done))
+		meta := m.theme.style(styleMuted).Render(i18n.T("panel.tasks_meta", open, done))
 		titleLine = lipgloss.JoinHorizontal(lipgloss.Left, titleLine, "  ", meta)
 	}
+	rows, scroll := m.scrollRows(height, len(tasks), m.taskCursor, &m.taskOffset)
+	titleLine += scroll
 
-	lines := make([]string, 0, len(tasks)+3)
+	lines := make([]string, 0, rows+3)
 	lines = append(lines, titleLine)
 
 	if !hasList {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render("Sem lista ativa. Vá em Listas e pressione 'a'."))
+		lines = append(lines, m.theme.style(styleMuted).Render(i18n.T("panel.no_active_list")))
 	} else if len(tasks) == 0 {
 		state := m.svc.State()
 		switch {
 		case len(allTasksInList) == 0:
-			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render("Lista vazia. Pressione 'a' para adicionar tarefa."))
+			lines = append(lines, m.theme.style(styleMuted).Render(i18n.T("panel.empty_list")))
 		case strings.TrimSpace(state.Query) != "":
-			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render("Nenhuma tarefa corresponde à busca/filtro atual."))
+			lines = append(lines, m.theme.style(styleMuted).Render(i18n.T("panel.no_match_query")))
 		default:
-			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render("Nenhuma tarefa para o filtro atual (use 'f')."))
+			lines = append(lines, m.theme.style(styleMuted).Render(i18n.T("panel.no_match_filter")))
 		}
 	} else {
-		for i, t := range tasks {
-			cursor := " "
-			if i == m.taskCursor {
-				cursor = "›"
-			}
-			check := "[ ]"
-			if t.Done {
-				check = "[x]"
-			}
-			pri := priorityIndicator(t.Priority)
+		day := dateOf(time.Now())
+		for i := m.taskOffset; i < m.taskOffset+rows; i++ {
+			lines = append(lines, m.renderTaskRow(tasks[i], i == m.taskCursor, isActive, day))
+		}
+	}
 
-			cursorStyle := lipgloss.NewStyle()
-			checkStyle := lipgloss.NewStyle()
-			textStyle := lipgloss.NewStyle()
+	return renderPanel(width, height, lines)
+}
 
-			// Evita glitch visual com ANSI em alguns terminais ao combinar
-			// Strikethrough + segmentos já coloridos (indicador de prioridade).
-			if t.Done {
-				textStyle = textStyle.Faint(true)
-			}
-			if i == m.taskCursor {
-				cursorStyle = cursorStyle.Bold(true)
-				checkStyle = checkStyle.Bold(true)
-				textStyle = textStyle.Bold(true)
-				if isActive {
-					sel := lipgloss.Color("229")
-					cursorStyle = cursorStyle.Foreground(sel)
-					checkStyle = checkStyle.Foreground(sel)
-					textStyle = textStyle.Foreground(sel)
-				}
-			}
+// renderTaskRow draws one task of the tasks panel or the agenda, with its
+// pin and due date badges.
+func (m *Model) renderTaskRow(t model.Task, selected, isActive bool, day time.Time) string {
+	cursor := " "
+	if selected {
+		cursor = "›"
+	}
+	check := "[ ]"
+	if t.Done {
+		check = "[x]"
+	}
+	pri := m.theme.priorityIndicator(t.Priority)
 
-			line := lipgloss.JoinHorizontal(lipgloss.Left,
-				cursorStyle.Render(cursor+" "),
-				checkStyle.Render(check+" "),
-				pri+" ",
-				textStyle.Render(t.Text),
-			)
-			lines = append(lines, line)
+	cursorStyle := lipgloss.NewStyle()
+	checkStyle := lipgloss.NewStyle()
+	textStyle := lipgloss.NewStyle()
+
+	// Evita glitch visual com ANSI em alguns terminais ao combinar
+	// Strikethrough + segmentos já coloridos (indicador de prioridade).
+	if t.Done {
+		textStyle = m.theme.style(styleDone)
+	}
+	if selected {
+		cursorStyle = cursorStyle.Bold(true)
+		checkStyle = checkStyle.Bold(true)
+		textStyle = textStyle.Bold(true)
+		if isActive {
+			sel := m.theme.color(styleSelected)
+			cursorStyle = cursorStyle.Foreground(sel)
+			checkStyle = checkStyle.Foreground(sel)
+			textStyle = textStyle.Foreground(sel)
 		}
 	}
 
-	panelStyle := lipgloss.NewStyle().
-		Width(width).
-		Height(height).
-		Padding(0, 1)
-	return panelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Left,
+		cursorStyle.Render(cursor+" "),
+		checkStyle.Render(check+" "),
+		pri+" ",
+		textStyle.Render(t.Text),
+		m.taskBadges(t, day),
+	)
 }
 
 func (m *Model) renderHistoryPanel(width, height int) string {
 	entries := m.archivedForDisplay()
 	list, hasList := m.activeList()
 	isActive := m.focus == focusTasks
-	title := "Histórico de concluídas"
+	title := i18n.T("panel.history")
 	if hasList {
-		title = fmt.Sprintf("Histórico de concluídas — %s", list.Name)
+		title = i18n.T("panel.history_of", list.Name)
 	}
 
-	titleLine := panelTitleStyled(title, isActive)
-	meta := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(fmt.Sprintf("%d itens", len(entries)))
-	titleLine = lipgloss.JoinHorizontal(lipgloss.Left, titleLine, "  ", meta)
+	titleLine := m.theme.panelTitle(title, isActive)
+	meta := m.theme.style(styleMuted).Render(i18n.T("panel.history_meta", len(entries)))
+	rows, scroll := m.scrollRows(height, len(entries), m.historyCursor, &m.historyOffset)
+	titleLine = lipgloss.JoinHorizontal(lipgloss.Left, titleLine, "  ", meta, scroll)
 
-	lines := make([]string, 0, len(entries)+2)
+	lines := make([]string, 0, rows+2)
 	lines = append(lines, titleLine)
 	if len(entries) == 0 {
-		emptyMsg := "Histórico vazio. Use 'C' para arquivar concluídas da lista ativa."
+		emptyMsg := i18n.T("panel.history_empty")
 		if hasList {
-			emptyMsg = "Sem itens arquivados para esta lista. Use 'C' ou 'A' na lista ativa."
+			emptyMsg = i18n.T("panel.history_empty_list")
 		}
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(emptyMsg))
+		lines = append(lines, m.theme.style(styleMuted).Render(emptyMsg))
 	} else {
-		for i, e := range entries {
+		for i := m.historyOffset; i < m.historyOffset+rows; i++ {
+			e := entries[i]
 			cursor := " "
 			if i == m.historyCursor {
 				cursor = "▸"
 			}
-			line := fmt.Sprintf("%s %s %s (%s • %s)", cursor, priorityIndicator(e.Priority), e.TaskText, e.OriginList, e.DoneAt.Local().Format("02/01 15:04"))
-			style := lipgloss.NewStyle().Faint(true)
+			line := fmt.Sprintf("%s %s %s (%s • %s)", cursor, m.theme.priorityIndicator(e.Priority), e.TaskText, e.OriginList, e.DoneAt.Local().Format(i18n.T("format.datetime")))
+			style := m.theme.style(styleDone)
 			if i == m.historyCursor {
 				style = lipgloss.NewStyle().Bold(true)
 				if isActive {
-					style = style.Foreground(lipgloss.Color("229"))
+					style = style.Foreground(m.theme.color(styleSelected))
 				}
 			}
 			lines = append(lines, style.Render(line))
 		}
 	}
 
-	panelStyle := lipgloss.NewStyle().
-		Width(width).
-		Height(height).
-		Padding(0, 1)
-	return panelStyle.Render(strings.Join(lines, "\n"))
+	return renderPanel(width, height, lines)
 }
 
-func panelTitleStyled(title string, active bool) string {
-	base := lipgloss.NewStyle().Bold(true)
-	if !active {
-		return base.Render(title)
+// scrollRows fits total items below a panel title of the given height,
+// moving *offset as little as possible to keep the cursor visible. It
+// returns how many rows to render from *offset and, when not everything
+// fits, a position indicator for the title.
+func (m *Model) scrollRows(height, total, cursor int, offset *int) (int, string) {
+	rows := max(height-1, 1)
+	*offset = scrollWindow(*offset, cursor, rows, total)
+	if total <= rows {
+		return total, ""
 	}
-	text := base.Foreground(lipgloss.Color("229")).Render(title)
-	marker := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10")).Render("*")
-	return lipgloss.JoinHorizontal(lipgloss.Left, text, " ", marker)
+	indicator := i18n.T("panel.scroll", *offset+1, *offset+rows, total)
+	return rows, "  " + m.theme.style(styleMuted).Render(indicator)
+}
+
+func scrollWindow(offset, cursor, rows, total int) int {
+	if rows <= 0 || total <= rows {
+		return 0
+	}
+	if cursor < offset {
+		offset = cursor
+	}
+	if cursor >= offset+rows {
+		offset = cursor - rows + 1
+	}
+	return clamp(offset, 0, total-rows)
+}
+
+// renderPanel pads lines to the panel size. Lines are cut at the panel
+// width instead of wrapping, so each item takes exactly one row.
+func renderPanel(width, height int, lines []string) string {
+	contentW := width - 2
+	for i, l := range lines {
+		lines[i] = ansi.Truncate(l, contentW, "…")
+	}
+	return lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
 }
 
 func (m *Model) listTaskStats(listID string) (open int, done int, total int) {
@@ -1626,59 +1883,27 @@ func matchesFilter(filter model.Filter, done bool) bool {
 	}
 }
 
-func priorityIndicator(p model.Priority) string {
-	s := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("•")
-	switch p {
-	case model.PriorityLow:
-		s = lipgloss.NewStyle().Foreground(lipgloss.Color("114")).Render("●")
-	case model.PriorityMedium:
-		s = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render("●")
-	case model.PriorityHigh:
-		s = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render("●")
-	}
-	return s
-}
-
 func priorityLabel(p model.Priority) string {
 	switch p {
 	case model.PriorityLow:
-		return "baixa"
+		return i18n.T("priority.low")
 	case model.PriorityMedium:
-		return "média"
+		return i18n.T("priority.medium")
 	case model.PriorityHigh:
-		return "alta"
+		return i18n.T("priority.high")
 	default:
-		return "nenhuma"
+		return i18n.T("priority.none")
 	}
 }
 
 func filterLabel(f model.Filter) string {
 	switch f {
 	case model.FilterTodo:
-		return "abertas"
+		return i18n.T("filter.todo")
 	case model.FilterDone:
-		return "concluídas"
-	default:
-		return "todas"
-	}
-}
-
-func colorForName(name string) lipgloss.Color {
-	switch strings.ToLower(strings.TrimSpace(name)) {
-	case "blue":
-		return lipgloss.Color("12")
-	case "green":
-		return lipgloss.Color("10")
-	case "yellow":
-		return lipgloss.Color("11")
-	case "magenta", "purple":
-		return lipgloss.Color("13")
-	case "cyan":
-		return lipgloss.Color("14")
-	case "red":
-		return lipgloss.Color("9")
+		return i18n.T("filter.done")
 	default:
-		return lipgloss.Color("7")
+		return i18n.T("filter.all")
 	}
 }
 
@@ -1701,7 +1926,7 @@ func copyToClipboard(text string) error {
 		go runClipboardCommand(c.name, c.args, text)
 		return nil
 	}
-	return fmt.Errorf("nenhum comando de clipboard disponível (instale wl-copy ou xclip)")
+	return i18n.Errorf("error.no_clipboard")
 }
 
 func runClipboardCommand(name string, args []string, text string) {
@@ -1726,6 +1951,28 @@ func truncateRunes(s string, max int) string {
 	return string(r[:max-1]) + "…"
 }
 
+// wrapItems joins items with sep into lines no wider than width.
+func wrapItems(items []string, sep string, width int) []string {
+	var lines []string
+	current := ""
+	for _, item := range items {
+		if current == "" {
+			current = item
+			continue
+		}
+		if lipgloss.Width(current+sep+item) > width {
+			lines = append(lines, current)
+			current = item
+			continue
+		}
+		current += sep + item
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
 func clamp(v, min, max int) int {
 	if v < min {
 		return min
@@ -1735,11 +1982,3 @@ func clamp(v, min, max int) int {
 	}
 	return v
 }
-
-func trimLastRune(s string) string {
-	if s == "" {
-		return s
-	}
-	_, size := utf8.DecodeLastRuneInString(s)
-	return s[:len(s)-size]
-}